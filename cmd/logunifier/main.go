@@ -5,12 +5,13 @@ import (
 	"github.com/suikast42/logunifier/internal/bootstrap"
 	"github.com/suikast42/logunifier/internal/config"
 	"github.com/suikast42/logunifier/internal/health"
-	"github.com/suikast42/logunifier/internal/streams/connectors"
-	"github.com/suikast42/logunifier/internal/streams/connectors/lokishipper"
-	"github.com/suikast42/logunifier/internal/streams/ingress"
-	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
-	"github.com/suikast42/logunifier/internal/streams/ingress/journald"
-	"github.com/suikast42/logunifier/internal/streams/process"
+	"github.com/suikast42/logunifier/internal/pipeline"
+	"github.com/suikast42/logunifier/internal/streams/ingress/elastic"
+	"github.com/suikast42/logunifier/internal/streams/ingress/filetail"
+	"github.com/suikast42/logunifier/internal/streams/ingress/httpingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/loki"
+	"github.com/suikast42/logunifier/internal/streams/ingress/splunk"
+	"github.com/suikast42/logunifier/internal/streams/ingress/syslog"
 	internalPatterns "github.com/suikast42/logunifier/pkg/patterns"
 	// https://levelup.gitconnected.com/know-gomaxprocs-before-deploying-your-go-app-to-kubernetes-7a458fb63af1
 	_ "go.uber.org/automaxprocs"
@@ -25,12 +26,9 @@ func main() {
 
 	//fmt.Printf("GRPC_GO_LOG_VERBOSITY_LEVEL: %s\n", os.Getenv("GRPC_GO_LOG_VERBOSITY_LEVEL")) // 99
 	//fmt.Printf("GRPC_GO_LOG_SEVERITY_LEVEL: %s\n", os.Getenv("GRPC_GO_LOG_SEVERITY_LEVEL"))  // info
-	processChannelEcs := make(chan ingress.IngressMsgContext, bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
-	processChannelJournalD := make(chan ingress.IngressMsgContext, bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
-	egressChannelLoki := make(chan connectors.EgressMsgContext, 4096)
 	//ctx, cancelFunc := context.WithCancel(context.Background())
 	// Listen on os exit signals
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 	//Read the config file -> program flags
 	config.ReadConfigs()
@@ -62,112 +60,40 @@ func main() {
 		os.Exit(1)
 	}
 
-	//Stream definitions
-	const (
-		streamNameLogStreamIngress = "LogStreamIngress"
-		streamNameLogStreamEgress  = "LogStreamEgress"
-	)
-
-	streamDefinitions := make(map[string]*bootstrap.NatsStreamConfiguration)
-
-	streamDefinitions[streamNameLogStreamIngress] = &bootstrap.NatsStreamConfiguration{
-		StreamConfiguration: bootstrap.StreamConfig(streamNameLogStreamIngress,
-			"Ingress stream for unify and enrich logs from various formats to ecs",
-			[]string{
-				cfg.IngressNatsJournald(),
-				//cfg.IngresNatsTest(),
-				cfg.IngressNatsNativeEcs(),
-				//cfg.IngressNatsDocker(),
-			}),
+	// Streams, consumers and process channels are declared by the pipeline definition
+	definition, err := pipeline.Read(cfg)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Can't read pipeline definition %s", cfg.PipelineConfig())
+		os.Exit(1)
 	}
-
-	streamDefinitions[streamNameLogStreamEgress] = &bootstrap.NatsStreamConfiguration{
-		StreamConfiguration: bootstrap.StreamConfig(streamNameLogStreamEgress,
-			"Egress stream that contains ecs logs in json format for ship in various sinks",
-			[]string{
-				cfg.EgressSubjectEcs(),
-			}),
+	// The listeners publish to their ingress subject. Without a pipeline on it the logs are never consumed
+	listeners := map[string]string{}
+	if cfg.LokiIngressPort() > 0 {
+		listeners["loki push api"] = cfg.IngressNatsLoki()
 	}
-
-	const (
-		ingressConsumerTest      = "ConsumerIngressTest"
-		ingressConsumerJournalD  = "ConsumerIngressJournalD"
-		ingressConsumerNativeEcs = "ConsumerIngressEcsNative"
-		//ingressConsumerDocker   = "ConsumerIngressDocker"
-		egressLokiShipper = "ConsumerEgressLokiShipper"
-	)
-
-	// Ingress stream Consumer configuration
-	streamConsumerDefinitions := make(map[string]*bootstrap.NatsConsumerConfiguration)
-
-	//streamConsumerDefinitions[ingressConsumerTest] = &bootstrap.NatsConsumerConfiguration{
-	//	ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
-	//		ingressConsumerTest,
-	//		ingressConsumerTest+"_Group",
-	//		streamDefinitions[streamNameLogStreamIngress].StreamConfiguration,
-	//		cfg.IngresNatsTest(),
-	//	),
-	//	StreamName: streamNameLogStreamIngress,
-	//	MsgHandler: bootstrap.IngressMsgHandler(processChannel, &testingress.TestEcsConverter{}),
-	//}
-	streamConsumerDefinitions[ingressConsumerJournalD] = &bootstrap.NatsConsumerConfiguration{
-		ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
-			ingressConsumerJournalD,
-			ingressConsumerJournalD+"_Group",
-			streamDefinitions[streamNameLogStreamIngress].StreamConfiguration,
-			cfg.IngressNatsJournald(),
-		),
-		StreamName: streamNameLogStreamIngress,
-		MsgHandler: bootstrap.IngressMsgHandler(processChannelJournalD, &journald.JournaldDToEcsConverter{}),
+	if cfg.ElasticIngressPort() > 0 {
+		listeners["elasticsearch bulk api"] = cfg.IngressNatsNativeEcs()
 	}
-
-	streamConsumerDefinitions[ingressConsumerNativeEcs] = &bootstrap.NatsConsumerConfiguration{
-		ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
-			ingressConsumerNativeEcs,
-			ingressConsumerNativeEcs+"_Group",
-			streamDefinitions[streamNameLogStreamIngress].StreamConfiguration,
-			cfg.IngressNatsNativeEcs(),
-		),
-		StreamName: streamNameLogStreamIngress,
-		MsgHandler: bootstrap.IngressMsgHandler(processChannelEcs, &ecs.EcsWrapper{}),
+	if cfg.SplunkIngressPort() > 0 {
+		listeners["splunk hec"] = cfg.IngressNatsSplunk()
 	}
-
-	streamConsumerDefinitions[egressLokiShipper] = &bootstrap.NatsConsumerConfiguration{
-		ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
-			egressLokiShipper,
-			egressLokiShipper+"_Group",
-			streamDefinitions[streamNameLogStreamEgress].StreamConfiguration,
-			cfg.EgressSubjectEcs(),
-		),
-		StreamName: streamNameLogStreamEgress,
-		MsgHandler: bootstrap.EgressMessageHandler(egressChannelLoki),
+	if len(cfg.FileTailSources()) > 0 {
+		listeners["file tail"] = cfg.IngressNatsFileTail()
 	}
-
-	//streamConsumerDefinitions[ingressConsumerDocker] = bootstrap.NatsConsumerConfiguration{
-	//	ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
-	//		ingressConsumerDocker,
-	//		ingressConsumerDocker+"_Group",
-	//		streamDefinitions[streamNameLogStreamIngress].StreamConfiguration,
-	//		cfg.IngressNatsDocker(),
-	//	),
-	//	StreamName: streamNameLogStreamIngress,
-	//	MsgHandler: bootstrap.IngressMsgHandler(processChannel, &dockerlogs.DockerToEcsConverter{}),
-	//}
-	// Egress stream Consumer configuration
-	lokiShipper := lokishipper.NewLokiShipper(cfg)
-	lokiShipper.Connect()
-	// Strat go channel receiver
-	lokiShipper.StartReceive(egressChannelLoki)
+	if cfg.SyslogTcpPort() > 0 || cfg.SyslogUdpPort() > 0 {
+		listeners["syslog"] = cfg.IngressNatsSyslog()
+	}
+	for listener, subject := range listeners {
+		if !definition.HasPipelineFor(subject) {
+			logger.Error().Msgf("The %s listener publishes to %s but no pipeline consumes that subject", listener, subject)
+			os.Exit(1)
+		}
+	}
+	pipelines := pipeline.Build(cfg, definition)
 
 	logger.Info().Msgf("Starting with config: %s", cfg.String())
 
-	err = process.Start(processChannelEcs, "EcsLogChannel", cfg.EgressSubjectEcs(), bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
-	if err != nil {
-		logger.Error().Err(err).Stack().Msg("Can't start process channel")
-		os.Exit(1)
-	}
-
-	err = process.Start(processChannelJournalD, "JournalDLogChannel", cfg.EgressSubjectEcs(), bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
+	err = pipelines.Start()
 	if err != nil {
 		logger.Error().Err(err).Stack().Msg("Can't start process channel")
 		os.Exit(1)
@@ -175,7 +101,7 @@ func main() {
 
 	var dialer *bootstrap.NatsDialer
 	go func() {
-		dialer, err = bootstrap.New(streamDefinitions, streamConsumerDefinitions)
+		dialer, err = bootstrap.New(pipelines.Streams(), pipelines.Consumers())
 		if err != nil {
 			logger.Error().Err(err).Msg("Instantiation error during bootstrap")
 			os.Exit(1)
@@ -186,7 +112,46 @@ func main() {
 			logger.Error().Err(err).Stack().Msg("Can't connect to nats")
 			os.Exit(1)
 		}
-		err = health.Start("/health", 3000, dialer, lokiShipper)
+		publisher := bootstrap.NewIngressPublisher(time.Second*time.Duration(cfg.AckTimeoutS()), bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
+		if cfg.LokiIngressPort() > 0 {
+			err = httpingress.Start("loki push api", cfg.LokiIngressPort(), loki.NewPushHandler(cfg.IngressNatsLoki(), publisher).Mux())
+			if err != nil {
+				logger.Error().Err(err).Msg("Can't start loki push api")
+				os.Exit(1)
+			}
+		}
+		if cfg.ElasticIngressPort() > 0 {
+			// The documents are native ecs logs
+			err = httpingress.Start("elasticsearch bulk api", cfg.ElasticIngressPort(), elastic.NewBulkHandler(cfg.IngressNatsNativeEcs(), cfg.ElasticVersion(), publisher).Mux())
+			if err != nil {
+				logger.Error().Err(err).Msg("Can't start elasticsearch bulk api")
+				os.Exit(1)
+			}
+		}
+		if cfg.SplunkIngressPort() > 0 {
+			err = httpingress.Start("splunk hec", cfg.SplunkIngressPort(), splunk.NewHecHandler(cfg.IngressNatsSplunk(), cfg.SplunkTokens(), publisher).Mux())
+			if err != nil {
+				logger.Error().Err(err).Msg("Can't start splunk hec")
+				os.Exit(1)
+			}
+		}
+		if len(cfg.FileTailSources()) > 0 {
+			sources, err := filetail.ParseSources(cfg.FileTailSources())
+			if err != nil {
+				logger.Error().Err(err).Msg("Can't read file tail sources")
+				os.Exit(1)
+			}
+			filetail.NewTailer(cfg.IngressNatsFileTail(), sources, cfg.FileTailCheckpoint(), time.Millisecond*time.Duration(cfg.FileTailIntervalMs()), publisher).Start()
+		}
+		if cfg.SyslogTcpPort() > 0 || cfg.SyslogUdpPort() > 0 {
+			certFile, keyFile := cfg.SyslogTls()
+			err = syslog.NewListener(cfg.IngressNatsSyslog(), publisher).Start(cfg.SyslogTcpPort(), cfg.SyslogUdpPort(), certFile, keyFile)
+			if err != nil {
+				logger.Error().Err(err).Msg("Can't start syslog listener")
+				os.Exit(1)
+			}
+		}
+		err = health.Start("/health", 3000, dialer, pipelines.Sinks())
 		if err != nil {
 			logger.Error().Err(err).Stack().Msg("Can't start health check endpoint")
 			os.Exit(1)
@@ -201,15 +166,7 @@ func main() {
 			if err != nil {
 				logger.Error().Err(err).Stack().Msg("Can't disconnect from nats")
 			}
-			if processChannelEcs != nil {
-				close(processChannelEcs)
-			}
-			if processChannelJournalD != nil {
-				close(processChannelJournalD)
-			}
-			if lokiShipper != nil {
-				lokiShipper.DisConnect()
-			}
+			pipelines.Stop()
 			os.Exit(1)
 		case <-time.After(time.Second * 1):
 			if cfg.PingLog() {
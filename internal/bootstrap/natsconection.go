@@ -295,7 +295,11 @@ func (nd *NatsDialer) upsertStreams(nc *nats.Conn) error {
 			logger.Info().Msgf("Connected to stream streamName: %s", streamInfo.Config.Name)
 		} else {
 			// Update a stream
-			updateStreamInfo, err := js.UpdateStream(&definition.StreamConfiguration)
+			update := StreamUpdate(streamInfo.Config, definition.StreamConfiguration)
+			if update.MaxConsumers > 0 && definition.StreamConfiguration.MaxConsumers > update.MaxConsumers {
+				logger.Warn().Msgf("Stream %s keeps its max consumers %d. %d consumers are declared", update.Name, update.MaxConsumers, definition.StreamConfiguration.MaxConsumers)
+			}
+			updateStreamInfo, err := js.UpdateStream(&update)
 			if err != nil {
 				logger.Error().Err(err).Msgf("Can't update stream %s", definition.StreamConfiguration.Name)
 				return err
@@ -369,7 +373,7 @@ func (nd *NatsDialer) startSubscriptions(definition *NatsConsumerConfiguration,
 		logger.Error().Err(err).Msg("startSubscriptions: Can't create JetStream for ")
 		return err
 	}
-	logger.Info().Msgf("Start subscription for consumer %s at stream %s and subject %s", definition.ConsumerConfiguration.Name, definition.ConsumerConfiguration.FilterSubject)
+	logger.Info().Msgf("Start subscription for consumer %s at stream %s and subject %s", definition.ConsumerConfiguration.Name, definition.StreamName, definition.ConsumerConfiguration.FilterSubject)
 	_, exists := nd.streamConfigurations[definition.StreamName]
 	if !exists {
 		return errors.New(fmt.Sprintf("No stream configuration found for client configuration %+v ", definition))
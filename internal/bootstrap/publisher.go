@@ -0,0 +1,89 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"sync"
+	"time"
+)
+
+// IngressPublisher publishes messages received by an ingress endpoint (for example http) to the ingress stream
+// The producer stream is created lazily after the NatsDialer is connected
+type IngressPublisher struct {
+	lock       sync.Mutex
+	stream     nats.JetStreamContext
+	nc         *nats.Conn
+	ackTimeout time.Duration
+	maxPending int
+}
+
+var errNotConnected = errors.New("not connected to nats yet")
+
+func NewIngressPublisher(ackTimeout time.Duration, maxPending int) *IngressPublisher {
+	return &IngressPublisher{
+		ackTimeout: ackTimeout,
+		maxPending: maxPending,
+	}
+}
+
+// Publish publishes all messages async and waits for the acks of the stream
+// Returns an error if at least one message is not accepted
+func (p *IngressPublisher) Publish(msgs ...*nats.Msg) error {
+	stream, err := p.producer()
+	if err != nil {
+		return err
+	}
+	acks := make([]nats.PubAckFuture, 0, len(msgs))
+	for _, msg := range msgs {
+		ack, err := stream.PublishMsgAsync(msg)
+		if err != nil {
+			return fmt.Errorf("can't publish message to %s: %w", msg.Subject, err)
+		}
+		acks = append(acks, ack)
+	}
+	var result error
+	for _, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			result = errors.Join(result, fmt.Errorf("can't publish message to %s: %w", ack.Msg().Subject, err))
+		case <-time.After(p.ackTimeout):
+			result = errors.Join(result, fmt.Errorf("timeout on publish message to %s after %v", ack.Msg().Subject, p.ackTimeout))
+		}
+	}
+	return result
+}
+
+// MaxPayload the max payload of the nats server or an error if not connected yet
+func (p *IngressPublisher) MaxPayload() (int64, error) {
+	_, err := p.producer()
+	if err != nil {
+		return 0, err
+	}
+	return p.nc.MaxPayload(), nil
+}
+
+func (p *IngressPublisher) producer() (nats.JetStreamContext, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.stream != nil {
+		return p.stream, nil
+	}
+	dialer, err := Intance()
+	if err != nil {
+		return nil, errNotConnected
+	}
+	nc := dialer.ProducerConnection()
+	if nc == nil {
+		return nil, errNotConnected
+	}
+	stream, err := ProducerStream(context.Background(), nc, p.maxPending)
+	if err != nil {
+		return nil, err
+	}
+	p.nc = nc
+	p.stream = stream
+	return stream, nil
+}
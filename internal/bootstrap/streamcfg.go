@@ -32,6 +32,13 @@ func StreamConfig(streamName string, description string, subjects []string) nats
 	}
 }
 
+// StreamUpdate the declared configuration of an existing stream
+// The max consumers of an existing stream are kept. The nats server rejects to change them
+func StreamUpdate(existing nats.StreamConfig, declared nats.StreamConfig) nats.StreamConfig {
+	declared.MaxConsumers = existing.MaxConsumers
+	return declared
+}
+
 const (
 	QueueSubscribeConsumerGroupConfigMaxAckPending = 32 * 1024
 )
@@ -58,17 +65,28 @@ func QueueSubscribeConsumerGroupConfig(name string, consumerGroup string, stream
 	}
 }
 
+// IngressMsgHandler converts a nats message to one or more ingress.IngressMsgContext and pushes them to the process channel
+// Compressed payloads are decompressed by the Content-Encoding header before the conversion
+// Batches of log entries in one message are split by the ingress.BatchConverter
+// The message is acked by the last processed context
 func IngressMsgHandler(pushChannel chan<- ingress.IngressMsgContext, metaLogConverter ingress.MetaLogConverter) nats.MsgHandler {
+	converter := ingress.NewBatchConverter(metaLogConverter)
 	return func(msg *nats.Msg) {
-		log := metaLogConverter.ConvertToMetaLog(msg)
-		if !log.Skip {
-			pushChannel <- log
+		var converted []ingress.IngressMsgContext
+		decompressed, err := ingress.Decompress(msg)
+		if err != nil {
+			// The decompression error is shipped to the output
+			converted = []ingress.IngressMsgContext{ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)}
 		} else {
-			err := msg.Ack()
-			if err != nil {
-				logger := config.Logger()
-				logger.Error().Err(err).Msg("Can't ack message")
-			}
+			converted = converter.ConvertToMetaLogs(decompressed)
+		}
+		logs, err := ingress.Share(msg, converted)
+		if err != nil {
+			logger := config.Logger()
+			logger.Error().Err(err).Msg("Can't ack message")
+		}
+		for _, log := range logs {
+			pushChannel <- log
 		}
 	}
 }
@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamUpdate(t *testing.T) {
+	existing := StreamConfig("LogStreamIngress", "Ingress stream", []string{"ingress.logs.journald", "ingress.logs.ecs"})
+	declared := StreamConfig("LogStreamIngress", "Ingress stream", []string{"ingress.logs.journald", "ingress.logs.ecs", "ingress.logs.loki"})
+	declared.MaxConsumers = 13
+	declared.MaxAge = time.Hour
+	update := StreamUpdate(existing, declared)
+	if update.MaxConsumers != existing.MaxConsumers {
+		t.Errorf("Expected the max consumers %d of the existing stream but got %d", existing.MaxConsumers, update.MaxConsumers)
+	}
+	if len(update.Subjects) != 3 || update.MaxAge != time.Hour {
+		t.Errorf("Expected the declared subjects and max age but got %v %v", update.Subjects, update.MaxAge)
+	}
+}
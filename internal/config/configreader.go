@@ -17,22 +17,49 @@ func ReadConfigs() {
 	fs := flag.NewFlagSet("logunifer", flag.ContinueOnError)
 
 	var (
-		natsServers             arrayFlags
-		lokiServers             arrayFlags
-		pingLog                 = fs.Bool("pingLog", false, "log every second a ping in debug level")
-		ingressSubjectJournalD  = fs.String("ingressSubjectJournalD", "ingress.logs.journald", "ingress subject journald logs shipped by vector")
-		ingressSubjectNativeEcs = fs.String("ingressSubjectNativeEcs", "ingress.logs.ecs", "ingress subject native ecs logs shipped directly to ingress")
-		//ingressSubjectDocker   = fs.String("ingressSubjectDocker", "ingress.logs.docker", "ingress subject docker container logs shipped by vector")
-		ingressSubjectTest = fs.String("ingressSubjectTest", "ingress.logs.test", "Nats subscription for test logs")
-		egressSubjectEcs   = fs.String("egressSubjectEcs", "egress.logs.ecs", "Standardized logs output")
-		loglevel           = fs.String("loglevel", "info", "Default log level")
-		ackTimeoutIns      = fs.Int("ackTimeoutIns", 10, "Ack timeout of ingress channels")
-		_                  = fs.String("config", "internal/config/local.cfg", "config file (optional)")
+		natsServers               arrayFlags
+		lokiServers               arrayFlags
+		splunkTokens              arrayFlags
+		fileTailSources           arrayFlags
+		pingLog                   = fs.Bool("pingLog", false, "log every second a ping in debug level")
+		ingressSubjectJournalD    = fs.String("ingressSubjectJournalD", "ingress.logs.journald", "ingress subject journald logs shipped by vector")
+		ingressSubjectNativeEcs   = fs.String("ingressSubjectNativeEcs", "ingress.logs.ecs", "ingress subject native ecs logs shipped directly to ingress")
+		ingressSubjectDocker      = fs.String("ingressSubjectDocker", "ingress.logs.docker", "ingress subject docker container logs shipped by vector")
+		ingressSubjectSyslog      = fs.String("ingressSubjectSyslog", "ingress.logs.syslog", "ingress subject raw syslog lines (rfc5424 or rfc3164) shipped by vector")
+		ingressSubjectOtlp        = fs.String("ingressSubjectOtlp", "ingress.logs.otlp", "ingress subject OTLP ExportLogsServiceRequest in protobuf or json encoding")
+		ingressSubjectLoki        = fs.String("ingressSubjectLoki", "ingress.logs.loki", "ingress subject loki push requests in snappy protobuf or json encoding")
+		ingressSubjectExport      = fs.String("ingressSubjectJournalExport", "ingress.logs.journalexport", "ingress subject journal export format of journalctl -o export or systemd-journal-upload")
+		lokiIngressPort           = fs.Int("lokiIngressPort", 0, "port of the loki push api endpoint /loki/api/v1/push. Disabled if 0")
+		elasticIngressPort        = fs.Int("elasticIngressPort", 0, "port of the elasticsearch bulk api endpoint for filebeat and logstash. Disabled if 0")
+		elasticVersion            = fs.String("elasticVersion", "8.17.0", "elasticsearch version reported by the bulk api endpoint")
+		ingressSubjectSplunk      = fs.String("ingressSubjectSplunk", "ingress.logs.splunk", "ingress subject splunk hec events as ndjson")
+		splunkIngressPort         = fs.Int("splunkIngressPort", 0, "port of the splunk hec endpoints /services/collector/event and /services/collector/raw. Disabled if 0")
+		ingressSubjectFileTail    = fs.String("ingressSubjectFileTail", "ingress.logs.filetail", "ingress subject lines of the tailed files")
+		fileTailCheckpoint        = fs.String("fileTailCheckpoint", "filetail-checkpoint.json", "file of the read offsets of the tailed files")
+		fileTailIntervalMs        = fs.Int("fileTailIntervalMs", 1000, "poll interval of the tailed files")
+		syslogTcpPort             = fs.Int("syslogTcpPort", 0, "port of the syslog tcp listener with octet counting or newline framing. Disabled if 0")
+		syslogUdpPort             = fs.Int("syslogUdpPort", 0, "port of the syslog udp listener. Disabled if 0")
+		syslogTlsCert             = fs.String("syslogTlsCert", "", "certificate file of the syslog tcp listener. Plain tcp if empty")
+		syslogTlsKey              = fs.String("syslogTlsKey", "", "key file of the syslog tcp listener certificate")
+		ingressSubjectGelf        = fs.String("ingressSubjectGelf", "ingress.logs.gelf", "ingress subject GELF 1.1 messages and chunks relayed by the gelf log driver")
+		ingressSubjectRaw         = fs.String("ingressSubjectRaw", "ingress.logs.raw", "ingress subject plain log lines with the metadata in Logu-* nats headers")
+		ingressSubjectCloudEvents = fs.String("ingressSubjectCloudEvents", "ingress.logs.cloudevents", "ingress subject CloudEvents in structured or binary mode whose data is a log record")
+		ingressSubjectKubernetes  = fs.String("ingressSubjectKubernetes", "ingress.logs.kubernetes", "ingress subject vector kubernetes_logs with the pod metadata")
+		ingressSubjectTest        = fs.String("ingressSubjectTest", "ingress.logs.test", "Nats subscription for test logs")
+		egressSubjectEcs          = fs.String("egressSubjectEcs", "egress.logs.ecs", "Standardized logs output")
+		loglevel                  = fs.String("loglevel", "info", "Default log level")
+		ackTimeoutIns             = fs.Int("ackTimeoutIns", 10, "Ack timeout of ingress channels")
+		pipelineConfig            = fs.String("pipelineConfig", "", "yaml file that declares the ingress pipelines and sinks (optional). Uses the built in pipelines if empty")
+		patternConfig             = fs.String("patternConfig", "", "yaml file that declares user defined grok patterns (optional)")
+		patternAutoDetect         = fs.Bool("patternAutoDetect", false, "detect the pattern of the logs without pattern key")
+		_                         = fs.String("config", "internal/config/local.cfg", "config file (optional)")
 	)
 
 	// Default defined in local.cfg
 	fs.Var(&natsServers, "natsServers", "list of nats server(s) host and port")
 	fs.Var(&lokiServers, "lokiServers", "list of loki server(s) host and port")
+	fs.Var(&splunkTokens, "splunkTokens", "list of accepted splunk hec tokens")
+	fs.Var(&fileTailSources, "fileTail", "list of tailed files path=<glob>,service=<name>,pattern_key=<key>. Disabled if empty")
 	if err := ff.Parse(fs, os.Args[1:],
 		ff.WithEnvVarPrefix("LOGU"),
 		ff.WithConfigFileFlag("config"),
@@ -43,19 +70,47 @@ func ReadConfigs() {
 	builder := newBuilder().
 		withIngressSubjectJournald(ingressSubjectJournalD).
 		withIngressSubjectNativeEcs(ingressSubjectNativeEcs).
+		withIngressSubjectDocker(ingressSubjectDocker).
+		withIngressSubjectSyslog(ingressSubjectSyslog).
+		withIngressSubjectOtlp(ingressSubjectOtlp).
+		withIngressSubjectLoki(ingressSubjectLoki).
+		withIngressSubjectJournalExport(ingressSubjectExport).
+		withLokiIngressPort(lokiIngressPort).
+		withElasticIngressPort(elasticIngressPort).
+		withElasticVersion(elasticVersion).
+		withIngressSubjectSplunk(ingressSubjectSplunk).
+		withSplunkIngressPort(splunkIngressPort).
+		withIngressSubjectFileTail(ingressSubjectFileTail).
+		withFileTailCheckpoint(fileTailCheckpoint).
+		withFileTailIntervalMs(fileTailIntervalMs).
+		withSyslogTcpPort(syslogTcpPort).
+		withSyslogUdpPort(syslogUdpPort).
+		withSyslogTls(syslogTlsCert, syslogTlsKey).
+		withIngressSubjectGelf(ingressSubjectGelf).
+		withIngressSubjectRaw(ingressSubjectRaw).
+		withIngressSubjectCloudEvents(ingressSubjectCloudEvents).
+		withIngressSubjectKubernetes(ingressSubjectKubernetes).
 		withIngresSubjectTest(ingressSubjectTest).
 		withPingLog(pingLog)
-	//withIngressSubjectDocker(ingressSubjectDocker)
 	for _, s := range natsServers {
 		builder.withNatsServer(s)
 	}
 	for _, s := range lokiServers {
 		builder.withLokiServers(s)
 	}
+	for _, s := range splunkTokens {
+		builder.withSplunkToken(s)
+	}
+	for _, s := range fileTailSources {
+		builder.withFileTailSource(s)
+	}
 	_ = builder.
 		withLogLevel(loglevel).
 		withAckTimeout(ackTimeoutIns).
 		withEgressSubjectEcs(egressSubjectEcs).
+		withPipelineConfig(pipelineConfig).
+		withPatternConfig(patternConfig).
+		withPatternAutoDetect(patternAutoDetect).
 		build()
 
 }
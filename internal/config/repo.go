@@ -9,16 +9,41 @@ import (
 
 // region type Config
 type Config struct {
-	ingressNatsJournald  string
-	ingressNatsNativeEcs string
-	//ingressNatsDocker   string
-	ingresSubjectTest string
-	natsServers       []string
-	lokiServers       []string
-	loglevel          string
-	egressSubjectEcs  string
-	ackTimeoutS       int
-	pingLog           bool
+	ingressNatsJournald    string
+	ingressNatsNativeEcs   string
+	ingressNatsDocker      string
+	ingressNatsSyslog      string
+	ingressNatsOtlp        string
+	ingressNatsLoki        string
+	ingressNatsExport      string
+	lokiIngressPort        int
+	elasticIngressPort     int
+	elasticVersion         string
+	ingressNatsSplunk      string
+	splunkIngressPort      int
+	splunkTokens           []string
+	ingressNatsFileTail    string
+	fileTailSources        []string
+	fileTailCheckpoint     string
+	fileTailIntervalMs     int
+	syslogTcpPort          int
+	syslogUdpPort          int
+	syslogTlsCert          string
+	syslogTlsKey           string
+	ingressNatsGelf        string
+	ingressNatsRaw         string
+	ingressNatsCloudEvents string
+	ingressNatsKubernetes  string
+	ingresSubjectTest      string
+	natsServers            []string
+	lokiServers            []string
+	loglevel               string
+	egressSubjectEcs       string
+	ackTimeoutS            int
+	pingLog                bool
+	pipelineConfig         string
+	patternConfig          string
+	patternAutoDetect      bool
 }
 
 func (c Config) AckTimeoutS() int {
@@ -30,7 +55,10 @@ func (c Config) String() string {
 ingressNatsJournald: %v,
 natsServers: %v,
 loglevel: %v,
-`, c.ingressNatsJournald, c.natsServers, c.loglevel)
+pipelineConfig: %v,
+patternConfig: %v,
+patternAutoDetect: %v,
+`, c.ingressNatsJournald, c.natsServers, c.loglevel, c.pipelineConfig, c.patternConfig, c.patternAutoDetect)
 }
 
 func (c Config) IngressNatsJournald() string {
@@ -41,13 +69,122 @@ func (c Config) IngressNatsNativeEcs() string {
 	return c.ingressNatsNativeEcs
 }
 
+// PipelineConfig path to the yaml pipeline definition. Empty if the built in pipelines should be used
+func (c Config) PipelineConfig() string {
+	return c.pipelineConfig
+}
+
+// PatternConfig path to the yaml definition of the user defined patterns. Empty if only the built in patterns should be used
+func (c Config) PatternConfig() string {
+	return c.patternConfig
+}
+
+// PatternAutoDetect true if the pattern of the logs without pattern key should be detected
+func (c Config) PatternAutoDetect() bool {
+	return c.patternAutoDetect
+}
+
 func (c Config) PingLog() bool {
 	return c.pingLog
 }
 
-//func (c Config) IngressNatsDocker() string {
-//	return c.ingressNatsDocker
-//}
+func (c Config) IngressNatsDocker() string {
+	return c.ingressNatsDocker
+}
+
+func (c Config) IngressNatsSyslog() string {
+	return c.ingressNatsSyslog
+}
+
+func (c Config) IngressNatsOtlp() string {
+	return c.ingressNatsOtlp
+}
+
+func (c Config) IngressNatsLoki() string {
+	return c.ingressNatsLoki
+}
+
+func (c Config) IngressNatsJournalExport() string {
+	return c.ingressNatsExport
+}
+
+// LokiIngressPort port of the loki push api endpoint. The endpoint is disabled if 0
+func (c Config) LokiIngressPort() int {
+	return c.lokiIngressPort
+}
+
+// ElasticIngressPort port of the elasticsearch bulk api endpoint. The endpoint is disabled if 0
+func (c Config) ElasticIngressPort() int {
+	return c.elasticIngressPort
+}
+
+// ElasticVersion the elasticsearch version reported to the agents
+func (c Config) ElasticVersion() string {
+	return c.elasticVersion
+}
+
+func (c Config) IngressNatsSplunk() string {
+	return c.ingressNatsSplunk
+}
+
+// SplunkIngressPort port of the splunk hec endpoints. The endpoints are disabled if 0
+func (c Config) SplunkIngressPort() int {
+	return c.splunkIngressPort
+}
+
+// SplunkTokens the accepted hec tokens. All requests are rejected if empty
+func (c Config) SplunkTokens() []string {
+	return c.splunkTokens
+}
+
+func (c Config) IngressNatsFileTail() string {
+	return c.ingressNatsFileTail
+}
+
+// FileTailSources the globs and the metadata of the tailed files. The file tail is disabled if empty
+func (c Config) FileTailSources() []string {
+	return c.fileTailSources
+}
+
+// FileTailCheckpoint the file of the read offsets
+func (c Config) FileTailCheckpoint() string {
+	return c.fileTailCheckpoint
+}
+
+func (c Config) FileTailIntervalMs() int {
+	return c.fileTailIntervalMs
+}
+
+// SyslogTcpPort port of the syslog tcp listener. The listener is disabled if 0
+func (c Config) SyslogTcpPort() int {
+	return c.syslogTcpPort
+}
+
+// SyslogUdpPort port of the syslog udp listener. The listener is disabled if 0
+func (c Config) SyslogUdpPort() int {
+	return c.syslogUdpPort
+}
+
+// SyslogTls the certificate and key file of the syslog tcp listener. Plain tcp if empty
+func (c Config) SyslogTls() (string, string) {
+	return c.syslogTlsCert, c.syslogTlsKey
+}
+
+func (c Config) IngressNatsGelf() string {
+	return c.ingressNatsGelf
+}
+
+func (c Config) IngressNatsRaw() string {
+	return c.ingressNatsRaw
+}
+
+func (c Config) IngressNatsCloudEvents() string {
+	return c.ingressNatsCloudEvents
+}
+
+func (c Config) IngressNatsKubernetes() string {
+	return c.ingressNatsKubernetes
+}
 
 func (c Config) IngresNatsTest() string {
 	return c.ingresSubjectTest
@@ -101,6 +238,26 @@ func (r *ConfigBuilder) withLokiServers(server string) *ConfigBuilder {
 	return r
 }
 
+func (r *ConfigBuilder) withSplunkToken(token string) *ConfigBuilder {
+	for _, t := range r.cfg.splunkTokens {
+		if strings.Compare(t, token) == 0 {
+			return r
+		}
+	}
+	r.cfg.splunkTokens = append(r.cfg.splunkTokens, token)
+	return r
+}
+
+func (r *ConfigBuilder) withFileTailSource(source string) *ConfigBuilder {
+	for _, s := range r.cfg.fileTailSources {
+		if strings.Compare(s, source) == 0 {
+			return r
+		}
+	}
+	r.cfg.fileTailSources = append(r.cfg.fileTailSources, source)
+	return r
+}
+
 func (r *ConfigBuilder) withLogLevel(loglevel *string) *ConfigBuilder {
 	r.cfg.loglevel = *loglevel
 	return r
@@ -135,10 +292,122 @@ func (r *ConfigBuilder) withPingLog(pingLog *bool) *ConfigBuilder {
 	return r
 }
 
-//	func (r *ConfigBuilder) withIngressSubjectDocker(ingressNatsDocker *string) *ConfigBuilder {
-//		r.cfg.ingressNatsDocker = *ingressNatsDocker
-//		return r
-//	}
+func (r *ConfigBuilder) withPipelineConfig(pipelineConfig *string) *ConfigBuilder {
+	r.cfg.pipelineConfig = *pipelineConfig
+	return r
+}
+
+func (r *ConfigBuilder) withPatternConfig(patternConfig *string) *ConfigBuilder {
+	r.cfg.patternConfig = *patternConfig
+	return r
+}
+
+func (r *ConfigBuilder) withPatternAutoDetect(patternAutoDetect *bool) *ConfigBuilder {
+	r.cfg.patternAutoDetect = *patternAutoDetect
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectDocker(ingressNatsDocker *string) *ConfigBuilder {
+	r.cfg.ingressNatsDocker = *ingressNatsDocker
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectSyslog(ingressNatsSyslog *string) *ConfigBuilder {
+	r.cfg.ingressNatsSyslog = *ingressNatsSyslog
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectOtlp(ingressNatsOtlp *string) *ConfigBuilder {
+	r.cfg.ingressNatsOtlp = *ingressNatsOtlp
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectLoki(ingressNatsLoki *string) *ConfigBuilder {
+	r.cfg.ingressNatsLoki = *ingressNatsLoki
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectJournalExport(ingressNatsExport *string) *ConfigBuilder {
+	r.cfg.ingressNatsExport = *ingressNatsExport
+	return r
+}
+
+func (r *ConfigBuilder) withLokiIngressPort(lokiIngressPort *int) *ConfigBuilder {
+	r.cfg.lokiIngressPort = *lokiIngressPort
+	return r
+}
+
+func (r *ConfigBuilder) withElasticIngressPort(elasticIngressPort *int) *ConfigBuilder {
+	r.cfg.elasticIngressPort = *elasticIngressPort
+	return r
+}
+
+func (r *ConfigBuilder) withElasticVersion(elasticVersion *string) *ConfigBuilder {
+	r.cfg.elasticVersion = *elasticVersion
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectSplunk(ingressNatsSplunk *string) *ConfigBuilder {
+	r.cfg.ingressNatsSplunk = *ingressNatsSplunk
+	return r
+}
+
+func (r *ConfigBuilder) withSplunkIngressPort(splunkIngressPort *int) *ConfigBuilder {
+	r.cfg.splunkIngressPort = *splunkIngressPort
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectFileTail(ingressNatsFileTail *string) *ConfigBuilder {
+	r.cfg.ingressNatsFileTail = *ingressNatsFileTail
+	return r
+}
+
+func (r *ConfigBuilder) withFileTailCheckpoint(fileTailCheckpoint *string) *ConfigBuilder {
+	r.cfg.fileTailCheckpoint = *fileTailCheckpoint
+	return r
+}
+
+func (r *ConfigBuilder) withFileTailIntervalMs(fileTailIntervalMs *int) *ConfigBuilder {
+	r.cfg.fileTailIntervalMs = *fileTailIntervalMs
+	return r
+}
+
+func (r *ConfigBuilder) withSyslogTcpPort(syslogTcpPort *int) *ConfigBuilder {
+	r.cfg.syslogTcpPort = *syslogTcpPort
+	return r
+}
+
+func (r *ConfigBuilder) withSyslogUdpPort(syslogUdpPort *int) *ConfigBuilder {
+	r.cfg.syslogUdpPort = *syslogUdpPort
+	return r
+}
+
+func (r *ConfigBuilder) withSyslogTls(syslogTlsCert *string, syslogTlsKey *string) *ConfigBuilder {
+	r.cfg.syslogTlsCert = *syslogTlsCert
+	r.cfg.syslogTlsKey = *syslogTlsKey
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectGelf(ingressNatsGelf *string) *ConfigBuilder {
+	r.cfg.ingressNatsGelf = *ingressNatsGelf
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectRaw(ingressNatsRaw *string) *ConfigBuilder {
+	r.cfg.ingressNatsRaw = *ingressNatsRaw
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectCloudEvents(ingressNatsCloudEvents *string) *ConfigBuilder {
+	r.cfg.ingressNatsCloudEvents = *ingressNatsCloudEvents
+	return r
+}
+
+func (r *ConfigBuilder) withIngressSubjectKubernetes(ingressNatsKubernetes *string) *ConfigBuilder {
+	r.cfg.ingressNatsKubernetes = *ingressNatsKubernetes
+	return r
+}
+
 func (r *ConfigBuilder) build() *Config {
 	lock.Lock()
 	defer lock.Unlock()
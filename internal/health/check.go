@@ -6,20 +6,20 @@ import (
 	"github.com/alexliesenfeld/health"
 	"github.com/suikast42/logunifier/internal/bootstrap"
 	"github.com/suikast42/logunifier/internal/config"
-	"github.com/suikast42/logunifier/internal/streams/connectors/lokishipper"
+	"github.com/suikast42/logunifier/internal/streams/connectors"
 	"net/http"
 	"time"
 )
 
-func Start(path string, port int, dialer *bootstrap.NatsDialer, lokishipper *lokishipper.LokiShipper) error {
+func Start(path string, port int, dialer *bootstrap.NatsDialer, sinks map[string]connectors.EgressSink) error {
 	logger := config.Logger()
-	checker := health.NewChecker(
+	opts := []health.CheckerOption{
 
 		// Set the time-to-live for our cache to 1 second (default).
-		health.WithCacheDuration(1*time.Second),
+		health.WithCacheDuration(1 * time.Second),
 
 		// Configure a global timeout that will be applied to all checks.
-		health.WithTimeout(10*time.Second),
+		health.WithTimeout(10 * time.Second),
 
 		// A check configuration to see if our database connection is up.
 		// The check function will be executed for each HTTP request.
@@ -28,17 +28,21 @@ func Start(path string, port int, dialer *bootstrap.NatsDialer, lokishipper *lok
 			Timeout: 2 * time.Second, // A check specific timeout.
 			Check:   dialer.Health,
 		}),
-		health.WithCheck(health.Check{
-			Name:    "loki",          // A unique check name.
-			Timeout: 2 * time.Second, // A check specific timeout.
-			Check:   lokishipper.Health,
-		}),
 		// Set a status listener that will be invoked when the health status changes.
 		// More powerful hooks are also available (see docs).
 		health.WithStatusListener(func(ctx context.Context, state health.CheckerState) {
 			logger.Info().Msgf("health status changed to %s", state.Status)
 		}),
-	)
+	}
+	// One check for each declared sink
+	for name, sink := range sinks {
+		opts = append(opts, health.WithCheck(health.Check{
+			Name:    name,            // A unique check name.
+			Timeout: 2 * time.Second, // A check specific timeout.
+			Check:   sink.Health,
+		}))
+	}
+	checker := health.NewChecker(opts...)
 
 	// Create a new health check http.Handler that returns the health status
 	// serialized as a JSON string. You can pass pass further configuration
@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/bootstrap"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/connectors"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/process"
+)
+
+// Stream definitions
+const (
+	StreamNameLogStreamIngress = "LogStreamIngress"
+	StreamNameLogStreamEgress  = "LogStreamEgress"
+)
+
+// Pipelines the nats streams, consumers and go channels built from a Definition
+type Pipelines struct {
+	definition      *Definition
+	streams         map[string]*bootstrap.NatsStreamConfiguration
+	consumers       map[string]*bootstrap.NatsConsumerConfiguration
+	processChannels map[string]chan ingress.IngressMsgContext
+	egressChannels  map[string]chan connectors.EgressMsgContext
+	sinks           map[string]connectors.EgressSink
+}
+
+// Build the stream and consumer configurations for the bootstrap.NatsDialer out of the definition
+func Build(cfg *config.Config, definition *Definition) *Pipelines {
+	result := &Pipelines{
+		definition:      definition,
+		streams:         make(map[string]*bootstrap.NatsStreamConfiguration),
+		consumers:       make(map[string]*bootstrap.NatsConsumerConfiguration),
+		processChannels: make(map[string]chan ingress.IngressMsgContext),
+		egressChannels:  make(map[string]chan connectors.EgressMsgContext),
+		sinks:           make(map[string]connectors.EgressSink),
+	}
+
+	ingressSubjects := make([]string, 0, len(definition.Pipelines))
+	for _, pipeline := range definition.Pipelines {
+		ingressSubjects = appendDistinct(ingressSubjects, pipeline.Subject)
+	}
+	egressSubjects := make([]string, 0, len(definition.Sinks))
+	for _, sink := range definition.Sinks {
+		egressSubjects = appendDistinct(egressSubjects, sink.Subject)
+	}
+
+	ingressStream := streamConfig(StreamNameLogStreamIngress,
+		"Ingress stream for unify and enrich logs from various formats to ecs",
+		ingressSubjects, len(definition.Pipelines))
+	result.streams[StreamNameLogStreamIngress] = &bootstrap.NatsStreamConfiguration{
+		StreamConfiguration: ingressStream,
+	}
+
+	egressStream := streamConfig(StreamNameLogStreamEgress,
+		"Egress stream that contains ecs logs in json format for ship in various sinks",
+		egressSubjects, len(definition.Sinks))
+	result.streams[StreamNameLogStreamEgress] = &bootstrap.NatsStreamConfiguration{
+		StreamConfiguration: egressStream,
+	}
+
+	// Ingress stream Consumer configuration
+	for _, pipeline := range definition.Pipelines {
+		consumerName := "ConsumerIngress" + pipeline.Name
+		processChannel := make(chan ingress.IngressMsgContext, bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
+		result.processChannels[pipeline.Name] = processChannel
+		result.consumers[consumerName] = &bootstrap.NatsConsumerConfiguration{
+			ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
+				consumerName,
+				consumerName+"_Group",
+				ingressStream,
+				pipeline.Subject,
+			),
+			StreamName: StreamNameLogStreamIngress,
+			MsgHandler: bootstrap.IngressMsgHandler(processChannel, converters[pipeline.Converter]()),
+		}
+	}
+
+	// Egress stream Consumer configuration
+	for _, sink := range definition.Sinks {
+		consumerName := "ConsumerEgress" + sink.Name
+		egressChannel := make(chan connectors.EgressMsgContext, 4096)
+		result.egressChannels[sink.Name] = egressChannel
+		result.sinks[sink.Name] = sinkTypes[sink.Type](cfg)
+		result.consumers[consumerName] = &bootstrap.NatsConsumerConfiguration{
+			ConsumerConfiguration: bootstrap.QueueSubscribeConsumerGroupConfig(
+				consumerName,
+				consumerName+"_Group",
+				egressStream,
+				sink.Subject,
+			),
+			StreamName: StreamNameLogStreamEgress,
+			MsgHandler: bootstrap.EgressMessageHandler(egressChannel),
+		}
+	}
+	return result
+}
+
+// streamConfig the baseline bootstrap.StreamConfig. The max consumers are raised only if more consumers are declared
+// An existing stream keeps its max consumers. See bootstrap.StreamUpdate
+func streamConfig(name string, description string, subjects []string, consumers int) nats.StreamConfig {
+	stream := bootstrap.StreamConfig(name, description, subjects)
+	stream.MaxConsumers = max(stream.MaxConsumers, consumers)
+	return stream
+}
+
+func (p *Pipelines) Streams() map[string]*bootstrap.NatsStreamConfiguration {
+	return p.streams
+}
+
+func (p *Pipelines) Consumers() map[string]*bootstrap.NatsConsumerConfiguration {
+	return p.consumers
+}
+
+func (p *Pipelines) Sinks() map[string]connectors.EgressSink {
+	return p.sinks
+}
+
+// Start connects the sinks and starts the process channel of every pipeline
+func (p *Pipelines) Start() error {
+	for name, sink := range p.sinks {
+		sink.Connect()
+		// Start go channel receiver
+		sink.StartReceive(p.egressChannels[name])
+	}
+	for _, pipeline := range p.definition.Pipelines {
+		stages, err := process.StagesFor(pipeline.Processors)
+		if err != nil {
+			return err
+		}
+		err = process.Start(p.processChannels[pipeline.Name],
+			pipeline.Name+"LogChannel",
+			p.definition.sinkSubjects(pipeline),
+			stages,
+			bootstrap.QueueSubscribeConsumerGroupConfigMaxAckPending)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop closes the process channels and disconnects the sinks
+func (p *Pipelines) Stop() {
+	for _, processChannel := range p.processChannels {
+		close(processChannel)
+	}
+	for _, sink := range p.sinks {
+		sink.DisConnect()
+	}
+}
@@ -0,0 +1,25 @@
+package pipeline
+
+import (
+	"github.com/suikast42/logunifier/internal/bootstrap"
+	"reflect"
+	"testing"
+)
+
+func TestStreamConfig(t *testing.T) {
+	subjects := []string{"ingress.logs.journald", "ingress.logs.ecs"}
+	baseline := bootstrap.StreamConfig(StreamNameLogStreamIngress, "Ingress stream", subjects)
+	// The built-in pipelines keep the baseline
+	if actual := streamConfig(StreamNameLogStreamIngress, "Ingress stream", subjects, 2); !reflect.DeepEqual(actual, baseline) {
+		t.Errorf("Expected the baseline stream config %+v but got %+v", baseline, actual)
+	}
+	// More declared consumers raise the max consumers only
+	actual := streamConfig(StreamNameLogStreamIngress, "Ingress stream", subjects, 13)
+	if actual.MaxConsumers != 13 {
+		t.Errorf("Expected 13 max consumers but got %d", actual.MaxConsumers)
+	}
+	actual.MaxConsumers = baseline.MaxConsumers
+	if !reflect.DeepEqual(actual, baseline) {
+		t.Errorf("Expected the baseline stream config %+v but got %+v", baseline, actual)
+	}
+}
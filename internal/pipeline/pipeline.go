@@ -0,0 +1,211 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/process"
+	"gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+// Definition declares the ingress pipelines and the sinks of logunifier
+// A pipeline reads an ingress subject, converts the messages with a registered ingress.MetaLogConverter,
+// runs the declared processors and pushes the result to the subjects of its sinks
+type Definition struct {
+	Sinks     []SinkDefinition     `yaml:"sinks"`
+	Pipelines []PipelineDefinition `yaml:"pipelines"`
+}
+
+type PipelineDefinition struct {
+	// Name of the pipeline. The consumer is named ConsumerIngress<Name>
+	Name string `yaml:"name"`
+	// Subject the ingress subject of the pipeline
+	Subject string `yaml:"subject"`
+	// Converter the registered name of the ingress.MetaLogConverter
+	Converter string `yaml:"converter"`
+	// Processors the names of the process.Stage that runs after the pattern parsing in the declared order
+	// Defaults to process.DefaultStages
+	Processors []string `yaml:"processors"`
+	// Sinks the names of the declared sinks that receives the output of this pipeline
+	Sinks []string `yaml:"sinks"`
+}
+
+type SinkDefinition struct {
+	// Name of the sink. The consumer is named ConsumerEgress<Name>
+	Name string `yaml:"name"`
+	// Type the registered sink type. For example loki
+	Type string `yaml:"type"`
+	// Subject the egress subject that the sink consumes
+	Subject string `yaml:"subject"`
+}
+
+// Read the pipeline definition from the file defined in config.Config#PipelineConfig
+// Returns the built-in Default if no file is configured
+func Read(cfg *config.Config) (*Definition, error) {
+	if len(cfg.PipelineConfig()) == 0 {
+		return Default(cfg), nil
+	}
+	content, err := os.ReadFile(cfg.PipelineConfig())
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
+
+// Parse a yaml pipeline definition and validate it
+func Parse(content []byte) (*Definition, error) {
+	definition := &Definition{}
+	err := yaml.Unmarshal(content, definition)
+	if err != nil {
+		return nil, err
+	}
+	for i := range definition.Pipelines {
+		if len(definition.Pipelines[i].Processors) == 0 {
+			definition.Pipelines[i].Processors = process.DefaultStages()
+		}
+	}
+	err = definition.validate()
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// Default the built-in pipelines
+//   - journald and native ecs ingress
+//   - shipped to loki after the process.DefaultStages
+//
+// The other ingresses are opt-in. They are declared in the pipeline config. See pipelines.yml
+func Default(cfg *config.Config) *Definition {
+	const sinkLoki = "LokiShipper"
+	return &Definition{
+		Sinks: []SinkDefinition{
+			{
+				Name:    sinkLoki,
+				Type:    SinkTypeLoki,
+				Subject: cfg.EgressSubjectEcs(),
+			},
+		},
+		Pipelines: []PipelineDefinition{
+			{
+				Name:       "JournalD",
+				Subject:    cfg.IngressNatsJournald(),
+				Converter:  ConverterJournald,
+				Processors: process.DefaultStages(),
+				Sinks:      []string{sinkLoki},
+			},
+			{
+				Name:       "EcsNative",
+				Subject:    cfg.IngressNatsNativeEcs(),
+				Converter:  ConverterEcs,
+				Processors: process.DefaultStages(),
+				Sinks:      []string{sinkLoki},
+			},
+		},
+	}
+}
+
+func (d *Definition) validate() error {
+	var err error
+	if len(d.Pipelines) == 0 {
+		err = errors.Join(err, errors.New("no pipeline declared"))
+	}
+	sinks := make(map[string]bool)
+	for _, sink := range d.Sinks {
+		if len(sink.Name) == 0 {
+			err = errors.Join(err, errors.New("sink without name declared"))
+			continue
+		}
+		if sinks[sink.Name] {
+			err = errors.Join(err, fmt.Errorf("sink %s is declared twice", sink.Name))
+		}
+		sinks[sink.Name] = true
+		if !isSinkTypeRegistered(sink.Type) {
+			err = errors.Join(err, fmt.Errorf("sink %s: type %s is not registered", sink.Name, sink.Type))
+		}
+		if len(sink.Subject) == 0 {
+			err = errors.Join(err, fmt.Errorf("sink %s: no subject declared", sink.Name))
+		}
+	}
+	pipelines := make(map[string]bool)
+	for _, pipeline := range d.Pipelines {
+		if len(pipeline.Name) == 0 {
+			err = errors.Join(err, errors.New("pipeline without name declared"))
+			continue
+		}
+		if pipelines[pipeline.Name] {
+			err = errors.Join(err, fmt.Errorf("pipeline %s is declared twice", pipeline.Name))
+		}
+		pipelines[pipeline.Name] = true
+		if len(pipeline.Subject) == 0 {
+			err = errors.Join(err, fmt.Errorf("pipeline %s: no subject declared", pipeline.Name))
+		}
+		if !isConverterRegistered(pipeline.Converter) {
+			err = errors.Join(err, fmt.Errorf("pipeline %s: converter %s is not registered", pipeline.Name, pipeline.Converter))
+		}
+		for _, processor := range pipeline.Processors {
+			if !process.IsStageRegistered(processor) {
+				err = errors.Join(err, fmt.Errorf("pipeline %s: processor %s is not registered", pipeline.Name, processor))
+			}
+		}
+		if len(pipeline.Sinks) == 0 {
+			err = errors.Join(err, fmt.Errorf("pipeline %s: no sink declared", pipeline.Name))
+		}
+		for _, sink := range pipeline.Sinks {
+			if !sinks[sink] {
+				err = errors.Join(err, fmt.Errorf("pipeline %s: sink %s is not declared", pipeline.Name, sink))
+			}
+		}
+	}
+	return err
+}
+
+// HasPipelineFor reports if a pipeline consumes the subject
+// The subject of a pipeline may contain the nats wildcards * and >
+func (d *Definition) HasPipelineFor(subject string) bool {
+	for _, pipeline := range d.Pipelines {
+		if subjectMatches(pipeline.Subject, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func subjectMatches(filter string, subject string) bool {
+	filterTokens := strings.Split(filter, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for i, token := range filterTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(filterTokens) == len(subjectTokens)
+}
+
+// sinkSubjects the distinct egress subjects of the sinks of a pipeline
+// Sinks that share a subject receive the same message over their own consumer
+func (d *Definition) sinkSubjects(pipeline PipelineDefinition) []string {
+	result := make([]string, 0, len(pipeline.Sinks))
+	for _, name := range pipeline.Sinks {
+		for _, sink := range d.Sinks {
+			if sink.Name == name {
+				result = appendDistinct(result, sink.Subject)
+			}
+		}
+	}
+	return result
+}
+
+func appendDistinct(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,170 @@
+package pipeline
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSamplePipelineConfig(t *testing.T) {
+	content, err := os.ReadFile("../config/pipelines.yml")
+	if err != nil {
+		t.Fatalf("Can't read sample config %s", err)
+	}
+	definition, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	// The sample declares one pipeline per built-in converter
+	expected := map[string]string{
+		"ingress.logs.journald":      ConverterJournald,
+		"ingress.logs.ecs":           ConverterEcs,
+		"ingress.logs.docker":        ConverterDocker,
+		"ingress.logs.syslog":        ConverterSyslog,
+		"ingress.logs.otlp":          ConverterOtlp,
+		"ingress.logs.loki":          ConverterLoki,
+		"ingress.logs.journalexport": ConverterExport,
+		"ingress.logs.splunk":        ConverterSplunk,
+		"ingress.logs.filetail":      ConverterFileTail,
+		"ingress.logs.gelf":          ConverterGelf,
+		"ingress.logs.raw":           ConverterRaw,
+		"ingress.logs.cloudevents":   ConverterCloudEvents,
+		"ingress.logs.kubernetes":    ConverterKubernetes,
+	}
+	actual := map[string]string{}
+	for _, pipeline := range definition.Pipelines {
+		actual[pipeline.Subject] = pipeline.Converter
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected the converters of the subjects %v but got %v", expected, actual)
+	}
+	if len(definition.Sinks) != 1 {
+		t.Errorf("Expected 1 sink but got %d", len(definition.Sinks))
+	}
+	journald := definition.Pipelines[0]
+	if journald.Converter != ConverterJournald {
+		t.Errorf("Expected converter %s but got %s", ConverterJournald, journald.Converter)
+	}
+	if !reflect.DeepEqual(definition.sinkSubjects(journald), []string{"egress.logs.ecs"}) {
+		t.Errorf("Expected sink subjects %v but got %v", []string{"egress.logs.ecs"}, definition.sinkSubjects(journald))
+	}
+}
+
+func TestParseDefaultProcessors(t *testing.T) {
+	content := `
+sinks:
+  - name: loki
+    type: loki
+    subject: egress.logs.ecs
+pipelines:
+  - name: Journald
+    subject: ingress.logs.journald
+    converter: journald
+    sinks: [loki]
+`
+	definition, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	if !reflect.DeepEqual(definition.Pipelines[0].Processors, []string{"validate"}) {
+		t.Errorf("Expected default processors but got %v", definition.Pipelines[0].Processors)
+	}
+}
+
+func TestParseInvalidPipelineConfig(t *testing.T) {
+	tests := []struct {
+		pos      int
+		content  string
+		expected string
+	}{
+		{
+			pos:      1,
+			content:  `sinks: []`,
+			expected: "no pipeline declared",
+		},
+		{
+			pos: 2,
+			content: `
+sinks:
+  - name: loki
+    type: loki
+    subject: egress.logs.ecs
+pipelines:
+  - name: Journald
+    subject: ingress.logs.journald
+    converter: unknown
+    sinks: [loki]
+`,
+			expected: "converter unknown is not registered",
+		},
+		{
+			pos: 3,
+			content: `
+sinks:
+  - name: loki
+    type: loki
+    subject: egress.logs.ecs
+pipelines:
+  - name: Journald
+    subject: ingress.logs.journald
+    converter: journald
+    processors: [unknown]
+    sinks: [elastic]
+`,
+			expected: "sink elastic is not declared",
+		},
+		{
+			pos: 4,
+			content: `
+sinks:
+  - name: loki
+    type: elastic
+    subject: egress.logs.ecs
+pipelines:
+  - name: Journald
+    converter: journald
+    sinks: [loki]
+`,
+			expected: "no subject declared",
+		},
+	}
+	for _, test := range tests {
+		_, err := Parse([]byte(test.content))
+		if err == nil {
+			t.Errorf("Pos %d: expected error %s but got nil", test.pos, test.expected)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Pos %d: expected error %s but got %s", test.pos, test.expected, err)
+		}
+	}
+}
+
+func TestHasPipelineFor(t *testing.T) {
+	definition := &Definition{
+		Pipelines: []PipelineDefinition{
+			{Name: "Loki", Subject: "ingress.logs.loki"},
+			{Name: "Tenants", Subject: "ingress.tenant.*.logs"},
+			{Name: "Any", Subject: "ingress.any.>"},
+		},
+	}
+	tests := []struct {
+		pos      int
+		subject  string
+		expected bool
+	}{
+		{pos: 1, subject: "ingress.logs.loki", expected: true},
+		{pos: 2, subject: "ingress.logs.syslog", expected: false},
+		{pos: 3, subject: "ingress.tenant.a.logs", expected: true},
+		{pos: 4, subject: "ingress.tenant.a.b.logs", expected: false},
+		{pos: 5, subject: "ingress.any.a.b", expected: true},
+		{pos: 6, subject: "ingress.any", expected: false},
+		{pos: 7, subject: "ingress.logs", expected: false},
+	}
+	for _, test := range tests {
+		if actual := definition.HasPipelineFor(test.subject); actual != test.expected {
+			t.Errorf("Pos %d: expected %t for %s but got %t", test.pos, test.expected, test.subject, actual)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/connectors"
+	"github.com/suikast42/logunifier/internal/streams/connectors/lokishipper"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/cloudevents"
+	"github.com/suikast42/logunifier/internal/streams/ingress/dockerlogs"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/internal/streams/ingress/filetail"
+	"github.com/suikast42/logunifier/internal/streams/ingress/gelf"
+	"github.com/suikast42/logunifier/internal/streams/ingress/journald"
+	"github.com/suikast42/logunifier/internal/streams/ingress/kubernetes"
+	"github.com/suikast42/logunifier/internal/streams/ingress/loki"
+	"github.com/suikast42/logunifier/internal/streams/ingress/otlp"
+	"github.com/suikast42/logunifier/internal/streams/ingress/raw"
+	"github.com/suikast42/logunifier/internal/streams/ingress/splunk"
+	"github.com/suikast42/logunifier/internal/streams/ingress/syslog"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+)
+
+// Registered converter names that can be referenced by a pipeline definition
+const (
+	ConverterJournald    = "journald"
+	ConverterEcs         = "ecs"
+	ConverterDocker      = "docker"
+	ConverterSyslog      = "syslog"
+	ConverterOtlp        = "otlp"
+	ConverterLoki        = "loki"
+	ConverterExport      = "journalexport"
+	ConverterSplunk      = "splunk"
+	ConverterFileTail    = "filetail"
+	ConverterGelf        = "gelf"
+	ConverterRaw         = "raw"
+	ConverterCloudEvents = "cloudevents"
+	ConverterKubernetes  = "kubernetes"
+	ConverterTest        = "test"
+)
+
+// Registered sink types that can be referenced by a sink definition
+const (
+	SinkTypeLoki = "loki"
+)
+
+// Every pipeline gets its own converter instance. Some converters like journald are stateful
+var converters = map[string]func() ingress.MetaLogConverter{
+	ConverterJournald:    func() ingress.MetaLogConverter { return &journald.JournaldDToEcsConverter{} },
+	ConverterEcs:         func() ingress.MetaLogConverter { return &ecs.EcsWrapper{} },
+	ConverterDocker:      func() ingress.MetaLogConverter { return &dockerlogs.DockerToEcsConverter{} },
+	ConverterSyslog:      func() ingress.MetaLogConverter { return &syslog.SyslogToEcsConverter{} },
+	ConverterOtlp:        func() ingress.MetaLogConverter { return &otlp.OtlpToEcsConverter{} },
+	ConverterLoki:        func() ingress.MetaLogConverter { return &loki.LokiToEcsConverter{} },
+	ConverterExport:      func() ingress.MetaLogConverter { return &journald.JournalExportToEcsConverter{} },
+	ConverterSplunk:      func() ingress.MetaLogConverter { return &splunk.SplunkToEcsConverter{} },
+	ConverterFileTail:    func() ingress.MetaLogConverter { return &filetail.FileTailToEcsConverter{} },
+	ConverterGelf:        func() ingress.MetaLogConverter { return &gelf.GelfToEcsConverter{} },
+	ConverterRaw:         func() ingress.MetaLogConverter { return &raw.RawToEcsConverter{} },
+	ConverterCloudEvents: func() ingress.MetaLogConverter { return &cloudevents.CloudEventsToEcsConverter{} },
+	ConverterKubernetes:  func() ingress.MetaLogConverter { return &kubernetes.KubernetesToEcsConverter{} },
+	ConverterTest:        func() ingress.MetaLogConverter { return &testingress.TestEcsConverter{} },
+}
+
+var sinkTypes = map[string]func(cfg *config.Config) connectors.EgressSink{
+	SinkTypeLoki: func(cfg *config.Config) connectors.EgressSink { return lokishipper.NewLokiShipper(cfg) },
+}
+
+func isConverterRegistered(name string) bool {
+	_, ok := converters[name]
+	return ok
+}
+
+func isSinkTypeRegistered(name string) bool {
+	_, ok := sinkTypes[name]
+	return ok
+}
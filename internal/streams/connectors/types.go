@@ -1,6 +1,7 @@
 package connectors
 
 import (
+	"context"
 	"github.com/nats-io/nats.go"
 	"github.com/suikast42/logunifier/pkg/model"
 )
@@ -25,3 +26,13 @@ type ExternalConnection interface {
 	// DisConnect if the connection is established
 	DisConnect()
 }
+
+type EgressSink interface {
+	ExternalConnection
+
+	// StartReceive consumes the egress channel and ships the received logs to the sink
+	StartReceive(processChannel <-chan EgressMsgContext)
+
+	// Health reports the state of the connection to the sink
+	Health(ctx context.Context) error
+}
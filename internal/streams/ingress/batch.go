@@ -0,0 +1,101 @@
+package ingress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"strings"
+)
+
+const (
+	HeaderContentType = "Content-Type"
+	// ContentTypeNdjson one log entry per line
+	ContentTypeNdjson = "application/x-ndjson"
+	ContentTypeJson   = "application/json"
+)
+
+// BatchConverter splits a batch of log entries in one nats message and converts every entry with the wrapped converter
+// A batch is either
+//   - a body with the header Content-Type: application/x-ndjson. Every non-empty line is an entry
+//   - a json array body with the header Content-Type: application/json. Every element is an entry
+//
+// A body without Content-Type is never split. A raw text line like ["a","b"] is one entry
+// Other messages are passed as they are to the wrapped converter
+type BatchConverter struct {
+	converter MetaLogConverter
+}
+
+// NewBatchConverter wraps the converter if it is not a MultiMetaLogConverter already
+func NewBatchConverter(converter MetaLogConverter) MultiMetaLogConverter {
+	if multi, ok := converter.(MultiMetaLogConverter); ok {
+		return multi
+	}
+	return &BatchConverter{
+		converter: converter,
+	}
+}
+
+func (b *BatchConverter) ConvertToMetaLog(msg *nats.Msg) IngressMsgContext {
+	return b.converter.ConvertToMetaLog(msg)
+}
+
+func (b *BatchConverter) ConvertToMetaLogs(msg *nats.Msg) []IngressMsgContext {
+	entries, ok := split(msg)
+	if !ok {
+		return []IngressMsgContext{b.converter.ConvertToMetaLog(msg)}
+	}
+	result := make([]IngressMsgContext, 0, len(entries))
+	for _, entry := range entries {
+		// The entry keeps the metadata of the nats message. For example the ingestion timestamp
+		result = append(result, b.converter.ConvertToMetaLog(&nats.Msg{
+			Subject: msg.Subject,
+			Reply:   msg.Reply,
+			Header:  msg.Header,
+			Data:    entry,
+			Sub:     msg.Sub,
+		}))
+	}
+	return result
+}
+
+// split the entries of a batch message. Returns false if the message is not a batch
+// An invalid or an empty json array is not split. The converter reports the error then
+func split(msg *nats.Msg) ([][]byte, bool) {
+	contentType := msg.Header.Get(HeaderContentType)
+	if strings.HasPrefix(contentType, ContentTypeNdjson) {
+		return splitLines(msg.Data), true
+	}
+	if !strings.HasPrefix(contentType, ContentTypeJson) {
+		return nil, false
+	}
+	trimmed := bytes.TrimSpace(msg.Data)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, false
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(trimmed, &elements); err != nil || len(elements) == 0 {
+		return nil, false
+	}
+	result := make([][]byte, 0, len(elements))
+	for _, element := range elements {
+		result = append(result, element)
+	}
+	return result, true
+}
+
+func splitLines(data []byte) [][]byte {
+	var result [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// A single log line can be larger than the default of 64k
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		// The scanner reuses its buffer
+		result = append(result, bytes.Clone(line))
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package ingress
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+type rawConverter struct {
+}
+
+func (r *rawConverter) ConvertToMetaLog(msg *nats.Msg) IngressMsgContext {
+	return IngressMsgContext{
+		NatsMsg: msg,
+		MetaLog: &model.MetaLog{
+			RawMessage: string(msg.Data),
+		},
+	}
+}
+
+func TestBatchConverter(t *testing.T) {
+	tests := []struct {
+		pos         int
+		contentType string
+		data        string
+		expected    []string
+	}{
+		{
+			pos:         1,
+			contentType: ContentTypeNdjson,
+			data:        "{\"a\":1}\n\n{\"b\":2}\r\n{\"c\":3}",
+			expected:    []string{`{"a":1}`, `{"b":2}`, `{"c":3}`},
+		},
+		{
+			pos:         2,
+			contentType: ContentTypeJson,
+			data:        ` [{"a":1}, {"b":[1,2]}] `,
+			expected:    []string{`{"a":1}`, `{"b":[1,2]}`},
+		},
+		{
+			pos:         3,
+			contentType: "application/json; charset=utf-8",
+			data:        `[{"a":1}]`,
+			expected:    []string{`{"a":1}`},
+		},
+		{
+			pos:      4,
+			data:     `{"a":1}`,
+			expected: []string{`{"a":1}`},
+		},
+		{
+			// Not split. The converter reports the invalid json
+			pos:         5,
+			contentType: ContentTypeJson,
+			data:        `[{"a":1}`,
+			expected:    []string{`[{"a":1}`},
+		},
+		{
+			// Only json bodies are split as array
+			pos:         6,
+			contentType: "text/plain",
+			data:        `[1,2]`,
+			expected:    []string{`[1,2]`},
+		},
+		{
+			// A raw line without Content-Type is never split
+			pos:      7,
+			data:     `["a","b"]`,
+			expected: []string{`["a","b"]`},
+		},
+		{
+			// An empty array is passed to the converter instead of being dropped
+			pos:         8,
+			contentType: ContentTypeJson,
+			data:        `[]`,
+			expected:    []string{`[]`},
+		},
+	}
+	converter := NewBatchConverter(&rawConverter{})
+	for _, test := range tests {
+		msg := &nats.Msg{Subject: "ingress.logs.test", Data: []byte(test.data), Header: nats.Header{}}
+		if len(test.contentType) > 0 {
+			msg.Header.Set(HeaderContentType, test.contentType)
+		}
+		result := converter.ConvertToMetaLogs(msg)
+		if len(result) != len(test.expected) {
+			t.Errorf("Pos %d: expected %d entries but got %d", test.pos, len(test.expected), len(result))
+			continue
+		}
+		for i, expected := range test.expected {
+			if result[i].MetaLog.RawMessage != expected {
+				t.Errorf("Pos %d: expected entry %s but got %s", test.pos, expected, result[i].MetaLog.RawMessage)
+			}
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	msg := &nats.Msg{Subject: "ingress.logs.test"}
+	contexts := []IngressMsgContext{
+		{MetaLog: &model.MetaLog{}},
+		{Skip: true},
+		{MetaLog: &model.MetaLog{}},
+	}
+	result, err := Share(msg, contexts)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 contexts but got %d", len(result))
+	}
+	for i, ctx := range result {
+		if ctx.NatsMsg != msg {
+			t.Errorf("Expected the shared nats message for context %d", i)
+		}
+		if ctx.ackGroup == nil || ctx.ackGroup.pending != 2 {
+			t.Errorf("Expected an ack group with 2 pending for context %d", i)
+		}
+	}
+	// Only the last done acks the message
+	if err = result[0].Ack(); err != nil {
+		t.Errorf("Expected no ack of the nats message but got %s", err)
+	}
+	if err = result[1].NakWithDelay(0); err == nil {
+		t.Error("Expected the nack of the unbound nats message fails but got nil")
+	}
+	if !result[0].ackGroup.failed {
+		t.Error("Expected the ack group failed but got not failed")
+	}
+}
@@ -0,0 +1,307 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"mime"
+	"net/textproto"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// ContentTypeStructured a single event in structured mode
+	ContentTypeStructured = "application/cloudevents+json"
+	// ContentTypeBatch a json array of events in structured mode
+	ContentTypeBatch = "application/cloudevents-batch+json"
+	// headerPrefix the attributes of the binary mode. See https://github.com/cloudevents/spec/blob/main/cloudevents/bindings/nats-protocol-binding.md
+	headerPrefix = "Ce-"
+	// labelPrefix the subject and the extension attributes are kept as labels with this prefix
+	labelPrefix = "ce_"
+)
+
+// contentTypePatternKeys the extractors of the data by datacontenttype
+// A json record is expected to be a native ecs log
+var contentTypePatternKeys = map[string]model.MetaLog_PatternKey{
+	"application/json":     model.MetaLog_Ecs,
+	"application/ecs+json": model.MetaLog_Ecs,
+	"application/x-logfmt": model.MetaLog_LogFmt,
+	"text/x-logfmt":        model.MetaLog_LogFmt,
+	"text/plain":           model.MetaLog_Nop,
+}
+
+// CloudEventsToEcsConverter unwraps CloudEvents whose data is a log record
+// Structured mode (single or batch) and binary mode with the attributes in the nats headers are supported
+// The dataschema or the datacontenttype selects the extractor of the data
+type CloudEventsToEcsConverter struct {
+}
+
+// Event the context attributes and the data of a CloudEvent. See https://github.com/cloudevents/spec/blob/main/cloudevents/spec.md
+type Event struct {
+	SpecVersion     string
+	Id              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            string
+	DataContentType string
+	DataSchema      string
+	Extensions      map[string]string
+	Data            []byte
+}
+
+func (r *CloudEventsToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	// Only called if the converter is not used as ingress.MultiMetaLogConverter
+	return r.ConvertToMetaLogs(msg)[0]
+}
+
+func (r *CloudEventsToEcsConverter) ConvertToMetaLogs(msg *nats.Msg) []ingress.IngressMsgContext {
+	events, err := Decode(msg)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return []ingress.IngressMsgContext{ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)}
+	}
+	if len(events) == 0 {
+		// An empty batch. Nothing to do
+		return []ingress.IngressMsgContext{{Skip: true, NatsMsg: msg}}
+	}
+	result := make([]ingress.IngressMsgContext, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.toMetaLog(msg))
+	}
+	return result
+}
+
+// Decode the events of a message. Binary mode if the header Ce-Specversion is set. Structured mode otherwise
+func Decode(msg *nats.Msg) ([]*Event, error) {
+	attributes := make(map[string]string)
+	for name, values := range msg.Header {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if attribute, found := strings.CutPrefix(name, headerPrefix); found && len(values) > 0 {
+			attributes[strings.ToLower(attribute)] = values[0]
+		}
+	}
+	if len(attributes["specversion"]) > 0 {
+		event, err := fromAttributes(attributes)
+		if err != nil {
+			return nil, err
+		}
+		if len(event.DataContentType) == 0 {
+			event.DataContentType = msg.Header.Get(ingress.HeaderContentType)
+		}
+		event.Data = msg.Data
+		return []*Event{event}, nil
+	}
+	trimmed := bytes.TrimSpace(msg.Data)
+	if strings.HasPrefix(msg.Header.Get(ingress.HeaderContentType), ContentTypeBatch) || bytes.HasPrefix(trimmed, []byte("[")) {
+		var elements []json.RawMessage
+		err := json.Unmarshal(trimmed, &elements)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode cloudevents batch: %w", err)
+		}
+		events := make([]*Event, 0, len(elements))
+		for _, element := range elements {
+			event, err := decodeStructured(element)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	}
+	event, err := decodeStructured(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return []*Event{event}, nil
+}
+
+// decodeStructured an event in json format. See https://github.com/cloudevents/spec/blob/main/cloudevents/formats/json-format.md
+func decodeStructured(data []byte) (*Event, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode cloudevent: %w", err)
+	}
+	attributes := make(map[string]string, len(fields))
+	for name, value := range fields {
+		if name == "data" || name == "data_base64" {
+			continue
+		}
+		var text string
+		if json.Unmarshal(value, &text) != nil {
+			// Extension attributes may be numbers or booleans
+			text = string(value)
+		}
+		attributes[name] = text
+	}
+	event, err := fromAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+	if encoded, ok := fields["data_base64"]; ok {
+		var text string
+		err = json.Unmarshal(encoded, &text)
+		if err == nil {
+			event.Data, err = base64.StdEncoding.DecodeString(text)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid data_base64 of cloudevent %s: %w", event.Id, err)
+		}
+		return event, nil
+	}
+	if value, ok := fields["data"]; ok {
+		var text string
+		if json.Unmarshal(value, &text) == nil {
+			event.Data = []byte(text)
+		} else {
+			event.Data = value
+		}
+		if len(event.DataContentType) == 0 {
+			// See https://github.com/cloudevents/spec/blob/main/cloudevents/formats/json-format.md#31-handling-of-data
+			event.DataContentType = ingress.ContentTypeJson
+		}
+	}
+	return event, nil
+}
+
+func fromAttributes(attributes map[string]string) (*Event, error) {
+	event := &Event{Extensions: make(map[string]string)}
+	for name, value := range attributes {
+		switch name {
+		case "specversion":
+			event.SpecVersion = value
+		case "id":
+			event.Id = value
+		case "source":
+			event.Source = value
+		case "type":
+			event.Type = value
+		case "subject":
+			event.Subject = value
+		case "time":
+			event.Time = value
+		case "datacontenttype":
+			event.DataContentType = value
+		case "dataschema":
+			event.DataSchema = value
+		default:
+			event.Extensions[name] = value
+		}
+	}
+	if !strings.HasPrefix(event.SpecVersion, "1.") {
+		return nil, fmt.Errorf("unsupported cloudevents specversion %q", event.SpecVersion)
+	}
+	if len(event.Id) == 0 || len(event.Source) == 0 || len(event.Type) == 0 {
+		return nil, errors.New("cloudevent without id, source or type")
+	}
+	return event, nil
+}
+
+// PatternKey the extractor of the data
+// The last path segment of the dataschema (without extension) if it names a pattern key. The datacontenttype otherwise
+func (e *Event) PatternKey() model.MetaLog_PatternKey {
+	if len(e.DataSchema) > 0 {
+		name := strings.TrimSuffix(path.Base(e.DataSchema), path.Ext(e.DataSchema))
+		if key := model.StringToLogPatternKey(name); strings.EqualFold(key.String(), name) {
+			return key
+		}
+	}
+	mediaType, _, err := mime.ParseMediaType(e.DataContentType)
+	if err != nil {
+		return model.MetaLog_Nop
+	}
+	if key, ok := contentTypePatternKeys[mediaType]; ok {
+		return key
+	}
+	return model.MetaLog_Nop
+}
+
+// patternKeyName the name of a user defined pattern named by the dataschema. The name of the PatternKey otherwise
+func (e *Event) patternKeyName() string {
+	name := strings.TrimSuffix(path.Base(e.DataSchema), path.Ext(e.DataSchema))
+	if len(e.DataSchema) > 0 && model.IsUserPatternKey(name) {
+		return strings.ToLower(name)
+	}
+	return e.PatternKey().String()
+}
+
+func (e *Event) toMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	patternKey := e.PatternKey()
+	if patternKey == model.MetaLog_Ecs {
+		// The data is a native ecs log. The attributes fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, e.withTimestamp())
+		e.toEcs(ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, e.patternKeyName(), ingress.RawData(e.Data), e.ts(msg))
+	e.toEcs(ctx.MetaLog.EcsLogEntry)
+	return ctx
+}
+
+// toEcs id, type and source are the ecs event. The source is the service name if not set yet
+// The subject and the extension attributes are kept as labels. The extension traceparent is the trace
+func (e *Event) toEcs(ecs *model.EcsLogEntry) {
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	if len(e.Subject) > 0 {
+		ecs.Labels[labelPrefix+"subject"] = e.Subject
+	}
+	for name, value := range e.Extensions {
+		ecs.Labels[labelPrefix+name] = value
+	}
+	ecs.Event = &model.Event{
+		Kind:     "event",
+		Id:       e.Id,
+		Action:   e.Type,
+		Provider: e.Source,
+	}
+	if !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(e.Source)
+	}
+	// See https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/distributed-tracing.md
+	if parts := strings.Split(e.Extensions["traceparent"], "-"); len(parts) == 4 && !ecs.IsTraceIdSet() {
+		ecs.Trace = &model.Tracing{
+			Trace: &model.Tracing_Trace{Id: parts[1]},
+			Span:  &model.Tracing_Span{Id: parts[2]},
+		}
+	}
+}
+
+// withTimestamp the ecs data with the time of the event as @timestamp if missing there
+func (e *Event) withTimestamp() []byte {
+	if len(e.Time) == 0 {
+		return e.Data
+	}
+	var record map[string]json.RawMessage
+	if json.Unmarshal(e.Data, &record) != nil {
+		return e.Data
+	}
+	if _, ok := record["@timestamp"]; ok {
+		return e.Data
+	}
+	record["@timestamp"], _ = json.Marshal(e.Time)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return e.Data
+	}
+	return data
+}
+
+// ts the time of the event. The ingestion time if missing
+func (e *Event) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	if timestamp, err := time.Parse(time.RFC3339Nano, e.Time); err == nil {
+		return timestamppb.New(timestamp)
+	}
+	return ingress.TimestampFromIngestion(msg)
+}
@@ -0,0 +1,142 @@
+package cloudevents
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+func ceMsg(data string, headers map[string]string) *nats.Msg {
+	msg := nats.NewMsg("ingress.logs.cloudevents")
+	msg.Data = []byte(data)
+	for name, value := range headers {
+		msg.Header.Set(name, value)
+	}
+	return msg
+}
+
+func TestStructured(t *testing.T) {
+	converter := CloudEventsToEcsConverter{}
+	results := converter.ConvertToMetaLogs(ceMsg(`{
+		"specversion":"1.0","id":"A234-1234","source":"/billing/invoices","type":"com.acme.invoice.sent",
+		"subject":"invoice-42","time":"2024-03-01T12:00:00.123Z","datacontenttype":"text/x-logfmt",
+		"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01","tenant":7,
+		"data":"level=warn msg=\"invoice delayed\""}`, map[string]string{ingress.HeaderContentType: ContentTypeStructured}))
+	if len(results) != 1 {
+		t.Fatalf("Expected one event but got %d", len(results))
+	}
+	metaLog := results[0].MetaLog
+	ecs := metaLog.EcsLogEntry
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if metaLog.PatternKey != model.MetaLog_LogFmt || metaLog.RawMessage != `level=warn msg="invoice delayed"` {
+		t.Errorf("Expected the logfmt data but got %s %s", metaLog.PatternKey, metaLog.RawMessage)
+	}
+	if ecs.Event.Id != "A234-1234" || ecs.Event.Action != "com.acme.invoice.sent" || ecs.Event.Provider != "/billing/invoices" {
+		t.Errorf("Expected the event A234-1234 but got %v", ecs.Event)
+	}
+	if ecs.Service.Name != "/billing/invoices" {
+		t.Errorf("Expected the source as service but got %s", ecs.Service.Name)
+	}
+	if ecs.Timestamp.AsTime().UnixMilli() != 1709294400123 {
+		t.Errorf("Expected timestamp 1709294400123 but got %d", ecs.Timestamp.AsTime().UnixMilli())
+	}
+	if ecs.Labels["ce_subject"] != "invoice-42" || ecs.Labels["ce_tenant"] != "7" {
+		t.Errorf("Expected the labels ce_subject and ce_tenant but got %v", ecs.Labels)
+	}
+	if ecs.Trace.Trace.Id != "0af7651916cd43dd8448eb211c80319c" || ecs.Trace.Span.Id != "b7ad6b7169203331" {
+		t.Errorf("Expected the trace of the traceparent but got %v", ecs.Trace)
+	}
+}
+
+func TestStructuredEcsData(t *testing.T) {
+	converter := CloudEventsToEcsConverter{}
+	results := converter.ConvertToMetaLogs(ceMsg(`[
+		{"specversion":"1.0","id":"1","source":"billing","type":"log","time":"2024-03-01T12:00:00Z",
+		 "data":{"message":"from ecs","service":{"name":"payment"},"log":{"level":"error"}}},
+		{"specversion":"1.0","id":"2","source":"billing","type":"log","dataschema":"https://schemas.acme.com/logfmt.json",
+		 "data_base64":"bGV2ZWw9aW5mbw=="}]`, nil))
+	if len(results) != 2 {
+		t.Fatalf("Expected two events but got %d", len(results))
+	}
+	ecs := results[0].MetaLog.EcsLogEntry
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if results[0].MetaLog.PatternKey != model.MetaLog_Ecs || ecs.Message != "from ecs" || ecs.Service.Name != "payment" || ecs.Log.Level != model.LogLevel_error {
+		t.Errorf("Expected the ecs log of payment with level error but got %s %v %s", ecs.Message, ecs.Service, ecs.Log.Level)
+	}
+	if ecs.Timestamp.AsTime().Unix() != 1709294400 || ecs.Event.Id != "1" {
+		t.Errorf("Expected the time and id of the event but got %v %v", ecs.Timestamp.AsTime(), ecs.Event)
+	}
+	if results[1].MetaLog.PatternKey != model.MetaLog_LogFmt || results[1].MetaLog.RawMessage != "level=info" {
+		t.Errorf("Expected the logfmt data of the dataschema but got %s %s", results[1].MetaLog.PatternKey, results[1].MetaLog.RawMessage)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	converter := CloudEventsToEcsConverter{}
+	results := converter.ConvertToMetaLogs(ceMsg("level=info msg=started", map[string]string{
+		"ce-specversion":          "1.0",
+		"ce-id":                   "42",
+		"Ce-Source":               "orders",
+		"ce-type":                 "com.acme.order.log",
+		"ce-time":                 "2024-03-01T12:00:00Z",
+		"ce-region":               "eu",
+		ingress.HeaderContentType: "application/x-logfmt; charset=utf-8",
+		"X-Other":                 "ignored",
+	}))
+	metaLog := results[0].MetaLog
+	if metaLog.EcsLogEntry.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", metaLog.EcsLogEntry.ProcessError.Reason)
+	}
+	if metaLog.PatternKey != model.MetaLog_LogFmt || metaLog.EcsLogEntry.Event.Id != "42" || metaLog.EcsLogEntry.Service.Name != "orders" {
+		t.Errorf("Expected the logfmt event 42 of orders but got %s %v %v", metaLog.PatternKey, metaLog.EcsLogEntry.Event, metaLog.EcsLogEntry.Service)
+	}
+	if len(metaLog.EcsLogEntry.Labels) != 1 || metaLog.EcsLogEntry.Labels["ce_region"] != "eu" {
+		t.Errorf("Expected the label ce_region but got %v", metaLog.EcsLogEntry.Labels)
+	}
+}
+
+func TestPatternKey(t *testing.T) {
+	tests := []struct {
+		pos      int
+		event    Event
+		expected model.MetaLog_PatternKey
+	}{
+		{pos: 1, event: Event{DataSchema: "https://schemas.acme.com/v1/LogFmt.json"}, expected: model.MetaLog_LogFmt},
+		{pos: 2, event: Event{DataSchema: "https://schemas.acme.com/v1/invoice.json", DataContentType: "application/json"}, expected: model.MetaLog_Ecs},
+		{pos: 3, event: Event{DataContentType: "text/plain"}, expected: model.MetaLog_Nop},
+		{pos: 4, event: Event{DataContentType: "application/xml"}, expected: model.MetaLog_Nop},
+		{pos: 5, event: Event{}, expected: model.MetaLog_Nop},
+	}
+	for _, test := range tests {
+		if actual := test.event.PatternKey(); actual != test.expected {
+			t.Errorf("Pos %d: expected %s but got %s", test.pos, test.expected, actual)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	converter := CloudEventsToEcsConverter{}
+	tests := []struct {
+		pos  int
+		data string
+	}{
+		{pos: 1, data: `{"specversion":"0.3","id":"1","source":"a","type":"b"}`},
+		{pos: 2, data: `{"specversion":"1.0","source":"a","type":"b"}`},
+		{pos: 3, data: `{"specversion":"1.0","id":"1","source":"a","type":"b","data_base64":"!"}`},
+		{pos: 4, data: `not json`},
+	}
+	for _, test := range tests {
+		results := converter.ConvertToMetaLogs(ceMsg(test.data, nil))
+		if len(results) != 1 || !results[0].MetaLog.EcsLogEntry.HasProcessError() {
+			t.Errorf("Pos %d: expected a process error", test.pos)
+		}
+	}
+	if results := converter.ConvertToMetaLogs(ceMsg("[]", nil)); !results[0].Skip {
+		t.Errorf("Expected an empty batch is skipped")
+	}
+}
@@ -0,0 +1,116 @@
+package ingress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/nats-io/nats.go"
+	"io"
+	"strings"
+)
+
+const (
+	HeaderContentEncoding = "Content-Encoding"
+	ContentEncodingGzip   = "gzip"
+	ContentEncodingZstd   = "zstd"
+	ContentEncodingSnappy = "snappy"
+	// maxDecompressedSize protects against decompression bombs
+	maxDecompressedSize = 64 << 20
+)
+
+// The stream identifier of the snappy framing format. Without it the payload is a snappy block
+var snappyStreamMagic = []byte("\xff\x06\x00\x00sNaPpY")
+
+var errTooLarge = fmt.Errorf("decompressed payload exceeds %d bytes", maxDecompressedSize)
+
+// zstdDecoder DecodeAll is safe for concurrent use
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedSize))
+
+// Decompress returns a copy of msg with the decompressed payload if the header Content-Encoding is set
+// The message is returned as it is without the header
+func Decompress(msg *nats.Msg) (*nats.Msg, error) {
+	encoding := msg.Header.Get(HeaderContentEncoding)
+	if !IsCompressed(encoding) {
+		return msg, nil
+	}
+	data, err := DecompressData(encoding, msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	header := nats.Header{}
+	for k, v := range msg.Header {
+		header[k] = v
+	}
+	header.Del(HeaderContentEncoding)
+	return &nats.Msg{
+		Subject: msg.Subject,
+		Reply:   msg.Reply,
+		Header:  header,
+		Data:    data,
+		Sub:     msg.Sub,
+	}, nil
+}
+
+// IsCompressed false for an empty or identity content encoding
+func IsCompressed(encoding string) bool {
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+	return len(encoding) > 0 && encoding != "identity"
+}
+
+// DecompressData decompresses data with the given content encoding gzip, zstd or snappy
+func DecompressData(encoding string, data []byte) ([]byte, error) {
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+	var result []byte
+	var err error
+	switch encoding {
+	case ContentEncodingGzip:
+		result, err = gunzip(data)
+	case ContentEncodingZstd:
+		result, err = zstdDecoder.DecodeAll(data, nil)
+	case ContentEncodingSnappy:
+		result, err = unsnappy(data)
+	default:
+		return nil, fmt.Errorf("unsupported %s %s", HeaderContentEncoding, encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress %s payload: %w", encoding, err)
+	}
+	return result, nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return readLimited(reader)
+}
+
+func unsnappy(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, snappyStreamMagic) {
+		return readLimited(snappy.NewReader(bytes.NewReader(data)))
+	}
+	length, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxDecompressedSize {
+		return nil, errTooLarge
+	}
+	return snappy.Decode(nil, data)
+}
+
+func readLimited(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(data) > maxDecompressedSize {
+		return nil, errTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,96 @@
+package ingress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/nats-io/nats.go"
+	"testing"
+)
+
+const testPayload = `{"message":"compressed log entry"}`
+
+func compressedMsg(encoding string, data []byte) *nats.Msg {
+	msg := &nats.Msg{Subject: "ingress.logs.ecs", Data: data, Header: nats.Header{}}
+	msg.Header.Set(HeaderContentEncoding, encoding)
+	msg.Header.Set(HeaderContentType, ContentTypeJson)
+	return msg
+}
+
+func TestDecompress(t *testing.T) {
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, _ = gzipWriter.Write([]byte(testPayload))
+	_ = gzipWriter.Close()
+
+	zstdEncoder, _ := zstd.NewWriter(nil)
+	zstded := zstdEncoder.EncodeAll([]byte(testPayload), nil)
+
+	var snappyFramed bytes.Buffer
+	snappyWriter := snappy.NewBufferedWriter(&snappyFramed)
+	_, _ = snappyWriter.Write([]byte(testPayload))
+	_ = snappyWriter.Close()
+
+	tests := []struct {
+		pos      int
+		encoding string
+		data     []byte
+	}{
+		{pos: 1, encoding: ContentEncodingGzip, data: gzipped.Bytes()},
+		{pos: 2, encoding: ContentEncodingZstd, data: zstded},
+		{pos: 3, encoding: ContentEncodingSnappy, data: snappy.Encode(nil, []byte(testPayload))},
+		{pos: 4, encoding: "SNAPPY", data: snappyFramed.Bytes()},
+		{pos: 5, encoding: "", data: []byte(testPayload)},
+	}
+	for _, test := range tests {
+		msg := compressedMsg(test.encoding, test.data)
+		decompressed, err := Decompress(msg)
+		if err != nil {
+			t.Errorf("Pos %d: expected no error but got %s", test.pos, err)
+			continue
+		}
+		if string(decompressed.Data) != testPayload {
+			t.Errorf("Pos %d: expected %s but got %s", test.pos, testPayload, string(decompressed.Data))
+		}
+		if len(decompressed.Header.Get(HeaderContentEncoding)) > 0 {
+			t.Errorf("Pos %d: expected no %s header but got %s", test.pos, HeaderContentEncoding, decompressed.Header.Get(HeaderContentEncoding))
+		}
+		if decompressed.Header.Get(HeaderContentType) != ContentTypeJson {
+			t.Errorf("Pos %d: expected the other headers are kept", test.pos)
+		}
+	}
+}
+
+func TestDecompressError(t *testing.T) {
+	tests := []struct {
+		pos      int
+		encoding string
+	}{
+		{pos: 1, encoding: ContentEncodingGzip},
+		{pos: 2, encoding: ContentEncodingZstd},
+		{pos: 3, encoding: ContentEncodingSnappy},
+		{pos: 4, encoding: "br"},
+	}
+	for _, test := range tests {
+		msg := compressedMsg(test.encoding, []byte(testPayload))
+		_, err := Decompress(msg)
+		if err == nil {
+			t.Errorf("Pos %d: expected error but got nil", test.pos)
+			continue
+		}
+		ctx := ProcessErrorContext(msg, 0, err)
+		if !ctx.MetaLog.EcsLogEntry.HasProcessError() {
+			t.Errorf("Pos %d: expected process error but got none", test.pos)
+		}
+	}
+}
+
+func TestRawData(t *testing.T) {
+	if RawData([]byte(testPayload)) != testPayload {
+		t.Errorf("Expected %s but got %s", testPayload, RawData([]byte(testPayload)))
+	}
+	if RawData([]byte{0xff, 0xfe}) != "base64://4=" {
+		t.Errorf("Expected base64://4= but got %s", RawData([]byte{0xff, 0xfe}))
+	}
+}
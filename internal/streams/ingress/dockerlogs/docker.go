@@ -1,115 +1,189 @@
 package dockerlogs
 
-//
-//import (
-//	"encoding/json"
-//	"github.com/nats-io/nats.go"
-//	"github.com/suikast42/logunifier/internal/streams/ingress"
-//	"github.com/suikast42/logunifier/pkg/model"
-//	"github.com/suikast42/logunifier/pkg/patterns"
-//	"google.golang.org/protobuf/types/known/timestamppb"
-//	"strings"
-//	"time"
-//)
-//
-//type DockerToEcsConverter struct {
-//}
-//type IngessSubjectDockerLogs struct {
-//	ContainerCreatedAt time.Time `json:"container_created_at"`
-//	ContainerId        string    `json:"container_id"`
-//	ContainerName      string    `json:"container_name"`
-//	Host               string    `json:"host"`
-//	Image              string    `json:"image"`
-//	Label              struct {
-//		ComHashicorpNomadAllocId       string `json:"com.hashicorp.nomad.alloc_id"`
-//		ComHashicorpNomadJobId         string `json:"com.hashicorp.nomad.job_id"`
-//		ComHashicorpNomadJobName       string `json:"com.hashicorp.nomad.job_name"`
-//		ComHashicorpNomadNamespace     string `json:"com.hashicorp.nomad.namespace"`
-//		ComHashicorpNomadNodeId        string `json:"com.hashicorp.nomad.node_id"`
-//		ComHashicorpNomadNodeName      string `json:"com.hashicorp.nomad.node_name"`
-//		ComHashicorpNomadTaskGroupName string `json:"com.hashicorp.nomad.task_group_name"`
-//		ComHashicorpNomadTaskName      string `json:"com.hashicorp.nomad.task_name"`
-//		OrgOpencontainersImageRevision string `json:"org.opencontainers.image.revision"`
-//		OrgOpencontainersImageSource   string `json:"org.opencontainers.image.source"`
-//		OrgOpencontainersImageTitle    string `json:"org.opencontainers.image.title"`
-//	} `json:"label"`
-//	Message    string    `json:"message"`
-//	SourceType string    `json:"source_type"`
-//	Stream     string    `json:"stream"`
-//	Timestamp  time.Time `json:"timestamp"`
-//}
-//
-//var containerToPattern = map[string]patterns.PatternKey{
-//	"keycloak": patterns.KeyCloakPattern,
-//	"nexus":    patterns.CommonUtcPatternWithCommaTsAndTz,
-//}
-//
-//func (r *DockerToEcsConverter) Convert(msg *nats.Msg) *model.EcsLogEntry {
-//	dockerLogEntry := IngessSubjectDockerLogs{}
-//	err := json.Unmarshal(msg.Data, &dockerLogEntry)
-//	if err != err {
-//		return model.ToUnmarshalError(msg, err)
-//	}
-//	patternKey := dockerLogEntry.Label.ComHashicorpNomadTaskName
-//	if patternKey == "" {
-//		patternKey = dockerLogEntry.ContainerName
-//	}
-//	pattern, patternFound := containerToPattern[patternKey]
-//
-//	if !patternFound {
-//		if strings.HasPrefix(patternKey, "connect-proxy-") {
-//			containerToPattern[patternKey] = patterns.ConsulConnectPattern
-//			pattern, patternFound = containerToPattern[patternKey]
-//		} else if strings.HasSuffix(patternKey, "postgres") {
-//			containerToPattern[patternKey] = patterns.ConsulConnectPattern
-//			pattern, patternFound = containerToPattern[patternKey]
-//		} else {
-//			containerToPattern[patternKey] = patterns.CommonPattern
-//			pattern, patternFound = containerToPattern[patternKey]
-//		}
-//	}
-//
-//	var parsed patterns.ParseResult
-//	// A registered pattern found for message
-//	def := patterns.ParseResult{
-//		LogLevel:  model.LogLevel_unknown,
-//		TimeStamp: dockerLogEntry.Timestamp,
-//	}
-//	parsed, err = patterns.Instance().ParseWitDefaults("IngessSubjectDockerLogs", patternKey, def, pattern, dockerLogEntry.Message)
-//	if err != nil {
-//		return model.ToUnmarshalError(msg, err)
-//	}
-//	return &model.EcsLogEntry{
-//		Id:        model.UUID(),
-//		Timestamp: timestamppb.New(parsed.TimeStamp),
-//		Tags:      []string{dockerLogEntry.SourceType},
-//		Log: &model.Log{
-//			Level: parsed.LogLevel,
-//		},
-//		Message: dockerLogEntry.Message,
-//		Container: &model.Container{
-//			Id:        dockerLogEntry.ContainerId,
-//			Name:      dockerLogEntry.ContainerName,
-//			CreatedAt: timestamppb.New(dockerLogEntry.ContainerCreatedAt),
-//			Image: &model.Container_Image{
-//				Name: dockerLogEntry.Image,
-//				Tag:  nil,
-//			},
-//			Labels: map[string]string{
-//				ingress.IndexedContainerLabelStackName: dockerLogEntry.Label.ComHashicorpNomadJobName,
-//				ingress.IndexedContainerLabelTaskGroup: dockerLogEntry.Label.ComHashicorpNomadTaskGroupName,
-//				ingress.IndexedContainerLabelTask:      patternKey,
-//				ingress.IndexedContainerLabelNamespace: dockerLogEntry.Label.ComHashicorpNomadNamespace,
-//			},
-//			Runtime: "",
-//		},
-//		Host: &model.Host{
-//			Name: dockerLogEntry.Host,
-//		},
-//
-//		Labels: map[string]string{
-//			ingress.IndexedLabelIngress:     "vector-docker",
-//			ingress.IndexedLabelUsedPattern: parsed.UsedPattern,
-//		},
-//	}
-//}
+import (
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/journald"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// partialTimeout the parts of a message are dropped if the last part is not received within this duration
+const partialTimeout = time.Minute
+
+// partialMessage the parts of a message received so far
+type partialMessage struct {
+	parts    []IngessSubjectDockerLogs
+	lastSeen time.Time
+}
+
+var (
+	partialCacheMtx sync.Mutex
+	// partialCache collects the partial messages of a container stream until the last part is received
+	// The parts are acked as soon as they are cached. So the parts of an incomplete message are lost on a restart
+	partialCache = map[string]*partialMessage{}
+)
+
+// DockerToEcsConverter converts the logs shipped by the vector docker_logs source
+// The container labels are translated to the journald fields the docker journald log driver emits.
+// So that the same metadata extraction of the journald.JournaldDToEcsConverter applies
+type DockerToEcsConverter struct {
+	journald journald.JournaldDToEcsConverter
+}
+
+// IngessSubjectDockerLogs For the vector docker_logs fields see https://vector.dev/docs/reference/configuration/sources/docker_logs/#output-data
+type IngessSubjectDockerLogs struct {
+	ContainerCreatedAt time.Time         `json:"container_created_at"`
+	ContainerId        string            `json:"container_id"`
+	ContainerName      string            `json:"container_name"`
+	Host               string            `json:"host"`
+	Image              string            `json:"image"`
+	Label              map[string]string `json:"label"`
+	Message            string            `json:"message"`
+	// Partial is set by vector if auto_partial_merge is disabled
+	// Every part of a message is marked with true except the last one
+	Partial    bool      `json:"_partial"`
+	SourceType string    `json:"source_type"`
+	Stream     string    `json:"stream"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (r *DockerToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	dockerLogEntry := IngessSubjectDockerLogs{}
+	err := json.Unmarshal(msg.Data, &dockerLogEntry)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return r.journald.ConvertError(msg, err)
+	}
+
+	partialKey := dockerLogEntry.partialKey()
+	if dockerLogEntry.Partial {
+		addPartial(partialKey, dockerLogEntry, time.Now())
+		return ingress.IngressMsgContext{
+			Skip: true,
+		}
+	}
+	if partialMessages, ok := takePartials(partialKey); ok {
+		// That's the last part. Vector ships the parts in order
+		var tmpMsg = ""
+		for _, partial := range partialMessages {
+			tmpMsg += partial.Message
+		}
+		dockerLogEntry.Message = tmpMsg + dockerLogEntry.Message
+	}
+
+	journaldEntry, err := dockerLogEntry.toJournald()
+	if err != nil {
+		return r.journald.ConvertError(msg, err)
+	}
+	result := r.journald.ConvertFrom(msg, journaldEntry)
+	if result.Skip {
+		return result
+	}
+	dockerLogEntry.extractContainerMetadata(result)
+	return result
+}
+
+// addPartial caches a part of the message and drops the parts of the messages that are never completed
+func addPartial(key string, part IngessSubjectDockerLogs, now time.Time) {
+	partialCacheMtx.Lock()
+	defer partialCacheMtx.Unlock()
+	partial, ok := partialCache[key]
+	if !ok || now.Sub(partial.lastSeen) > partialTimeout {
+		partial = &partialMessage{}
+		partialCache[key] = partial
+	}
+	partial.parts = append(partial.parts, part)
+	partial.lastSeen = now
+	for k, p := range partialCache {
+		if now.Sub(p.lastSeen) > partialTimeout {
+			delete(partialCache, k)
+		}
+	}
+}
+
+// takePartials removes the cached parts of the message
+func takePartials(key string) ([]IngessSubjectDockerLogs, bool) {
+	partialCacheMtx.Lock()
+	defer partialCacheMtx.Unlock()
+	partial, ok := partialCache[key]
+	if !ok {
+		return nil, false
+	}
+	delete(partialCache, key)
+	return partial.parts, true
+}
+
+// toJournald translates the docker entry into the fields of the docker journald log driver
+// The label com.hashicorp.nomad.alloc_id becomes COM_HASHICORP_NOMAD_ALLOC_ID for example
+func (r *IngessSubjectDockerLogs) toJournald() (journald.IngressSubjectJournald, error) {
+	fields := make(map[string]string)
+	for k, v := range r.Label {
+		// Only namespaced labels like com.hashicorp.* or org.opencontainers.*
+		// A plain label like timestamp would collide with the vector fields
+		if !strings.Contains(k, ".") {
+			continue
+		}
+		fields[labelToJournaldField(k)] = v
+	}
+	shortId := r.ContainerId
+	if len(shortId) > 12 {
+		shortId = shortId[:12]
+	}
+	fields["CONTAINER_ID"] = shortId
+	fields["CONTAINER_ID_FULL"] = r.ContainerId
+	fields["CONTAINER_NAME"] = strings.TrimPrefix(r.ContainerName, "/")
+	fields["CONTAINER_TAG"] = shortId
+	fields["SYSLOG_IDENTIFIER"] = shortId
+	fields["IMAGE_NAME"] = r.Image
+	fields["PRIORITY"] = r.priority()
+	fields["__REALTIME_TIMESTAMP"] = strconv.FormatInt(r.Timestamp.UnixMicro(), 10)
+	fields["host"] = r.Host
+	fields["message"] = r.Message
+	fields["source_type"] = r.SourceType
+
+	result := journald.IngressSubjectJournald{}
+	marshal, err := json.Marshal(fields)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(marshal, &result)
+	// The timestamp is not a string field in journald
+	result.Timestamp = r.Timestamp
+	return result, err
+}
+
+func (r *IngessSubjectDockerLogs) extractContainerMetadata(msgCtx ingress.IngressMsgContext) {
+	ecs := msgCtx.MetaLog.EcsLogEntry
+	if ecs == nil || ecs.Container == nil {
+		return
+	}
+	ecs.Container.Runtime = "docker"
+	if !r.ContainerCreatedAt.IsZero() {
+		ecs.Container.CreatedAt = timestamppb.New(r.ContainerCreatedAt)
+	}
+	if ecs.Container.Labels == nil {
+		ecs.Container.Labels = make(map[string]string)
+	}
+	ecs.Container.Labels["stream"] = r.Stream
+}
+
+// priority the docker journald log driver logs stderr with priority err and stdout with info
+func (r *IngessSubjectDockerLogs) priority() string {
+	if r.Stream == "stderr" {
+		return "3"
+	}
+	return "6"
+}
+
+func (r *IngessSubjectDockerLogs) partialKey() string {
+	return r.ContainerId + "@" + r.Stream
+}
+
+func labelToJournaldField(label string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(label))
+}
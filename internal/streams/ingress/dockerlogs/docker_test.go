@@ -0,0 +1,148 @@
+package dockerlogs
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+	"time"
+)
+
+const testDockerLog = `{
+  "container_created_at": "2023-05-02T08:49:11.163372375Z",
+  "container_id": "3c0fdd1a6f30b2e8f6b4d1a6c0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1",
+  "container_name": "/mimir-f1e2d3c4",
+  "host": "worker-01",
+  "image": "grafana/mimir:2.8.0",
+  "label": {
+    "com.github.logunifier.application.name": "mimir",
+    "com.github.logunifier.application.version": "2.8.0",
+    "com.github.logunifier.application.pattern.key": "logfmt",
+    "com.github.logunifier.application.org": "logunifier",
+    "com.github.logunifier.application.env": "dev",
+    "com.hashicorp.nomad.alloc_id": "a1b2c3d4",
+    "com.hashicorp.nomad.job_name": "observability",
+    "com.hashicorp.nomad.namespace": "default",
+    "com.hashicorp.nomad.task_group_name": "mimir",
+    "com.hashicorp.nomad.task_name": "mimir",
+    "org.opencontainers.image.revision": "2.8.0",
+    "org.opencontainers.image.source": "https://github.com/grafana/mimir",
+    "maintainer": "grafana",
+    "timestamp": "not a timestamp"
+  },
+  "message": "level=warn ts=2023-05-02T08:49:12.123Z msg=\"TestMessage\"",
+  "source_type": "docker_logs",
+  "stream": "stderr",
+  "timestamp": "2023-05-02T08:49:12.123456789Z"
+}`
+
+func TestDockerLogToMetaLog(t *testing.T) {
+	converter := &DockerToEcsConverter{}
+	msg := &nats.Msg{Subject: "ingress.logs.docker", Data: []byte(testDockerLog)}
+	result := converter.ConvertToMetaLog(msg)
+	if result.Skip {
+		t.Fatal("Expected not skipped but got skipped")
+	}
+	ecs := result.MetaLog.EcsLogEntry
+	if reason := ecs.ProcessError.Reason; len(reason) > 0 {
+		t.Fatalf("Expected no process error but got %s", reason)
+	}
+	if result.MetaLog.PatternKey != model.MetaLog_LogFmt {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_LogFmt, result.MetaLog.PatternKey)
+	}
+	if ecs.Service.Name != "mimir" {
+		t.Errorf("Expected service name mimir but got %s", ecs.Service.Name)
+	}
+	if ecs.Service.Version != "2.8.0" {
+		t.Errorf("Expected service version 2.8.0 but got %s", ecs.Service.Version)
+	}
+	if ecs.Service.Type != string(ingress.JobTypeNomadJob) {
+		t.Errorf("Expected service type %s but got %s", ingress.JobTypeNomadJob, ecs.Service.Type)
+	}
+	if ecs.Service.Stack != "observability" {
+		t.Errorf("Expected service stack observability but got %s", ecs.Service.Stack)
+	}
+	if ecs.Container == nil {
+		t.Fatal("Expected container not nil but got nil")
+	}
+	if ecs.Container.Id != "3c0fdd1a6f30" {
+		t.Errorf("Expected container id 3c0fdd1a6f30 but got %s", ecs.Container.Id)
+	}
+	if ecs.Container.Name != "mimir-f1e2d3c4" {
+		t.Errorf("Expected container name mimir-f1e2d3c4 but got %s", ecs.Container.Name)
+	}
+	if ecs.Container.Image.Name != "grafana/mimir:2.8.0" {
+		t.Errorf("Expected image grafana/mimir:2.8.0 but got %s", ecs.Container.Image.Name)
+	}
+	if ecs.Container.Runtime != "docker" {
+		t.Errorf("Expected runtime docker but got %s", ecs.Container.Runtime)
+	}
+	if ecs.Container.CreatedAt == nil {
+		t.Error("Expected container created at not nil but got nil")
+	}
+	if ecs.Container.Labels["stream"] != "stderr" {
+		t.Errorf("Expected stream stderr but got %s", ecs.Container.Labels["stream"])
+	}
+	if ecs.Container.Labels["ORG_OPENCONTAINERS_IMAGE_SOURCE"] != "https://github.com/grafana/mimir" {
+		t.Errorf("Expected image source https://github.com/grafana/mimir but got %s", ecs.Container.Labels["ORG_OPENCONTAINERS_IMAGE_SOURCE"])
+	}
+	// The level of nomad jobs is left to the pattern parsing
+	if ecs.Log.Level != model.LogLevel_not_set {
+		t.Errorf("Expected level %s but got %s", model.LogLevel_not_set, ecs.Log.Level)
+	}
+	if ecs.Log.Ingress != "ingress.logs.docker" {
+		t.Errorf("Expected ingress ingress.logs.docker but got %s", ecs.Log.Ingress)
+	}
+}
+
+func TestDockerLogPartialMessages(t *testing.T) {
+	converter := &DockerToEcsConverter{}
+	parts := []string{
+		`{"container_id":"abc","container_name":"/app","stream":"stdout","message":"first ","_partial":true,"timestamp":"2023-05-02T08:49:12Z"}`,
+		`{"container_id":"abc","container_name":"/app","stream":"stdout","message":"second ","_partial":true,"timestamp":"2023-05-02T08:49:12Z"}`,
+		`{"container_id":"abc","container_name":"/app","stream":"stdout","message":"last","timestamp":"2023-05-02T08:49:12Z"}`,
+	}
+	var result ingress.IngressMsgContext
+	for i, part := range parts {
+		result = converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.docker", Data: []byte(part)})
+		if i < len(parts)-1 && !result.Skip {
+			t.Errorf("Expected partial %d skipped but got not skipped", i)
+		}
+	}
+	if result.Skip {
+		t.Fatal("Expected last part not skipped but got skipped")
+	}
+	if result.MetaLog.RawMessage != "first second last" {
+		t.Errorf("Expected message 'first second last' but got '%s'", result.MetaLog.RawMessage)
+	}
+	if level := result.MetaLog.EcsLogEntry.Log.Level; level != model.LogLevel_info {
+		t.Errorf("Expected fallback level %s for stdout but got %s", model.LogLevel_info, level)
+	}
+	if _, ok := partialCache["abc@stdout"]; ok {
+		t.Error("Expected partial cache cleared but got an entry")
+	}
+}
+
+func TestDockerLogInvalidJson(t *testing.T) {
+	converter := &DockerToEcsConverter{}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.docker", Data: []byte("not json")})
+	if result.MetaLog == nil || len(result.MetaLog.EcsLogEntry.ProcessError.Reason) == 0 {
+		t.Error("Expected process error but got none")
+	}
+}
+
+func TestDockerLogPartialTimeout(t *testing.T) {
+	now := time.Now()
+	addPartial("lost@stdout", IngessSubjectDockerLogs{Message: "never completed "}, now)
+	addPartial("other@stdout", IngessSubjectDockerLogs{Message: "other"}, now.Add(partialTimeout+time.Second))
+	if _, ok := takePartials("lost@stdout"); ok {
+		t.Error("Expected the parts of the incomplete message are evicted")
+	}
+	addPartial("late@stdout", IngessSubjectDockerLogs{Message: "old "}, now)
+	addPartial("late@stdout", IngessSubjectDockerLogs{Message: "new "}, now.Add(partialTimeout+time.Second))
+	parts, ok := takePartials("late@stdout")
+	if !ok || len(parts) != 1 || parts[0].Message != "new " {
+		t.Errorf("Expected only the part after the timeout but got %v", parts)
+	}
+	takePartials("other@stdout")
+}
@@ -29,6 +29,15 @@ func (r *EcsWrapper) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
 
 }
 
+// ConvertEmbedded converts a native ecs log that is embedded in the message of another ingress
+// For example a kubernetes log line. The context keeps the original message for the ack
+func ConvertEmbedded(msg *nats.Msg, data []byte) ingress.IngressMsgContext {
+	wrapper := EcsWrapper{}
+	ctx := wrapper.ConvertToMetaLog(&nats.Msg{Subject: msg.Subject, Reply: msg.Reply, Header: msg.Header, Sub: msg.Sub, Data: data})
+	ctx.NatsMsg = msg
+	return ctx
+}
+
 func (r *EcsWrapper) fillMissing(err error, msg *nats.Msg, ecs *model.EcsLogEntry) {
 	if ecs.Timestamp == nil {
 		ecs.Timestamp = ingress.TimestampFromIngestion(msg)
@@ -0,0 +1,239 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/httpingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"net/http"
+	"time"
+)
+
+const headerElasticProduct = "X-Elastic-Product"
+
+// BulkHandler serves the minimal elasticsearch api that filebeat and logstash need to ship logs
+// The documents of the bulk requests are published as native ecs logs. So they are converted by ecs.EcsWrapper
+type BulkHandler struct {
+	subject   string
+	version   string
+	publisher ingress.Publisher
+	logger    zerolog.Logger
+}
+
+// bulkItem one action of a bulk request
+type bulkItem struct {
+	action string
+	index  string
+	id     string
+	source []byte
+	status int
+	err    string
+}
+
+type bulkAction struct {
+	Index string `json:"_index"`
+	Id    string `json:"_id"`
+}
+
+func NewBulkHandler(subject string, version string, publisher ingress.Publisher) *BulkHandler {
+	return &BulkHandler{
+		subject:   subject,
+		version:   version,
+		publisher: publisher,
+		logger:    config.Logger(),
+	}
+}
+
+// Mux the cluster info, license and bulk endpoints
+// All other requests are acknowledged. The agents set up templates and policies with them
+func (h *BulkHandler) Mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.info)
+	mux.HandleFunc("GET /_license", h.license)
+	mux.HandleFunc("POST /_bulk", h.bulk)
+	mux.HandleFunc("PUT /_bulk", h.bulk)
+	mux.HandleFunc("POST /{index}/_bulk", h.bulk)
+	mux.HandleFunc("PUT /{index}/_bulk", h.bulk)
+	mux.HandleFunc("/", h.acknowledge)
+	return mux
+}
+
+func (h *BulkHandler) info(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"name":         "logunifier",
+		"cluster_name": "logunifier",
+		"cluster_uuid": "logunifier",
+		"version": map[string]any{
+			"number":                              h.version,
+			"build_flavor":                        "default",
+			"minimum_wire_compatibility_version":  "7.17.0",
+			"minimum_index_compatibility_version": "7.0.0",
+		},
+		"tagline": "You Know, for Search",
+	})
+}
+
+func (h *BulkHandler) license(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"license": map[string]any{
+			"status": "active",
+			"type":   "basic",
+			"mode":   "basic",
+		},
+	})
+}
+
+func (h *BulkHandler) acknowledge(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+// bulk publishes the documents of the index and create actions. Update and delete actions are rejected
+// The response is 500 if the documents can't be published. The agents retry the whole request then
+func (h *BulkHandler) bulk(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	body, err := httpingress.ReadBody(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	items, err := parseBulk(body, r.PathValue("index"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	maxPayload, err := h.publisher.MaxPayload()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	err = h.publisher.Publish(h.messages(items, maxPayload)...)
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("Can't publish bulk request to %s", h.subject)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJson(w, http.StatusOK, bulkResponse(items, time.Since(start)))
+}
+
+// messages the accepted documents as ndjson batches that fit into the max payload
+func (h *BulkHandler) messages(items []*bulkItem, maxPayload int64) []*nats.Msg {
+	var accepted []*bulkItem
+	var documents [][]byte
+	for _, item := range items {
+		if item.status == http.StatusCreated {
+			accepted = append(accepted, item)
+			documents = append(documents, item.source)
+		}
+	}
+	msgs, tooLarge := ingress.NdjsonMessages(h.subject, documents, maxPayload)
+	for _, i := range tooLarge {
+		accepted[i].status = http.StatusRequestEntityTooLarge
+		accepted[i].err = fmt.Sprintf("document exceeds the max payload of %d bytes", maxPayload)
+	}
+	return msgs
+}
+
+// parseBulk the action and source lines of a bulk request
+// See https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+func parseBulk(body []byte, defaultIndex string) ([]*bulkItem, error) {
+	var lines [][]byte
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	var items []*bulkItem
+	for i := 0; i < len(lines); i++ {
+		var actionLine map[string]bulkAction
+		err := json.Unmarshal(lines[i], &actionLine)
+		if err != nil || len(actionLine) != 1 {
+			return nil, fmt.Errorf("malformed action/metadata line [%d]", i+1)
+		}
+		for action, metadata := range actionLine {
+			item := &bulkItem{
+				action: action,
+				index:  metadata.Index,
+				id:     metadata.Id,
+				status: http.StatusCreated,
+			}
+			if len(item.index) == 0 {
+				item.index = defaultIndex
+			}
+			if len(item.id) == 0 {
+				item.id = model.UUID()
+			}
+			switch action {
+			case "index", "create":
+				if i+1 >= len(lines) {
+					return nil, errors.New("the bulk request must be terminated by a source line")
+				}
+				i++
+				item.source = lines[i]
+			case "update":
+				// The partial document is skipped
+				i++
+				item.status = http.StatusBadRequest
+				item.err = "update is not supported"
+			case "delete":
+				item.status = http.StatusBadRequest
+				item.err = "delete is not supported"
+			default:
+				return nil, fmt.Errorf("malformed action/metadata line [%d], unknown action [%s]", i+1, action)
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func bulkResponse(items []*bulkItem, took time.Duration) map[string]any {
+	hasErrors := false
+	responseItems := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		result := map[string]any{
+			"_index": item.index,
+			"_id":    item.id,
+			"status": item.status,
+		}
+		if item.status == http.StatusCreated {
+			result["result"] = "created"
+			result["_version"] = 1
+		} else {
+			hasErrors = true
+			result["error"] = map[string]any{
+				"type":   "illegal_argument_exception",
+				"reason": item.err,
+			}
+		}
+		responseItems = append(responseItems, map[string]any{item.action: result})
+	}
+	return map[string]any{
+		"took":   took.Milliseconds(),
+		"errors": hasErrors,
+		"items":  responseItems,
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJson(w, status, map[string]any{
+		"error": map[string]any{
+			"type":   "logunifier_exception",
+			"reason": err.Error(),
+		},
+		"status": status,
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set(headerElasticProduct, "Elasticsearch")
+	w.Header().Set(ingress.HeaderContentType, ingress.ContentTypeJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
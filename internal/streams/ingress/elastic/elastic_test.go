@@ -0,0 +1,167 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testBulkResponse struct {
+	Errors bool                        `json:"errors"`
+	Items  []map[string]map[string]any `json:"items"`
+}
+
+const testBulk = `{"index":{"_index":"filebeat-8.17.0","_id":"1"}}
+{"@timestamp":"2024-03-01T12:00:00.000Z","message":"first","log":{"level":"info","file":{"path":"/var/log/app.log"},"offset":42},"host":{"name":"worker-01"},"agent":{"type":"filebeat"}}
+{"create":{}}
+{"@timestamp":"2024-03-01T12:00:01.000Z","message":"second","service":{"name":"billing"}}
+{"delete":{"_index":"filebeat-8.17.0","_id":"1"}}
+`
+
+func bulkRequest(handler http.Handler, path string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestBulk(t *testing.T) {
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	handler := NewBulkHandler("ingress.logs.ecs", "8.17.0", publisher).Mux()
+	recorder := bulkRequest(handler, "/logs-default/_bulk", testBulk)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d but got %d %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	response := testBulkResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	if !response.Errors || len(response.Items) != 3 {
+		t.Fatalf("Expected 3 items with errors but got %s", recorder.Body.String())
+	}
+	expected := []struct {
+		action string
+		index  string
+		status float64
+	}{
+		{action: "index", index: "filebeat-8.17.0", status: 201},
+		{action: "create", index: "logs-default", status: 201},
+		{action: "delete", index: "filebeat-8.17.0", status: 400},
+	}
+	for i, e := range expected {
+		item := response.Items[i][e.action]
+		if item == nil || item["_index"] != e.index || item["status"] != e.status {
+			t.Errorf("Expected item %d %s of %s with status %v but got %v", i, e.action, e.index, e.status, response.Items[i])
+		}
+	}
+	if len(publisher.Msgs) != 1 {
+		t.Fatalf("Expected 1 published message but got %d", len(publisher.Msgs))
+	}
+	msg := publisher.Msgs[0]
+	if msg.Subject != "ingress.logs.ecs" || msg.Header.Get(ingress.HeaderContentType) != ingress.ContentTypeNdjson {
+		t.Errorf("Expected a ndjson message to ingress.logs.ecs but got %s %v", msg.Subject, msg.Header)
+	}
+	// The documents are converted as native ecs
+	result := ingress.NewBatchConverter(&ecs.EcsWrapper{}).ConvertToMetaLogs(msg)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 ecs entries but got %d", len(result))
+	}
+	first := result[0].MetaLog.EcsLogEntry
+	if first.HasProcessError() {
+		t.Errorf("Expected no process error but got %s", first.ProcessError.Reason)
+	}
+	if first.Message != "first" || first.Host.Name != "worker-01" || first.Log.File.Path != "/var/log/app.log" {
+		t.Errorf("Expected the filebeat document as ecs but got %v", first)
+	}
+	if first.Log.Level != model.LogLevel_info || first.Log.PatternKey != model.MetaLog_Ecs.String() {
+		t.Errorf("Expected level info and pattern key %s but got %s %s", model.MetaLog_Ecs, first.Log.Level, first.Log.PatternKey)
+	}
+	if result[1].MetaLog.EcsLogEntry.Service.Name != "billing" {
+		t.Errorf("Expected service name billing but got %s", result[1].MetaLog.EcsLogEntry.Service.Name)
+	}
+}
+
+func TestBulkBatches(t *testing.T) {
+	var body bytes.Buffer
+	for i := 0; i < 20; i++ {
+		body.WriteString(`{"index":{}}` + "\n")
+		body.WriteString(`{"@timestamp":"2024-03-01T12:00:00.000Z","message":"` + strings.Repeat("x", 200) + `"}` + "\n")
+	}
+	body.WriteString(`{"index":{}}` + "\n")
+	body.WriteString(`{"@timestamp":"2024-03-01T12:00:00.000Z","message":"` + strings.Repeat("x", 2000) + `"}` + "\n")
+	publisher := &testingress.Publisher{MaxPayloadBytes: 2048}
+	handler := NewBulkHandler("ingress.logs.ecs", "8.17.0", publisher).Mux()
+	recorder := bulkRequest(handler, "/_bulk", body.String())
+	response := testBulkResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	if response.Items[20]["index"]["status"] != float64(http.StatusRequestEntityTooLarge) {
+		t.Errorf("Expected the too large document is rejected but got %v", response.Items[20])
+	}
+	lines := 0
+	for _, msg := range publisher.Msgs {
+		if int64(len(msg.Data)) > publisher.MaxPayloadBytes {
+			t.Errorf("Expected a message smaller than %d but got %d", publisher.MaxPayloadBytes, len(msg.Data))
+		}
+		lines += bytes.Count(msg.Data, []byte("\n"))
+	}
+	if len(publisher.Msgs) < 2 || lines != 20 {
+		t.Errorf("Expected 20 documents in several messages but got %d in %d", lines, len(publisher.Msgs))
+	}
+}
+
+func TestBulkErrors(t *testing.T) {
+	tests := []struct {
+		pos        int
+		body       string
+		publishErr error
+		expected   int
+	}{
+		{pos: 1, body: "no json\n", expected: http.StatusBadRequest},
+		{pos: 2, body: `{"index":{}}` + "\n", expected: http.StatusBadRequest},
+		{pos: 3, body: `{"unknown":{}}` + "\n{}\n", expected: http.StatusBadRequest},
+		{pos: 4, body: testBulk, publishErr: errors.New("nats down"), expected: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024, Err: test.publishErr}
+		handler := NewBulkHandler("ingress.logs.ecs", "8.17.0", publisher).Mux()
+		recorder := bulkRequest(handler, "/_bulk", test.body)
+		if recorder.Code != test.expected {
+			t.Errorf("Pos %d: expected status %d but got %d", test.pos, test.expected, recorder.Code)
+		}
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	handler := NewBulkHandler("ingress.logs.ecs", "8.17.0", &testingress.Publisher{}).Mux()
+	tests := []struct {
+		pos      int
+		method   string
+		path     string
+		expected string
+	}{
+		{pos: 1, method: http.MethodGet, path: "/", expected: `"number":"8.17.0"`},
+		{pos: 2, method: http.MethodGet, path: "/_license", expected: `"status":"active"`},
+		{pos: 3, method: http.MethodPut, path: "/_index_template/filebeat-8.17.0", expected: `"acknowledged":true`},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+		if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), test.expected) {
+			t.Errorf("Pos %d: expected %s but got %d %s", test.pos, test.expected, recorder.Code, recorder.Body.String())
+		}
+		if recorder.Header().Get(headerElasticProduct) != "Elasticsearch" {
+			t.Errorf("Pos %d: expected the header %s", test.pos, headerElasticProduct)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package filetail
+
+import "os"
+
+// fileId is not supported. The checkpoint offset is used as long as the file is not smaller
+func fileId(_ os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package filetail
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileId the inode of the file. A rotated file gets a new inode at the same path
+func fileId(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino)
+	}
+	return 0
+}
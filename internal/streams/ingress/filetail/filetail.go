@@ -0,0 +1,74 @@
+package filetail
+
+import (
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"time"
+)
+
+// Record one line of a tailed file. The Tailer publishes the records as ndjson
+type Record struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Host       string    `json:"host,omitempty"`
+	Path       string    `json:"path"`
+	Offset     int64     `json:"offset"`
+	Service    string    `json:"service,omitempty"`
+	PatternKey string    `json:"pattern_key,omitempty"`
+	Message    string    `json:"message"`
+}
+
+// FileTailToEcsConverter converts the lines of the Tailer
+// The pattern key and the service are configured per glob. See Source
+type FileTailToEcsConverter struct {
+}
+
+func (r *FileTailToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	record := Record{}
+	err := json.Unmarshal(msg.Data, &record)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	patternKey := model.StringToLogPatternKey(record.PatternKey)
+	if patternKey == model.MetaLog_Ecs {
+		// The file contains native ecs logs. The file metadata fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, []byte(record.Message))
+		record.toEcs(ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, model.PatternKeyName(record.PatternKey), record.Message, record.ts(msg))
+	record.toEcs(ctx.MetaLog.EcsLogEntry)
+	return ctx
+}
+
+// toEcs the file path is log.file.path and the offset of the line the label file_offset
+func (r *Record) toEcs(ecs *model.EcsLogEntry) {
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	ecs.Labels["file_offset"] = strconv.FormatInt(r.Offset, 10)
+	if ecs.Log == nil {
+		ecs.Log = &model.Log{}
+	}
+	if ecs.Log.File == nil || len(ecs.Log.File.Path) == 0 {
+		ecs.Log.File = &model.Log_File{Path: r.Path}
+	}
+	if len(r.Host) > 0 && !ecs.IsHostNameSet() {
+		ecs.SetHostName(r.Host)
+	}
+	if len(r.Service) > 0 && !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(r.Service)
+	}
+}
+
+func (r *Record) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	if r.Timestamp.IsZero() {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	return timestamppb.New(r.Timestamp)
+}
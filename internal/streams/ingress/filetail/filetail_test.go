@@ -0,0 +1,58 @@
+package filetail
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+func TestFileTailToEcs(t *testing.T) {
+	tests := []struct {
+		pos        int
+		data       string
+		patternKey model.MetaLog_PatternKey
+		message    string
+		service    string
+	}{
+		{
+			pos:        1,
+			data:       `{"timestamp":"2024-03-01T12:00:00Z","host":"edge-01","path":"/var/log/app.log","offset":42,"service":"billing","pattern_key":"logfmt","message":"level=warn msg=\"invoice delayed\""}`,
+			patternKey: model.MetaLog_LogFmt,
+			message:    `level=warn msg="invoice delayed"`,
+			service:    "billing",
+		},
+		{
+			pos:        2,
+			data:       `{"timestamp":"2024-03-01T12:00:00Z","host":"edge-01","path":"/var/log/app.log","offset":42,"service":"billing","pattern_key":"ecs","message":"{\"@timestamp\":\"2024-03-01T11:59:59.000Z\",\"message\":\"from ecs\",\"service\":{\"name\":\"payment\"}}"}`,
+			patternKey: model.MetaLog_Ecs,
+			message:    "from ecs",
+			service:    "payment",
+		},
+	}
+	converter := FileTailToEcsConverter{}
+	for _, test := range tests {
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.filetail", Data: []byte(test.data)})
+		metaLog := result.MetaLog
+		ecs := metaLog.EcsLogEntry
+		if metaLog.PatternKey != test.patternKey {
+			t.Errorf("Pos %d: expected pattern key %s but got %s", test.pos, test.patternKey, metaLog.PatternKey)
+		}
+		message := metaLog.RawMessage
+		if test.patternKey == model.MetaLog_Ecs {
+			message = ecs.Message
+		}
+		if message != test.message {
+			t.Errorf("Pos %d: expected message %s but got %s", test.pos, test.message, message)
+		}
+		if ecs.Service.Name != test.service {
+			t.Errorf("Pos %d: expected service %s but got %s", test.pos, test.service, ecs.Service.Name)
+		}
+		if ecs.Host.Name != "edge-01" || ecs.Log.File.Path != "/var/log/app.log" || ecs.Labels["file_offset"] != "42" {
+			t.Errorf("Pos %d: expected host edge-01, path /var/log/app.log and offset 42 but got %v %v %v", test.pos, ecs.Host, ecs.Log.File, ecs.Labels)
+		}
+	}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.filetail", Data: []byte("no json")})
+	if !result.MetaLog.EcsLogEntry.HasProcessError() {
+		t.Errorf("Expected a process error")
+	}
+}
@@ -0,0 +1,510 @@
+package filetail
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRead the bytes read from one file per poll. A line without newline that exceeds it is split
+	maxRead = 1 << 20
+	// rotatedIdle a rotated file is read to the end, including the last line without newline, if it did not grow
+	// for this duration. The application may write to it until it reopens its log file
+	rotatedIdle = 30 * time.Second
+)
+
+// Source the files of a glob and the metadata of their lines
+type Source struct {
+	Glob       string
+	Service    string
+	PatternKey string
+}
+
+// Tailer follows the files of the sources and publishes the new lines to the file tail ingress subject
+// The offsets are persisted to the checkpoint file after the lines are accepted by the stream
+// Rotated files are read to the end before the new file is followed. A truncated file is read from the start
+// Every message has a Nats-Msg-Id of the file and the offset of its first line. So the stream deduplicates a retry
+// of the messages that are accepted already
+type Tailer struct {
+	subject    string
+	sources    []Source
+	checkpoint string
+	interval   time.Duration
+	host       string
+	publisher  ingress.Publisher
+	files      map[string]*tailedFile
+	logger     zerolog.Logger
+}
+
+// tailedFile an open file of a path. rotated are the files renamed or removed but not read to the end yet
+type tailedFile struct {
+	path   string
+	source Source
+	file   *os.File
+	info   os.FileInfo
+	offset int64
+	// pending the end of the lines of a failed publish. The retry publishes the same lines in the same messages
+	pending int64
+	// changed the last time a rotated file has grown
+	changed time.Time
+	rotated []*tailedFile
+}
+
+// checkpointEntry the file identity and the offset of the next unread line
+// Rotated the rotated files of the path that are not read to the end yet
+type checkpointEntry struct {
+	Id      uint64            `json:"id"`
+	Offset  int64             `json:"offset"`
+	Rotated []checkpointEntry `json:"rotated,omitempty"`
+}
+
+// ParseSources the sources of the form path=<glob>,service=<name>,pattern_key=<key>
+// A source without key is a glob only
+func ParseSources(specs []string) ([]Source, error) {
+	var sources []Source
+	for _, spec := range specs {
+		source := Source{}
+		for _, part := range strings.Split(spec, ",") {
+			key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+			if !found {
+				source.Glob = key
+				continue
+			}
+			switch key {
+			case "path":
+				source.Glob = value
+			case "service":
+				source.Service = value
+			case "pattern_key":
+				source.PatternKey = value
+			default:
+				return nil, fmt.Errorf("unknown key %s in file tail source %s", key, spec)
+			}
+		}
+		if len(source.Glob) == 0 {
+			return nil, fmt.Errorf("file tail source %s without path", spec)
+		}
+		if _, err := filepath.Match(source.Glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob of file tail source %s: %w", spec, err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func NewTailer(subject string, sources []Source, checkpoint string, interval time.Duration, publisher ingress.Publisher) *Tailer {
+	host, _ := os.Hostname()
+	return &Tailer{
+		subject:    subject,
+		sources:    sources,
+		checkpoint: checkpoint,
+		interval:   interval,
+		host:       host,
+		publisher:  publisher,
+		files:      make(map[string]*tailedFile),
+		logger:     config.Logger(),
+	}
+}
+
+// Start polls the files in the background. A failed poll is retried from the last checkpoint
+func (t *Tailer) Start() {
+	go func() {
+		t.logger.Info().Msgf("Start file tail of %d source(s) to %s", len(t.sources), t.subject)
+		checkpoints := t.readCheckpoint()
+		for {
+			err := t.poll(checkpoints)
+			if err != nil {
+				t.logger.Error().Err(err).Msgf("Can't tail files to %s", t.subject)
+			}
+			checkpoints = nil
+			time.Sleep(t.interval)
+		}
+	}()
+}
+
+// poll publishes the new lines of all files. The checkpoints are used for files that are not open yet
+func (t *Tailer) poll(checkpoints map[string]checkpointEntry) error {
+	t.restoreRotated(checkpoints)
+	t.discover(checkpoints)
+	maxPayload, err := t.publisher.MaxPayload()
+	if err != nil {
+		return err
+	}
+	var msgs []*nats.Msg
+	commits := make(map[*tailedFile]int64)
+	now := time.Now()
+	for _, path := range t.paths() {
+		tailed := t.files[path]
+		for _, rotated := range tailed.rotated {
+			records, offsets, end, err := t.read(rotated, rotated.idle(now))
+			if err != nil {
+				t.logger.Error().Err(err).Msgf("Can't read rotated file %s", rotated.path)
+			}
+			msgs = append(msgs, t.messages(rotated, records, offsets, maxPayload)...)
+			commits[rotated] = end
+		}
+		if tailed.file == nil {
+			continue
+		}
+		records, offsets, end, err := t.read(tailed, false)
+		if err != nil {
+			t.logger.Error().Err(err).Msgf("Can't read file %s", tailed.path)
+		}
+		msgs = append(msgs, t.messages(tailed, records, offsets, maxPayload)...)
+		commits[tailed] = end
+	}
+	if len(msgs) > 0 {
+		err = t.publisher.Publish(msgs...)
+		if err != nil {
+			// The next poll publishes the same lines again. The stream deduplicates the messages accepted already
+			for tailed, end := range commits {
+				tailed.pending = end
+			}
+			return err
+		}
+	}
+	for tailed, end := range commits {
+		tailed.offset = end
+		tailed.pending = 0
+	}
+	for _, tailed := range t.files {
+		var pending []*tailedFile
+		for _, rotated := range tailed.rotated {
+			if rotated.hasMore() {
+				pending = append(pending, rotated)
+				continue
+			}
+			_ = rotated.file.Close()
+		}
+		tailed.rotated = pending
+	}
+	return t.writeCheckpoint()
+}
+
+// discover opens the new files of the globs and detects rotation and truncation of the open files
+func (t *Tailer) discover(checkpoints map[string]checkpointEntry) {
+	matched := make(map[string]bool)
+	for _, source := range t.sources {
+		paths, _ := filepath.Glob(source.Glob)
+		for _, path := range paths {
+			if matched[path] {
+				continue
+			}
+			matched[path] = true
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			tailed, tracked := t.files[path]
+			if !tracked || tailed.file == nil {
+				next, err := openFile(path, source, 0)
+				if err != nil {
+					t.logger.Error().Err(err).Msgf("Can't open file %s", path)
+					continue
+				}
+				if entry, ok := checkpoints[path]; ok && entry.Id == fileId(next.info) && entry.Offset <= next.info.Size() {
+					next.offset = entry.Offset
+				}
+				if tracked {
+					next.rotated = tailed.rotated
+				}
+				t.files[path] = next
+				continue
+			}
+			if !os.SameFile(tailed.info, info) {
+				// Rotated. The old file is read to the end first
+				next, err := openFile(path, source, 0)
+				if err != nil {
+					t.logger.Error().Err(err).Msgf("Can't open file %s", path)
+					continue
+				}
+				tailed.changed = time.Now()
+				next.rotated = append(tailed.rotated, tailed)
+				tailed.rotated = nil
+				t.files[path] = next
+				continue
+			}
+			if info.Size() < tailed.offset {
+				t.logger.Info().Msgf("File %s is truncated. Read it from the start", path)
+				tailed.offset = 0
+				tailed.pending = 0
+			}
+			tailed.info = info
+		}
+	}
+	for path, tailed := range t.files {
+		if !matched[path] && tailed.file != nil {
+			// Removed or renamed. It is read to the end and forgotten then
+			tailed.changed = time.Now()
+			t.files[path] = &tailedFile{path: path, source: tailed.source, rotated: append(tailed.rotated, tailed)}
+			tailed.rotated = nil
+		}
+	}
+}
+
+// paths the open paths in a stable order
+func (t *Tailer) paths() []string {
+	paths := make([]string, 0, len(t.files))
+	for path, tailed := range t.files {
+		if tailed.file == nil && len(tailed.rotated) == 0 {
+			delete(t.files, path)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// read the complete lines after the offset as records and their offsets. An idle rotated file is read to the end,
+// including the last line without newline. The lines of a failed publish are read up to their end only
+// Returns the offset after the last line read
+func (t *Tailer) read(tailed *tailedFile, toEnd bool) ([][]byte, []int64, int64, error) {
+	info, err := tailed.file.Stat()
+	if err != nil {
+		return nil, nil, tailed.offset, err
+	}
+	size := info.Size()
+	if tailed.pending > tailed.offset && tailed.pending < size {
+		size = tailed.pending
+	}
+	remaining := size - tailed.offset
+	if remaining <= 0 {
+		return nil, nil, tailed.offset, nil
+	}
+	buffer := make([]byte, min(remaining, maxRead))
+	n, err := tailed.file.ReadAt(buffer, tailed.offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, tailed.offset, err
+	}
+	data := buffer[:n]
+	end := bytes.LastIndexByte(data, '\n') + 1
+	if n == maxRead && end == 0 {
+		// A line without newline that exceeds maxRead
+		end = n
+	}
+	if toEnd && n < maxRead {
+		end = n
+	}
+	var records [][]byte
+	var offsets []int64
+	offset := tailed.offset
+	now := time.Now()
+	for _, line := range bytes.SplitAfter(data[:end], []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		lineOffset := offset
+		offset += int64(len(line))
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			continue
+		}
+		record, err := json.Marshal(Record{
+			Timestamp:  now,
+			Host:       t.host,
+			Path:       tailed.path,
+			Offset:     lineOffset,
+			Service:    tailed.source.Service,
+			PatternKey: tailed.source.PatternKey,
+			Message:    ingress.RawData(line),
+		})
+		if err != nil {
+			return records, offsets, lineOffset, err
+		}
+		records = append(records, record)
+		offsets = append(offsets, lineOffset)
+	}
+	return records, offsets, offset, nil
+}
+
+// messages batches the records of the file into ndjson messages
+// The Nats-Msg-Id of a message is the path, the file id and the offset of its first line
+func (t *Tailer) messages(tailed *tailedFile, records [][]byte, offsets []int64, maxPayload int64) []*nats.Msg {
+	msgs, tooLarge := ingress.NdjsonMessages(t.subject, records, maxPayload)
+	skipped := make(map[int]bool, len(tooLarge))
+	for _, i := range tooLarge {
+		t.logger.Warn().Msgf("Skip line of %d bytes in %s. It exceeds the max payload of %d bytes", len(records[i]), tailed.path, maxPayload)
+		skipped[i] = true
+	}
+	next := 0
+	for _, msg := range msgs {
+		for skipped[next] {
+			next++
+		}
+		msg.Header.Set(nats.MsgIdHdr, fmt.Sprintf("%s@%d:%d", tailed.path, fileId(tailed.info), offsets[next]))
+		// A record is one line. Json escapes the newlines of the message
+		for lines := bytes.Count(msg.Data, []byte("\n")); lines > 0; lines-- {
+			for skipped[next] {
+				next++
+			}
+			next++
+		}
+	}
+	return msgs
+}
+
+// idle true if the rotated file did not grow since rotatedIdle
+func (f *tailedFile) idle(now time.Time) bool {
+	info, err := f.file.Stat()
+	if err != nil {
+		return true
+	}
+	if info.Size() != f.info.Size() {
+		f.info = info
+		f.changed = now
+		return false
+	}
+	return now.Sub(f.changed) >= rotatedIdle
+}
+
+// hasMore true if the file is not read to the end
+func (f *tailedFile) hasMore() bool {
+	info, err := f.file.Stat()
+	return err == nil && info.Size() > f.offset
+}
+
+func openFile(path string, source Source, offset int64) (*tailedFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &tailedFile{
+		path:   path,
+		source: source,
+		file:   file,
+		info:   info,
+		offset: offset,
+	}, nil
+}
+
+// restoreRotated reopens the rotated files of the checkpoints. They are found by their file id in the directory of
+// their path. For example app.log.1 of app.log. A rotated file that is removed meanwhile can't be read to the end
+// A file of the checkpoint that is not the file of its path anymore is rotated during the downtime. It is read to the
+// end like the other rotated files
+func (t *Tailer) restoreRotated(checkpoints map[string]checkpointEntry) {
+	for path, entry := range checkpoints {
+		rotatedEntries := entry.Rotated
+		if entry.Id != 0 && !isFile(path, entry.Id) {
+			rotatedEntries = append(append([]checkpointEntry(nil), entry.Rotated...), checkpointEntry{Id: entry.Id, Offset: entry.Offset})
+		}
+		if len(rotatedEntries) == 0 {
+			continue
+		}
+		source, ok := t.sourceOf(path)
+		if !ok {
+			continue
+		}
+		candidates, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*"))
+		var rotated []*tailedFile
+		for _, rotatedEntry := range rotatedEntries {
+			found := false
+			for _, candidate := range candidates {
+				info, err := os.Stat(candidate)
+				if candidate == path || err != nil || !info.Mode().IsRegular() || fileId(info) != rotatedEntry.Id || rotatedEntry.Id == 0 {
+					continue
+				}
+				file, err := openFile(candidate, source, rotatedEntry.Offset)
+				if err != nil {
+					t.logger.Error().Err(err).Msgf("Can't open rotated file %s", candidate)
+					break
+				}
+				// The rotated file keeps the path of its lines
+				file.path = path
+				file.changed = time.Now()
+				rotated = append(rotated, file)
+				found = true
+				break
+			}
+			if !found {
+				t.logger.Warn().Msgf("Can't find the rotated file of %s. Its lines after offset %d are lost", path, rotatedEntry.Offset)
+			}
+		}
+		if len(rotated) > 0 {
+			t.files[path] = &tailedFile{path: path, source: source, rotated: rotated}
+		}
+	}
+}
+
+// isFile true if the file of the path has the file id
+func isFile(path string, id uint64) bool {
+	info, err := os.Stat(path)
+	return err == nil && fileId(info) == id
+}
+
+// sourceOf the first source whose glob matches the path
+func (t *Tailer) sourceOf(path string) (Source, bool) {
+	for _, source := range t.sources {
+		if matched, _ := filepath.Match(source.Glob, path); matched {
+			return source, true
+		}
+	}
+	return Source{}, false
+}
+
+func (t *Tailer) readCheckpoint() map[string]checkpointEntry {
+	checkpoints := make(map[string]checkpointEntry)
+	if len(t.checkpoint) == 0 {
+		return checkpoints
+	}
+	data, err := os.ReadFile(t.checkpoint)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			t.logger.Error().Err(err).Msgf("Can't read file tail checkpoint %s. Start from the beginning", t.checkpoint)
+		}
+		return checkpoints
+	}
+	err = json.Unmarshal(data, &checkpoints)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("Can't read file tail checkpoint %s. Start from the beginning", t.checkpoint)
+	}
+	return checkpoints
+}
+
+// writeCheckpoint replaces the checkpoint file atomically
+func (t *Tailer) writeCheckpoint() error {
+	if len(t.checkpoint) == 0 {
+		return nil
+	}
+	checkpoints := make(map[string]checkpointEntry, len(t.files))
+	for path, tailed := range t.files {
+		entry := checkpointEntry{}
+		if tailed.file != nil {
+			entry.Id = fileId(tailed.info)
+			entry.Offset = tailed.offset
+		}
+		for _, rotated := range tailed.rotated {
+			entry.Rotated = append(entry.Rotated, checkpointEntry{Id: fileId(rotated.info), Offset: rotated.offset})
+		}
+		if tailed.file != nil || len(entry.Rotated) > 0 {
+			checkpoints[path] = entry
+		}
+	}
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	temp := t.checkpoint + ".tmp"
+	err = os.WriteFile(temp, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("can't write file tail checkpoint %s: %w", t.checkpoint, err)
+	}
+	return os.Rename(temp, t.checkpoint)
+}
@@ -0,0 +1,221 @@
+package filetail
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// publishedRecords the published records since the last call
+func publishedRecords(t *testing.T, p *testingress.Publisher) []Record {
+	var records []Record
+	for _, msg := range p.Msgs {
+		for _, line := range bytes.Split(bytes.TrimSpace(msg.Data), []byte("\n")) {
+			record := Record{}
+			if err := json.Unmarshal(line, &record); err != nil {
+				t.Fatalf("Expected a record but got %s", line)
+			}
+			records = append(records, record)
+		}
+	}
+	p.Msgs = nil
+	return records
+}
+
+func messages(records []Record) []string {
+	var result []string
+	for _, record := range records {
+		result = append(result, record.Message)
+	}
+	return result
+}
+
+func appendFile(t *testing.T, path string, data string) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectMessages(t *testing.T, step string, publisher *testingress.Publisher, expected ...string) {
+	actual := messages(publishedRecords(t, publisher))
+	if len(actual) != len(expected) {
+		t.Fatalf("%s: expected %v but got %v", step, expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("%s: expected %v but got %v", step, expected, actual)
+		}
+	}
+}
+
+func TestTailer(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	checkpoint := filepath.Join(dir, "checkpoint.json")
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	sources := []Source{{Glob: filepath.Join(dir, "*.log"), Service: "billing", PatternKey: "logfmt"}}
+	tailer := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+
+	appendFile(t, path, "first\nsecond\nthi")
+	if err := tailer.poll(nil); err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	records := publishedRecords(t, publisher)
+	if len(records) != 2 || records[0].Service != "billing" || records[0].PatternKey != "logfmt" || records[1].Offset != 6 {
+		t.Fatalf("Expected the 2 complete lines with the source metadata but got %v", records)
+	}
+
+	// The partial line is published when it is complete
+	appendFile(t, path, "rd\n")
+	_ = tailer.poll(nil)
+	expectMessages(t, "append", publisher, "third")
+
+	// A failed publish is published again by the next poll with the same lines and message ids
+	appendFile(t, path, "fourth\n")
+	publisher.Err = errors.New("nats down")
+	if err := tailer.poll(nil); err == nil {
+		t.Fatal("Expected the publish error")
+	}
+	publisher.Err = nil
+	appendFile(t, path, "late\n")
+	_ = tailer.poll(nil)
+	if len(publisher.Failed) != 1 || len(publisher.Msgs) != 1 || publisher.Msgs[0].Header.Get(nats.MsgIdHdr) != publisher.Failed[0].Header.Get(nats.MsgIdHdr) {
+		t.Errorf("Expected the message id of the failed publish but got %v", publisher.Msgs)
+	}
+	expectMessages(t, "retry", publisher, "fourth")
+	_ = tailer.poll(nil)
+	expectMessages(t, "after retry", publisher, "late")
+
+	// The rotated file is read to the end before the new file
+	// Its last line without newline is read when the file is idle
+	appendFile(t, path, "fifth")
+	if err := os.Rename(path, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "sixth\n")
+	_ = tailer.poll(nil)
+	expectMessages(t, "rotate", publisher, "sixth")
+	tailer.files[path].rotated[0].changed = time.Now().Add(-rotatedIdle)
+	_ = tailer.poll(nil)
+	expectMessages(t, "rotate idle", publisher, "fifth")
+	if len(tailer.files[path].rotated) != 0 {
+		t.Errorf("Expected the rotated file is closed but got %d", len(tailer.files[path].rotated))
+	}
+
+	// A truncated file is read from the start
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "7\n")
+	_ = tailer.poll(nil)
+	expectMessages(t, "truncate", publisher, "7")
+
+	// A new tailer continues at the checkpoint
+	appendFile(t, path, "eighth\n")
+	restarted := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+	_ = restarted.poll(restarted.readCheckpoint())
+	expectMessages(t, "restart", publisher, "eighth")
+
+	// A removed file is forgotten
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	_ = restarted.poll(nil)
+	_ = restarted.poll(nil)
+	if len(restarted.files) != 0 {
+		t.Errorf("Expected no tailed file but got %d", len(restarted.files))
+	}
+}
+
+func TestTailerRotatedCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	checkpoint := filepath.Join(dir, "checkpoint.json")
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	sources := []Source{{Glob: filepath.Join(dir, "*.log"), Service: "billing"}}
+	tailer := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+
+	appendFile(t, path, "first\nsecond")
+	_ = tailer.poll(nil)
+	expectMessages(t, "start", publisher, "first")
+	if err := os.Rename(path, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "third\n")
+	_ = tailer.poll(nil)
+	expectMessages(t, "rotate", publisher, "third")
+
+	// A new tailer reads the rotated file of the checkpoint to the end
+	restarted := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+	_ = restarted.poll(restarted.readCheckpoint())
+	expectMessages(t, "restart", publisher)
+	restarted.files[path].rotated[0].changed = time.Now().Add(-rotatedIdle)
+	_ = restarted.poll(nil)
+	expectMessages(t, "restart idle", publisher, "second")
+}
+
+func TestTailerRotatedDuringDowntime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	checkpoint := filepath.Join(dir, "checkpoint.json")
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	sources := []Source{{Glob: filepath.Join(dir, "*.log"), Service: "billing"}}
+	tailer := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+
+	appendFile(t, path, "first\n")
+	_ = tailer.poll(nil)
+	expectMessages(t, "start", publisher, "first")
+
+	// Stopped. The file grows and is rotated before the restart
+	appendFile(t, path, "second\nthird")
+	if err := os.Rename(path, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "fourth\n")
+
+	// A new tailer reads the file of the checkpoint to the end before the new file
+	restarted := NewTailer("ingress.logs.filetail", sources, checkpoint, time.Second, publisher)
+	_ = restarted.poll(restarted.readCheckpoint())
+	expectMessages(t, "restart", publisher, "second", "fourth")
+	restarted.files[path].rotated[0].changed = time.Now().Add(-rotatedIdle)
+	_ = restarted.poll(nil)
+	expectMessages(t, "restart idle", publisher, "third")
+}
+
+func TestParseSources(t *testing.T) {
+	tests := []struct {
+		pos      int
+		spec     string
+		expected Source
+		err      bool
+	}{
+		{pos: 1, spec: "/var/log/app/*.log", expected: Source{Glob: "/var/log/app/*.log"}},
+		{pos: 2, spec: "path=/var/log/app/*.log,service=billing,pattern_key=logfmt", expected: Source{Glob: "/var/log/app/*.log", Service: "billing", PatternKey: "logfmt"}},
+		{pos: 3, spec: "service=billing", err: true},
+		{pos: 4, spec: "path=/var/log/*.log,unknown=x", err: true},
+		{pos: 5, spec: "path=/var/log/[.log", err: true},
+	}
+	for _, test := range tests {
+		sources, err := ParseSources([]string{test.spec})
+		if test.err {
+			if err == nil {
+				t.Errorf("Pos %d: expected an error", test.pos)
+			}
+			continue
+		}
+		if err != nil || len(sources) != 1 || sources[0] != test.expected {
+			t.Errorf("Pos %d: expected %v but got %v %v", test.pos, test.expected, sources, err)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// chunkHeaderSize magic bytes, message id, sequence number and sequence count
+	chunkHeaderSize = 12
+	// maxChunks of a message. See https://go2docs.graylog.org/current/getting_in_log_data/gelf.html#GELFviaUDP
+	maxChunks = 128
+	// chunkTimeout the chunks of a message must arrive within
+	chunkTimeout = 5 * time.Second
+	// maxMessageSize of a decompressed message
+	maxMessageSize = 32 << 20
+	// fieldPatternKey the additional field that selects the extractor
+	fieldPatternKey = "pattern_key"
+)
+
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// GelfToEcsConverter converts GELF 1.1 messages relayed to nats. One nats message is one udp datagram or tcp frame
+// Chunked messages are collected until the last chunk is received. The chunks before are skipped
+// The payload may be gzip or zlib compressed
+type GelfToEcsConverter struct {
+	mtx    sync.Mutex
+	chunks map[string]*chunkedMessage
+}
+
+type chunkedMessage struct {
+	created  time.Time
+	chunks   [][]byte
+	received int
+}
+
+// Message the GELF payload. The additional fields start with an underscore
+// See https://go2docs.graylog.org/current/getting_in_log_data/gelf.html#GELFPayloadSpecification
+type Message struct {
+	Version      string
+	Host         string
+	ShortMessage string
+	FullMessage  string
+	Timestamp    float64
+	Level        *int
+	Fields       map[string]string
+}
+
+func (r *GelfToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	data := msg.Data
+	if bytes.HasPrefix(data, chunkMagic) {
+		complete, err := r.collect(data)
+		if err != nil {
+			return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+		}
+		if complete == nil {
+			// Waiting for the other chunks
+			return ingress.IngressMsgContext{Skip: true, NatsMsg: msg}
+		}
+		data = complete
+	}
+	data, err := decompress(data)
+	if err != nil {
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	message, err := Parse(data)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	patternKey := model.StringToLogPatternKey(message.Fields[fieldPatternKey])
+	// The full message contains the stack trace of a java gelf appender for example. The short message its first line
+	rawMessage := message.ShortMessage
+	if len(message.FullMessage) > 0 {
+		rawMessage = message.FullMessage
+	}
+	return ingress.IngressMsgContext{
+		NatsMsg: msg,
+		MetaLog: &model.MetaLog{
+			PatternKey:   patternKey,
+			RawMessage:   rawMessage,
+			EcsLogEntry:  message.toEcs(msg, data),
+			IngressLevel: message.Level != nil,
+		},
+	}
+}
+
+// collect the chunk. Returns the payload of all chunks if this is the last one, nil otherwise
+func (r *GelfToEcsConverter) collect(chunk []byte) ([]byte, error) {
+	if len(chunk) < chunkHeaderSize {
+		return nil, errors.New("gelf chunk without header")
+	}
+	id := string(chunk[2:10])
+	sequence := int(chunk[10])
+	count := int(chunk[11])
+	if count == 0 || count > maxChunks || sequence >= count {
+		return nil, fmt.Errorf("invalid gelf chunk %d of %d", sequence, count)
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.chunks == nil {
+		r.chunks = make(map[string]*chunkedMessage)
+	}
+	now := time.Now()
+	for key, pending := range r.chunks {
+		if now.Sub(pending.created) > chunkTimeout {
+			delete(r.chunks, key)
+		}
+	}
+	pending, ok := r.chunks[id]
+	if !ok {
+		pending = &chunkedMessage{created: now, chunks: make([][]byte, count)}
+		r.chunks[id] = pending
+	}
+	if len(pending.chunks) != count {
+		delete(r.chunks, id)
+		return nil, fmt.Errorf("gelf chunk %d with count %d but expected %d", sequence, count, len(pending.chunks))
+	}
+	if pending.chunks[sequence] == nil {
+		pending.received++
+	}
+	pending.chunks[sequence] = bytes.Clone(chunk[chunkHeaderSize:])
+	if pending.received < count {
+		return nil, nil
+	}
+	delete(r.chunks, id)
+	return bytes.Join(pending.chunks, nil), nil
+}
+
+// decompress gzip and zlib payloads by their magic bytes. A plain json payload is returned as is
+func decompress(data []byte) ([]byte, error) {
+	var reader io.ReadCloser
+	var err error
+	switch {
+	case len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b:
+		reader, err = gzip.NewReader(bytes.NewReader(data))
+	case len(data) > 1 && data[0] == 0x78:
+		reader, err = zlib.NewReader(bytes.NewReader(data))
+	default:
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress gelf payload: %w", err)
+	}
+	defer reader.Close()
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxMessageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress gelf payload: %w", err)
+	}
+	if len(decompressed) > maxMessageSize {
+		return nil, fmt.Errorf("decompressed gelf payload exceeds %d bytes", maxMessageSize)
+	}
+	return decompressed, nil
+}
+
+// Parse a GELF payload. The additional fields are kept without the underscore prefix
+// A number or boolean field is kept as its json representation
+func Parse(data []byte) (*Message, error) {
+	var payload map[string]json.RawMessage
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode gelf payload: %w", err)
+	}
+	message := &Message{Fields: make(map[string]string)}
+	for key, value := range payload {
+		switch key {
+		case "version":
+			err = json.Unmarshal(value, &message.Version)
+		case "host":
+			err = json.Unmarshal(value, &message.Host)
+		case "short_message":
+			err = json.Unmarshal(value, &message.ShortMessage)
+		case "full_message":
+			err = json.Unmarshal(value, &message.FullMessage)
+		case "timestamp":
+			err = json.Unmarshal(value, &message.Timestamp)
+		case "level":
+			err = json.Unmarshal(value, &message.Level)
+		default:
+			// _id is reserved. Fields without underscore are not allowed but kept
+			name := strings.TrimPrefix(key, "_")
+			if len(name) == 0 || name == "id" {
+				continue
+			}
+			var text string
+			if json.Unmarshal(value, &text) != nil {
+				text = string(value)
+			}
+			message.Fields[name] = text
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid gelf field %s: %w", key, err)
+		}
+	}
+	if len(message.ShortMessage) == 0 {
+		return nil, errors.New("gelf payload without short_message")
+	}
+	return message, nil
+}
+
+func (m *Message) toEcs(msg *nats.Msg, data []byte) *model.EcsLogEntry {
+	level := m.toLogLevel()
+	labels := make(map[string]string, len(m.Fields))
+	for name, value := range m.Fields {
+		if name != fieldPatternKey {
+			labels[name] = value
+		}
+	}
+	ecs := &model.EcsLogEntry{
+		Labels:    labels,
+		Timestamp: m.ts(msg),
+		Log: &model.Log{
+			// The pattern parsing keeps a level defined by the ingress
+			Level:      level,
+			LevelEmoji: model.LogLevelToEmoji(level),
+			PatternKey: model.PatternKeyName(m.Fields[fieldPatternKey]),
+			Ingress:    msg.Subject,
+		},
+		Service: &model.Service{},
+		ProcessError: &model.ProcessError{
+			RawData: ingress.RawData(data),
+			Subject: msg.Subject,
+		},
+	}
+	if len(m.FullMessage) > 0 && m.FullMessage != m.ShortMessage {
+		ecs.Log.Original = m.FullMessage
+	}
+	if len(m.Host) > 0 {
+		ecs.SetHostName(m.Host)
+	}
+	// The fields of the docker gelf log driver
+	if name := m.Fields["container_name"]; len(name) > 0 {
+		ecs.Container = &model.Container{
+			Id:   m.Fields["container_id"],
+			Name: strings.TrimPrefix(name, "/"),
+		}
+		if image := m.Fields["image_name"]; len(image) > 0 {
+			ecs.Container.Image = &model.Container_Image{Name: image}
+		}
+		ecs.SetSetServiceName(ecs.Container.Name)
+	}
+	if tag := m.Fields["tag"]; len(tag) > 0 {
+		ecs.SetSetServiceName(tag)
+	}
+	return ecs
+}
+
+// toLogLevel the syslog severity of the level field. Not set if missing
+func (m *Message) toLogLevel() model.LogLevel {
+	if m.Level == nil {
+		return model.LogLevel_not_set
+	}
+	switch level := *m.Level; {
+	case level <= 2:
+		return model.LogLevel_fatal
+	case level == 3:
+		return model.LogLevel_error
+	case level == 4:
+		return model.LogLevel_warn
+	case level <= 6:
+		return model.LogLevel_info
+	default:
+		return model.LogLevel_debug
+	}
+}
+
+// ts the timestamp in seconds with optional decimal places
+func (m *Message) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	if m.Timestamp <= 0 {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	seconds, fraction := math.Modf(m.Timestamp)
+	return timestamppb.New(time.Unix(int64(seconds), int64(math.Round(fraction*1e6))*int64(time.Microsecond)))
+}
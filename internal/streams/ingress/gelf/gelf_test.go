@@ -0,0 +1,139 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+const testGelf = `{"version":"1.1","host":"docker-01","short_message":"level=warn msg=\"invoice delayed\"","full_message":"level=warn msg=\"invoice delayed\"\nat billing.go:42","timestamp":1385053862.3072,"level":4,"_container_name":"/billing","_container_id":"9f2a8c1e","_image_name":"billing:1.2","_tag":"billing-api","_pattern_key":"logfmt","_status":200,"_id":"skipped"}`
+
+func gzipped(data []byte) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, _ = writer.Write(data)
+	_ = writer.Close()
+	return buffer.Bytes()
+}
+
+func zlibbed(data []byte) []byte {
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	_, _ = writer.Write(data)
+	_ = writer.Close()
+	return buffer.Bytes()
+}
+
+// chunked splits the data into count gelf chunks
+func chunked(id string, data []byte, count int) [][]byte {
+	size := (len(data) + count - 1) / count
+	var chunks [][]byte
+	for i := 0; i < count; i++ {
+		end := min((i+1)*size, len(data))
+		chunk := append([]byte{0x1e, 0x0f}, []byte(id)...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks = append(chunks, append(chunk, data[i*size:end]...))
+	}
+	return chunks
+}
+
+func TestGelfToEcs(t *testing.T) {
+	tests := []struct {
+		pos  int
+		data []byte
+	}{
+		{pos: 1, data: []byte(testGelf)},
+		{pos: 2, data: gzipped([]byte(testGelf))},
+		{pos: 3, data: zlibbed([]byte(testGelf))},
+	}
+	for _, test := range tests {
+		converter := GelfToEcsConverter{}
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.gelf", Data: test.data})
+		metaLog := result.MetaLog
+		ecs := metaLog.EcsLogEntry
+		if ecs.HasProcessError() {
+			t.Fatalf("Pos %d: expected no process error but got %s", test.pos, ecs.ProcessError.Reason)
+		}
+		if metaLog.PatternKey != model.MetaLog_LogFmt || metaLog.RawMessage != "level=warn msg=\"invoice delayed\"\nat billing.go:42" {
+			t.Errorf("Pos %d: expected the logfmt full message but got %s %s", test.pos, metaLog.PatternKey, metaLog.RawMessage)
+		}
+		if ecs.Log.Original != "level=warn msg=\"invoice delayed\"\nat billing.go:42" {
+			t.Errorf("Pos %d: expected the full message as original but got %s", test.pos, ecs.Log.Original)
+		}
+		if ecs.Log.Level != model.LogLevel_warn {
+			t.Errorf("Pos %d: expected level warn but got %s", test.pos, ecs.Log.Level)
+		}
+		if ecs.Host.Name != "docker-01" || ecs.Service.Name != "billing-api" {
+			t.Errorf("Pos %d: expected host docker-01 and service billing-api but got %v %v", test.pos, ecs.Host, ecs.Service)
+		}
+		if ecs.Container.Name != "billing" || ecs.Container.Id != "9f2a8c1e" || ecs.Container.Image.Name != "billing:1.2" {
+			t.Errorf("Pos %d: expected the container billing but got %v", test.pos, ecs.Container)
+		}
+		if ecs.Labels["status"] != "200" || ecs.Labels["container_name"] != "/billing" {
+			t.Errorf("Pos %d: expected the additional fields as labels but got %v", test.pos, ecs.Labels)
+		}
+		if _, ok := ecs.Labels["id"]; ok {
+			t.Errorf("Pos %d: expected the reserved field _id is dropped", test.pos)
+		}
+		if _, ok := ecs.Labels[fieldPatternKey]; ok {
+			t.Errorf("Pos %d: expected no %s label", test.pos, fieldPatternKey)
+		}
+		if ecs.Timestamp.AsTime().UnixMicro() != 1385053862307200 {
+			t.Errorf("Pos %d: expected timestamp 1385053862307200 but got %d", test.pos, ecs.Timestamp.AsTime().UnixMicro())
+		}
+	}
+}
+
+func TestGelfShortMessage(t *testing.T) {
+	converter := GelfToEcsConverter{}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.gelf", Data: []byte(`{"version":"1.1","host":"docker-01","short_message":"started"}`)})
+	if result.MetaLog.RawMessage != "started" || len(result.MetaLog.EcsLogEntry.Log.Original) > 0 {
+		t.Errorf("Expected the short message without original but got %s %s", result.MetaLog.RawMessage, result.MetaLog.EcsLogEntry.Log.Original)
+	}
+}
+
+func TestGelfChunked(t *testing.T) {
+	converter := GelfToEcsConverter{}
+	chunks := chunked("abcdefgh", gzipped([]byte(testGelf)), 3)
+	// Chunks may arrive out of order
+	for i, chunk := range [][]byte{chunks[2], chunks[0], chunks[0]} {
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.gelf", Data: chunk})
+		if !result.Skip {
+			t.Fatalf("Expected chunk %d is skipped", i)
+		}
+	}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.gelf", Data: chunks[1]})
+	if result.Skip || result.MetaLog.EcsLogEntry.HasProcessError() {
+		t.Fatalf("Expected the assembled message but got %v", result)
+	}
+	if result.MetaLog.EcsLogEntry.Host.Name != "docker-01" {
+		t.Errorf("Expected host docker-01 but got %v", result.MetaLog.EcsLogEntry.Host)
+	}
+	if len(converter.chunks) != 0 {
+		t.Errorf("Expected no pending chunks but got %d", len(converter.chunks))
+	}
+}
+
+func TestGelfInvalid(t *testing.T) {
+	tests := []struct {
+		pos  int
+		data []byte
+	}{
+		{pos: 1, data: []byte("no json")},
+		{pos: 2, data: []byte(`{"version":"1.1","host":"docker-01"}`)},
+		{pos: 3, data: []byte(`{"version":"1.1","short_message":"x","level":"high"}`)},
+		{pos: 4, data: []byte{0x1e, 0x0f, 1, 2}},
+		{pos: 5, data: append([]byte{0x1e, 0x0f}, []byte("abcdefgh\x03\x02")...)},
+		{pos: 6, data: []byte{0x1f, 0x8b, 0, 0}},
+	}
+	converter := GelfToEcsConverter{}
+	for _, test := range tests {
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.gelf", Data: test.data})
+		if result.Skip || !result.MetaLog.EcsLogEntry.HasProcessError() {
+			t.Errorf("Pos %d: expected a process error", test.pos)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package httpingress
+
+import (
+	"errors"
+	"fmt"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxBodySize of a (compressed) request body
+const maxBodySize = 32 << 20
+
+// Start serves the handler on its own port in the background
+// The ingress endpoints are not mixed with the health check endpoint
+// The port is bound before the return. A bind error (e.g. port in use) is returned
+func Start(name string, port int, handler http.Handler) error {
+	logger := config.Logger()
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("can't listen on port %d for %s ingress endpoint: %w", port, name, err)
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info().Msgf("Start %s ingress endpoint on port %d", name, port)
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error().Err(err).Msgf("%s ingress endpoint on port %d stopped", name, port)
+			os.Exit(1)
+		}
+	}()
+	return nil
+}
+
+// ReadBody reads the request body and decompresses it by the Content-Encoding header
+func ReadBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	encoding := r.Header.Get(ingress.HeaderContentEncoding)
+	if !ingress.IsCompressed(encoding) {
+		return body, nil
+	}
+	return ingress.DecompressData(encoding, body)
+}
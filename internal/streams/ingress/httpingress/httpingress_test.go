@@ -0,0 +1,20 @@
+package httpingress
+
+import (
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestStartPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+	err = Start("test", port, http.NewServeMux())
+	if err == nil {
+		t.Errorf("Expected a bind error for port %d but got nil", port)
+	}
+}
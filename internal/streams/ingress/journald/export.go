@@ -0,0 +1,121 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"strconv"
+	"time"
+)
+
+// JournalExportToEcsConverter converts the journal export format of journalctl -o export or systemd-journal-upload
+// One payload contains many entries. See https://systemd.io/JOURNAL_EXPORT_FORMATS/
+// The fields are named like the vector journald source does. So the conversion of JournaldDToEcsConverter is reused
+type JournalExportToEcsConverter struct {
+	JournaldDToEcsConverter
+}
+
+func (r *JournalExportToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	// Only called if the converter is not used as ingress.MultiMetaLogConverter
+	return r.ConvertToMetaLogs(msg)[0]
+}
+
+func (r *JournalExportToEcsConverter) ConvertToMetaLogs(msg *nats.Msg) []ingress.IngressMsgContext {
+	entries, err := parseExport(msg.Data)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return []ingress.IngressMsgContext{ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)}
+	}
+	result := make([]ingress.IngressMsgContext, 0, len(entries))
+	for _, fields := range entries {
+		data, err := json.Marshal(toVectorFields(fields))
+		if err != nil {
+			result = append(result, ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err))
+			continue
+		}
+		// Every entry gets its own message. The ecs conversion of the journald converter replaces the payload
+		result = append(result, r.JournaldDToEcsConverter.ConvertToMetaLog(&nats.Msg{
+			Subject: msg.Subject,
+			Reply:   msg.Reply,
+			Header:  msg.Header,
+			Data:    data,
+			Sub:     msg.Sub,
+		}))
+	}
+	if len(result) == 0 {
+		// An empty payload. Nothing to do
+		return []ingress.IngressMsgContext{{Skip: true, NatsMsg: msg}}
+	}
+	return result
+}
+
+// parseExport the entries of the export format. An entry is a list of fields terminated by an empty line
+// A field is either NAME=value\n or, if the value is binary, NAME\n<little endian uint64 size><value>\n
+func parseExport(data []byte) ([]map[string][]byte, error) {
+	var entries []map[string][]byte
+	entry := make(map[string][]byte)
+	for len(data) > 0 {
+		var line []byte
+		if end := bytes.IndexByte(data, '\n'); end >= 0 {
+			line, data = data[:end], data[end+1:]
+		} else {
+			line, data = data, nil
+		}
+		if len(line) == 0 {
+			if len(entry) > 0 {
+				entries = append(entries, entry)
+				entry = make(map[string][]byte)
+			}
+			continue
+		}
+		// A field name never contains =
+		if separator := bytes.IndexByte(line, '='); separator >= 0 {
+			entry[string(line[:separator])] = line[separator+1:]
+			continue
+		}
+		if len(data) < 8 {
+			return nil, fmt.Errorf("binary field %s without size", line)
+		}
+		size := binary.LittleEndian.Uint64(data[:8])
+		data = data[8:]
+		if size > uint64(len(data)) {
+			return nil, fmt.Errorf("binary field %s of size %d exceeds the payload", line, size)
+		}
+		entry[string(line)], data = data[:size], data[size:]
+		if len(data) > 0 {
+			if data[0] != '\n' {
+				return nil, fmt.Errorf("binary field %s is not terminated by a newline", line)
+			}
+			data = data[1:]
+		}
+	}
+	if len(entry) > 0 {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// toVectorFields the fields as the vector journald source ships them
+// MESSAGE is message, _HOSTNAME is host and __REALTIME_TIMESTAMP is in addition the timestamp
+func toVectorFields(fields map[string][]byte) map[string]string {
+	result := make(map[string]string, len(fields)+2)
+	for name, value := range fields {
+		switch name {
+		case "MESSAGE":
+			result["message"] = ingress.RawData(value)
+		case "_HOSTNAME":
+			result["host"] = ingress.RawData(value)
+		default:
+			result[name] = ingress.RawData(value)
+		}
+	}
+	if realtime, err := strconv.ParseInt(result["__REALTIME_TIMESTAMP"], 10, 64); err == nil {
+		result["timestamp"] = time.UnixMicro(realtime).UTC().Format(time.RFC3339Nano)
+	}
+	result["source_type"] = "journald"
+	return result
+}
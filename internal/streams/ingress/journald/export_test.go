@@ -0,0 +1,101 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+func binaryField(buffer *bytes.Buffer, name string, value string) {
+	buffer.WriteString(name + "\n")
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, uint64(len(value)))
+	buffer.Write(size)
+	buffer.WriteString(value + "\n")
+}
+
+func testExportPayload() []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("__CURSOR=s=739ad463348b4ceca5a9e69c95a3c93f;i=4ece7\n")
+	buffer.WriteString("__REALTIME_TIMESTAMP=1678474432411076\n")
+	buffer.WriteString("__MONOTONIC_TIMESTAMP=1525819321\n")
+	buffer.WriteString("_BOOT_ID=c974f369cb4a4fc0b8a1f19886f272e4\n")
+	buffer.WriteString("PRIORITY=3\n")
+	buffer.WriteString("SYSLOG_FACILITY=3\n")
+	buffer.WriteString("_SYSTEMD_UNIT=docker.service\n")
+	buffer.WriteString("_HOSTNAME=worker-01\n")
+	buffer.WriteString("_MACHINE_ID=ceacb99587e34bcc840bc7a7cc0d4453\n")
+	binaryField(&buffer, "MESSAGE", "first line\nsecond line")
+	buffer.WriteString("\n")
+	buffer.WriteString("__REALTIME_TIMESTAMP=1678474433000000\n")
+	buffer.WriteString("PRIORITY=6\n")
+	buffer.WriteString("COM_GITHUB_LOGUNIFIER_APPLICATION_NAME=billing\n")
+	buffer.WriteString("COM_GITHUB_LOGUNIFIER_APPLICATION_PATTERN_KEY=logfmt\n")
+	buffer.WriteString("_HOSTNAME=worker-02\n")
+	buffer.WriteString("MESSAGE=level=warn msg=\"invoice delayed\"\n")
+	buffer.WriteString("\n")
+	return buffer.Bytes()
+}
+
+func TestJournalExport(t *testing.T) {
+	msg := &nats.Msg{Subject: "ingress.logs.journalexport", Data: testExportPayload()}
+	converter := JournalExportToEcsConverter{}
+	result := converter.ConvertToMetaLogs(msg)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entries but got %d", len(result))
+	}
+	first := result[0].MetaLog
+	if first.RawMessage != "first line\nsecond line" {
+		t.Errorf("Expected the binary message but got %s", first.RawMessage)
+	}
+	if first.EcsLogEntry.Host.Name != "worker-01" || first.EcsLogEntry.Host.Id != "ceacb99587e34bcc840bc7a7cc0d4453" {
+		t.Errorf("Expected host worker-01 but got %v", first.EcsLogEntry.Host)
+	}
+	if first.EcsLogEntry.Service.Name != "docker.service" {
+		t.Errorf("Expected service name docker.service but got %s", first.EcsLogEntry.Service.Name)
+	}
+	if first.EcsLogEntry.Log.Level != model.LogLevel_error {
+		t.Errorf("Expected level error but got %s", first.EcsLogEntry.Log.Level)
+	}
+	if first.EcsLogEntry.Timestamp.AsTime().UnixMicro() != 1678474432411076 {
+		t.Errorf("Expected timestamp 1678474432411076 but got %d", first.EcsLogEntry.Timestamp.AsTime().UnixMicro())
+	}
+	if first.EcsLogEntry.HasProcessError() {
+		t.Errorf("Expected no process error but got %s", first.EcsLogEntry.ProcessError.Reason)
+	}
+	second := result[1].MetaLog
+	if second.PatternKey != model.MetaLog_LogFmt {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_LogFmt, second.PatternKey)
+	}
+	if second.EcsLogEntry.Service.Name != "billing" {
+		t.Errorf("Expected service name billing but got %s", second.EcsLogEntry.Service.Name)
+	}
+	parsed := patternfactory.Parse(second)
+	if parsed.Message != "invoice delayed" || parsed.Log.Level != model.LogLevel_warn {
+		t.Errorf("Expected the logfmt message invoice delayed with level warn but got %s %s", parsed.Message, parsed.Log.Level)
+	}
+}
+
+func TestJournalExportInvalid(t *testing.T) {
+	tests := []struct {
+		pos  int
+		data []byte
+	}{
+		{pos: 1, data: []byte("MESSAGE\n\x05\x00")},
+		{pos: 2, data: []byte("MESSAGE\n\xff\x00\x00\x00\x00\x00\x00\x00short\n")},
+		{pos: 3, data: []byte("MESSAGE\n\x02\x00\x00\x00\x00\x00\x00\x00abc\n")},
+	}
+	converter := JournalExportToEcsConverter{}
+	for _, test := range tests {
+		result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.journalexport", Data: test.data})
+		if len(result) != 1 || !result[0].MetaLog.EcsLogEntry.HasProcessError() {
+			t.Errorf("Pos %d: expected one entry with a process error but got %v", test.pos, result)
+		}
+	}
+	result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.journalexport", Data: []byte("\n\n")})
+	if len(result) != 1 || !result[0].Skip {
+		t.Errorf("Expected a skipped entry for an empty payload but got %v", result)
+	}
+}
@@ -107,6 +107,12 @@ func (r *JournaldDToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.Ingres
 		return journald.toMetaLog(msg, err)
 	}
 
+	return r.ConvertFrom(msg, journald)
+}
+
+// ConvertFrom converts an already unmarshalled journald entry
+// Other ingress converters that provides the same metadata as journald can delegate to here
+func (r *JournaldDToEcsConverter) ConvertFrom(msg *nats.Msg, journald IngressSubjectJournald) ingress.IngressMsgContext {
 	if journald.isPartial() {
 		partialMessages, ok := multiLineCache[journald.CONTAINER_PARTIAL_ID]
 		// If the key exists
@@ -146,6 +152,12 @@ func (r *JournaldDToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.Ingres
 	}
 
 	// No error and no native ecs log. Parse over pattern factory
+	return journald.toMetaLog(msg, nil)
+}
+
+// ConvertError ships an error that occurs before a journald entry is available to the output
+func (r *JournaldDToEcsConverter) ConvertError(msg *nats.Msg, err error) ingress.IngressMsgContext {
+	journald := IngressSubjectJournald{}
 	return journald.toMetaLog(msg, err)
 }
 
@@ -163,7 +175,7 @@ func (r *IngressSubjectJournald) toMetaLog(msg *nats.Msg, err error) ingress.Ing
 					// Define a fallback Loglevel
 					Level:      r.toLogLevel(),
 					LevelEmoji: model.LogLevelToEmoji(r.toLogLevel()),
-					PatternKey: r.patternKey().String(),
+					PatternKey: model.PatternKeyName(r.COM_GITHUB_LOGUNIFIER_APPLICATION_PATTERN_KEY),
 				},
 				Service: &model.Service{
 					Node: &model.Service_Node{
@@ -41,6 +41,29 @@ func TestDefaultPatterContainerLogTest(t *testing.T) {
 
 }
 
+// The PRIORITY of a docker container is the stream. 3 is stderr and not an error level
+func TestPriorityIsNotTheLevel(t *testing.T) {
+	var withoutNomad []string
+	for _, line := range strings.Split(testJournaldContainerLog, "\n") {
+		if !strings.HasPrefix(line, "\"COM_HASHICORP_NOMAD") {
+			withoutNomad = append(withoutNomad, line)
+		}
+	}
+	logs := map[string]string{
+		"nomad":  testJournaldContainerLog,
+		"docker": strings.Join(withoutNomad, "\n"),
+	}
+	for name, log := range logs {
+		parsed := patternfactory.Parse(TestMetaLogFromJournalD([]byte(log), "TestMessage", t))
+		if parsed.Log.PatternKey != model.MetaLog_Nop.String() {
+			t.Errorf("%s: Expected pattern [%s] but got [%s]", name, model.MetaLog_Nop.String(), parsed.Log.PatternKey)
+		}
+		if parsed.Log.Level != model.LogLevel_unknown {
+			t.Errorf("%s: Expected Log level %+v but got %+v", name, model.LogLevel_unknown, parsed.Log.Level)
+		}
+	}
+}
+
 func TestDockerServiceLog(t *testing.T) {
 	log := TestMetaLogFromJournalDFromConst([]byte(testJournaldDockerServiceLog), t)
 	parsed := patternfactory.Parse(log)
@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The pod annotations of the application metadata. The same keys as the docker labels
+// For example logunifier selects the logfmt extractor for a pod annotated with com.github.logunifier.application.pattern.key: logfmt
+const (
+	AnnotationName       = "com.github.logunifier.application.name"
+	AnnotationVersion    = "com.github.logunifier.application.version"
+	AnnotationOrg        = "com.github.logunifier.application.org"
+	AnnotationEnv        = "com.github.logunifier.application.env"
+	AnnotationStack      = "com.github.logunifier.application.stack"
+	AnnotationNamespace  = "com.github.logunifier.application.namespace"
+	AnnotationPatternKey = "com.github.logunifier.application.pattern.key"
+)
+
+// The recommended labels of kubernetes. See https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
+const (
+	labelAppName    = "app.kubernetes.io/name"
+	labelAppVersion = "app.kubernetes.io/version"
+	labelAppPartOf  = "app.kubernetes.io/part-of"
+	labelApp        = "app"
+)
+
+// The CRI tags of a log line. See https://github.com/kubernetes/design-proposals-archive/blob/main/node/kubelet-cri-logging.md
+const (
+	criPartial = "P"
+	criFull    = "F"
+)
+
+// KubernetesToEcsConverter converts the logs shipped by the vector kubernetes_logs source
+// A message in the CRI format "timestamp stream P|F message" is unframed. The partial lines are collected until the full line is received
+type KubernetesToEcsConverter struct {
+	mtx      sync.Mutex
+	partials map[string][]string
+}
+
+// IngressSubjectKubernetesLogs For the vector kubernetes_logs fields see https://vector.dev/docs/reference/configuration/sources/kubernetes_logs/#output-data
+type IngressSubjectKubernetesLogs struct {
+	File       string   `json:"file"`
+	Kubernetes PodEntry `json:"kubernetes"`
+	Message    string   `json:"message"`
+	// Partial is set by vector if auto_partial_merge is disabled
+	// Every part of a message is marked with true except the last one
+	Partial    bool      `json:"_partial"`
+	SourceType string    `json:"source_type"`
+	Stream     string    `json:"stream"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+type PodEntry struct {
+	ContainerId    string            `json:"container_id"`
+	ContainerImage string            `json:"container_image"`
+	ContainerName  string            `json:"container_name"`
+	PodAnnotations map[string]string `json:"pod_annotations"`
+	PodIp          string            `json:"pod_ip"`
+	PodLabels      map[string]string `json:"pod_labels"`
+	PodName        string            `json:"pod_name"`
+	PodNamespace   string            `json:"pod_namespace"`
+	PodNodeName    string            `json:"pod_node_name"`
+	PodOwner       string            `json:"pod_owner"`
+	PodUid         string            `json:"pod_uid"`
+}
+
+// CriLine a line of the CRI log format
+type CriLine struct {
+	Timestamp time.Time
+	Stream    string
+	Tag       string
+	Message   string
+}
+
+func (r *KubernetesToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	entry := IngressSubjectKubernetesLogs{}
+	err := json.Unmarshal(msg.Data, &entry)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	partial := entry.Partial
+	if line, ok := ParseCri(entry.Message); ok {
+		entry.Timestamp = line.Timestamp
+		entry.Stream = line.Stream
+		entry.Message = line.Message
+		partial = line.Tag == criPartial
+	}
+	message, complete := r.reassemble(entry.partialKey(), entry.Message, partial)
+	if !complete {
+		// Waiting for the full line
+		return ingress.IngressMsgContext{Skip: true, NatsMsg: msg}
+	}
+	entry.Message = message
+	patternKey := entry.patternKey()
+	if patternKey == model.MetaLog_Ecs {
+		// The message is a native ecs log. The pod metadata fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, []byte(entry.Message))
+		entry.toEcs(ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, model.PatternKeyName(entry.Kubernetes.PodAnnotations[AnnotationPatternKey]), entry.Message, entry.ts(msg))
+	entry.toEcs(ctx.MetaLog.EcsLogEntry)
+	return ctx
+}
+
+// reassemble collects the partial messages of a container stream. Returns the joined message and true with the full line
+func (r *KubernetesToEcsConverter) reassemble(key string, message string, partial bool) (string, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.partials == nil {
+		r.partials = make(map[string][]string)
+	}
+	if partial {
+		r.partials[key] = append(r.partials[key], message)
+		return "", false
+	}
+	parts, ok := r.partials[key]
+	if !ok {
+		return message, true
+	}
+	// That's the last part. The kubelet writes the parts in order
+	delete(r.partials, key)
+	return strings.Join(parts, "") + message, true
+}
+
+// ParseCri a line in the CRI log format. False if the line is not in that format
+// For example 2023-10-06T00:17:09.669794202Z stdout F the message
+func ParseCri(line string) (CriLine, bool) {
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) < 3 {
+		return CriLine{}, false
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return CriLine{}, false
+	}
+	if fields[1] != "stdout" && fields[1] != "stderr" {
+		return CriLine{}, false
+	}
+	// The tag may be followed by further flags separated by a colon
+	tag, _, _ := strings.Cut(fields[2], ":")
+	if tag != criPartial && tag != criFull {
+		return CriLine{}, false
+	}
+	result := CriLine{Timestamp: timestamp, Stream: fields[1], Tag: tag}
+	if len(fields) == 4 {
+		result.Message = fields[3]
+	}
+	return result, true
+}
+
+// toEcs the pod metadata fill the fields that are not set yet
+// The annotations of the application metadata take precedence over the recommended labels
+func (r *IngressSubjectKubernetesLogs) toEcs(ecs *model.EcsLogEntry) {
+	pod := r.Kubernetes
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	for name, value := range pod.PodLabels {
+		if _, ok := ecs.Labels[name]; !ok {
+			ecs.Labels[name] = value
+		}
+	}
+	ecs.Labels["pod_name"] = pod.PodName
+	ecs.Labels["pod_uid"] = pod.PodUid
+	if len(pod.PodOwner) > 0 {
+		ecs.Labels["pod_owner"] = pod.PodOwner
+	}
+	if ecs.Service == nil {
+		ecs.Service = &model.Service{}
+	}
+	if len(ecs.Service.Type) == 0 {
+		ecs.Service.Type = string(ingress.JobTypeKubernetesPod)
+	}
+	if !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(r.serviceName())
+	}
+	if len(ecs.Service.Version) == 0 {
+		ecs.Service.Version = pod.metadata(AnnotationVersion, labelAppVersion)
+	}
+	if !ecs.IsServiceNameSpaceSet() {
+		ecs.SetServiceNameSpace(firstNonEmpty(pod.PodAnnotations[AnnotationNamespace], pod.PodNamespace))
+	}
+	if !ecs.IsStackSet() {
+		ecs.SetStack(pod.metadata(AnnotationStack, labelAppPartOf))
+	}
+	if value := pod.PodAnnotations[AnnotationEnv]; len(value) > 0 && !ecs.IsEnvironmentSet() {
+		ecs.SetEnvironment(value)
+	}
+	if value := pod.PodAnnotations[AnnotationOrg]; len(value) > 0 && !ecs.IsOrgNameSet() {
+		ecs.SetOrgName(value)
+	}
+	if len(pod.PodNodeName) > 0 {
+		ecs.Service.Node = &model.Service_Node{Name: pod.PodNodeName}
+		if !ecs.IsHostNameSet() {
+			ecs.SetHostName(pod.PodNodeName)
+		}
+	}
+	if ecs.Container == nil && len(pod.ContainerName) > 0 {
+		// The container id is prefixed by the runtime. For example containerd://3c0fdd1a6f30
+		runtime, id, found := strings.Cut(pod.ContainerId, "://")
+		if !found {
+			runtime, id = "", pod.ContainerId
+		}
+		ecs.Container = &model.Container{
+			Id:      id,
+			Name:    pod.ContainerName,
+			Runtime: runtime,
+			Image:   &model.Container_Image{Name: pod.ContainerImage},
+			Labels:  map[string]string{"stream": r.Stream},
+		}
+	}
+}
+
+// serviceName the annotated application name, the recommended name label, the app label or the container name
+func (r *IngressSubjectKubernetesLogs) serviceName() string {
+	pod := r.Kubernetes
+	return firstNonEmpty(pod.PodAnnotations[AnnotationName], pod.PodLabels[labelAppName], pod.PodLabels[labelApp], pod.ContainerName)
+}
+
+func (r *IngressSubjectKubernetesLogs) patternKey() model.MetaLog_PatternKey {
+	return model.StringToLogPatternKey(r.Kubernetes.PodAnnotations[AnnotationPatternKey])
+}
+
+func (r *IngressSubjectKubernetesLogs) partialKey() string {
+	return r.Kubernetes.PodUid + "/" + r.Kubernetes.ContainerName + "@" + r.Stream
+}
+
+func (r *IngressSubjectKubernetesLogs) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	if r.Timestamp.IsZero() {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	return timestamppb.New(r.Timestamp)
+}
+
+// metadata the annotation if set. The label otherwise
+func (p *PodEntry) metadata(annotation string, label string) string {
+	return firstNonEmpty(p.PodAnnotations[annotation], p.PodLabels[label])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+const testKubernetesLog = `{
+  "file": "/var/log/pods/billing_invoice-7d9f8c6b5-x2x9z_5a1b2c3d/invoice/0.log",
+  "kubernetes": {
+    "container_id": "containerd://3c0fdd1a6f30b2e8f6b4d1a6c0a9f8e7",
+    "container_image": "acme/invoice:1.4.2",
+    "container_name": "invoice",
+    "pod_annotations": {
+      "com.github.logunifier.application.pattern.key": "logfmt",
+      "com.github.logunifier.application.env": "prod"
+    },
+    "pod_labels": {
+      "app.kubernetes.io/name": "invoice",
+      "app.kubernetes.io/version": "1.4.2",
+      "app.kubernetes.io/part-of": "billing",
+      "team": "payments"
+    },
+    "pod_name": "invoice-7d9f8c6b5-x2x9z",
+    "pod_namespace": "billing",
+    "pod_node_name": "worker-01",
+    "pod_owner": "ReplicaSet/invoice-7d9f8c6b5",
+    "pod_uid": "5a1b2c3d"
+  },
+  "message": %q,
+  "source_type": "kubernetes_logs",
+  "stream": "stdout",
+  "timestamp": "2023-10-06T00:17:10Z"
+}`
+
+func kubernetesMsg(message string) *nats.Msg {
+	return &nats.Msg{Subject: "ingress.logs.kubernetes", Data: []byte(fmt.Sprintf(testKubernetesLog, message))}
+}
+
+func TestKubernetesToEcs(t *testing.T) {
+	converter := &KubernetesToEcsConverter{}
+	result := converter.ConvertToMetaLog(kubernetesMsg(`2023-10-06T00:17:09.669794202Z stderr F level=warn msg="invoice delayed"`))
+	if result.Skip {
+		t.Fatal("Expected not skipped but got skipped")
+	}
+	metaLog := result.MetaLog
+	ecs := metaLog.EcsLogEntry
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if metaLog.PatternKey != model.MetaLog_LogFmt || metaLog.RawMessage != `level=warn msg="invoice delayed"` {
+		t.Errorf("Expected the logfmt message but got %s %s", metaLog.PatternKey, metaLog.RawMessage)
+	}
+	if ecs.Timestamp.AsTime().UnixNano() != 1696551429669794202 {
+		t.Errorf("Expected the cri timestamp but got %v", ecs.Timestamp.AsTime())
+	}
+	if ecs.Service.Name != "invoice" || ecs.Service.Version != "1.4.2" || ecs.Service.Stack != "billing" || ecs.Service.Namespace != "billing" {
+		t.Errorf("Expected the service invoice 1.4.2 of billing but got %v", ecs.Service)
+	}
+	if ecs.Service.Type != string(ingress.JobTypeKubernetesPod) || ecs.Host.Name != "worker-01" || ecs.Environment.Name != "prod" {
+		t.Errorf("Expected a kubernetes pod on worker-01 in prod but got %s %v %v", ecs.Service.Type, ecs.Host, ecs.Environment)
+	}
+	if ecs.Container.Id != "3c0fdd1a6f30b2e8f6b4d1a6c0a9f8e7" || ecs.Container.Runtime != "containerd" || ecs.Container.Image.Name != "acme/invoice:1.4.2" {
+		t.Errorf("Expected the containerd container of acme/invoice:1.4.2 but got %v", ecs.Container)
+	}
+	if ecs.Container.Labels["stream"] != "stderr" {
+		t.Errorf("Expected the cri stream stderr but got %s", ecs.Container.Labels["stream"])
+	}
+	if ecs.Labels["team"] != "payments" || ecs.Labels["pod_name"] != "invoice-7d9f8c6b5-x2x9z" || ecs.Labels["pod_owner"] != "ReplicaSet/invoice-7d9f8c6b5" {
+		t.Errorf("Expected the pod labels but got %v", ecs.Labels)
+	}
+}
+
+func TestKubernetesPartial(t *testing.T) {
+	converter := &KubernetesToEcsConverter{}
+	parts := []string{
+		"2023-10-06T00:17:09.1Z stdout P first ",
+		"2023-10-06T00:17:09.2Z stdout P second ",
+		"2023-10-06T00:17:09.3Z stdout F last",
+	}
+	for i, part := range parts[:2] {
+		if result := converter.ConvertToMetaLog(kubernetesMsg(part)); !result.Skip {
+			t.Errorf("Pos %d: expected the partial line is skipped", i+1)
+		}
+	}
+	// The other stream of the container is not affected
+	result := converter.ConvertToMetaLog(kubernetesMsg("2023-10-06T00:17:09.25Z stderr F other"))
+	if result.Skip || result.MetaLog.RawMessage != "other" {
+		t.Errorf("Expected the full line of stderr but got %v", result.MetaLog)
+	}
+	result = converter.ConvertToMetaLog(kubernetesMsg(parts[2]))
+	if result.Skip || result.MetaLog.RawMessage != "first second last" {
+		t.Errorf("Expected the reassembled message but got %v", result.MetaLog)
+	}
+}
+
+func TestKubernetesVectorParsed(t *testing.T) {
+	converter := &KubernetesToEcsConverter{}
+	result := converter.ConvertToMetaLog(kubernetesMsg("level=info msg=started"))
+	metaLog := result.MetaLog
+	if metaLog.RawMessage != "level=info msg=started" || metaLog.EcsLogEntry.Timestamp.AsTime().Unix() != 1696551430 {
+		t.Errorf("Expected the message as is with the vector timestamp but got %s %v", metaLog.RawMessage, metaLog.EcsLogEntry.Timestamp.AsTime())
+	}
+}
+
+func TestParseCri(t *testing.T) {
+	tests := []struct {
+		pos     int
+		line    string
+		ok      bool
+		tag     string
+		message string
+	}{
+		{pos: 1, line: "2023-10-06T00:17:09.669794202Z stdout F hello world", ok: true, tag: criFull, message: "hello world"},
+		{pos: 2, line: "2023-10-06T00:17:09.669794202+02:00 stderr P part", ok: true, tag: criPartial, message: "part"},
+		{pos: 3, line: "2023-10-06T00:17:09Z stdout F", ok: true, tag: criFull, message: ""},
+		{pos: 4, line: "2023-10-06T00:17:09Z stdout F:x tagged", ok: true, tag: criFull, message: "tagged"},
+		{pos: 5, line: "level=info msg=started at", ok: false},
+		{pos: 6, line: "2023-10-06T00:17:09Z other F hello", ok: false},
+		{pos: 7, line: "2023-10-06T00:17:09Z stdout X hello", ok: false},
+	}
+	for _, test := range tests {
+		line, ok := ParseCri(test.line)
+		if ok != test.ok {
+			t.Errorf("Pos %d: expected %t but got %t", test.pos, test.ok, ok)
+			continue
+		}
+		if ok && (line.Tag != test.tag || line.Message != test.message) {
+			t.Errorf("Pos %d: expected %s %q but got %s %q", test.pos, test.tag, test.message, line.Tag, line.Message)
+		}
+	}
+}
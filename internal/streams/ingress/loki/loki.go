@@ -0,0 +1,145 @@
+package loki
+
+import (
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LokiToEcsConverter converts a loki push request to one MetaLog per stream entry
+// The payload has the wire format of the loki push api. Snappy compressed protobuf or json if the Content-Type is application/json
+type LokiToEcsConverter struct {
+}
+
+// labelPatternKey the stream label that selects the extractor of the log line
+const labelPatternKey = "pattern_key"
+
+func (r *LokiToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	// Only called if the converter is not used as ingress.MultiMetaLogConverter
+	return r.ConvertToMetaLogs(msg)[0]
+}
+
+func (r *LokiToEcsConverter) ConvertToMetaLogs(msg *nats.Msg) []ingress.IngressMsgContext {
+	request, err := Decode(msg.Header.Get(ingress.HeaderContentType), msg.Data)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return []ingress.IngressMsgContext{ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)}
+	}
+	var result []ingress.IngressMsgContext
+	for _, stream := range request.Streams {
+		labels, err := syntax.ParseLabels(stream.Labels)
+		if err != nil {
+			result = append(result, ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err))
+			continue
+		}
+		for _, entry := range stream.Entries {
+			result = append(result, toMetaLog(msg, labels.Map(), entry))
+		}
+	}
+	if len(result) == 0 {
+		// An empty push request. Nothing to do
+		return []ingress.IngressMsgContext{{Skip: true, NatsMsg: msg}}
+	}
+	return result
+}
+
+func toMetaLog(msg *nats.Msg, labels map[string]string, entry logproto.Entry) ingress.IngressMsgContext {
+	patternKey := model.StringToLogPatternKey(labels[labelPatternKey])
+	if patternKey == model.MetaLog_Ecs {
+		// The line is a native ecs log. The labels fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, []byte(entry.Line))
+		labelsToEcs(labels, entry, ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, model.PatternKeyName(labels[labelPatternKey]), entry.Line, ts(msg, entry))
+	// The message is the whole push request
+	ctx.MetaLog.EcsLogEntry.ProcessError.RawData = entry.Line
+	labelsToEcs(labels, entry, ctx.MetaLog.EcsLogEntry)
+	ctx.MetaLog.IngressLevel = ctx.MetaLog.EcsLogEntry.IsLogLevelSet()
+	return ctx
+}
+
+// labelsToEcs maps the well known stream labels to ecs fields that are not set yet
+// The stream labels and the structured metadata of the entry are kept as labels
+func labelsToEcs(labels map[string]string, entry logproto.Entry, ecs *model.EcsLogEntry) {
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		if k != labelPatternKey {
+			ecs.Labels[k] = v
+		}
+	}
+	metadata := make(map[string]string)
+	for _, label := range entry.StructuredMetadata {
+		metadata[label.Name] = label.Value
+		ecs.Labels[label.Name] = label.Value
+	}
+	if name := first(labels, "service_name", "app", "job"); len(name) > 0 && !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(name)
+	}
+	if namespace := labels["namespace"]; len(namespace) > 0 && !ecs.IsServiceNameSpaceSet() {
+		ecs.SetServiceNameSpace(namespace)
+	}
+	if stack := labels["stack"]; len(stack) > 0 && !ecs.IsStackSet() {
+		ecs.SetStack(stack)
+	}
+	if version := labels["version"]; len(version) > 0 && len(ecs.Service.Version) == 0 {
+		ecs.Service.Version = version
+	}
+	if env := first(labels, "env", "environment"); len(env) > 0 && !ecs.IsEnvironmentSet() {
+		ecs.SetEnvironment(env)
+	}
+	if org := labels["org"]; len(org) > 0 && !ecs.IsOrgNameSet() {
+		ecs.SetOrgName(org)
+	}
+	if host := first(labels, "host", "hostname", "instance"); len(host) > 0 && !ecs.IsHostNameSet() {
+		ecs.SetHostName(host)
+	}
+	if container := first(labels, "container", "container_name"); len(container) > 0 && ecs.Container == nil {
+		ecs.Container = &model.Container{
+			Name: container,
+		}
+		if pod := labels["pod"]; len(pod) > 0 {
+			ecs.Container.Labels = map[string]string{"pod": pod}
+		}
+	}
+	if level := first(labels, "level", "detected_level", "severity"); len(level) > 0 && !ecs.IsLogLevelSet() {
+		ecs.SetLogLevel(model.StringToLogLevel(level))
+	}
+	traceId := first(metadata, "trace_id", "traceID", "traceId")
+	spanId := first(metadata, "span_id", "spanID", "spanId")
+	if len(traceId) > 0 && !ecs.IsTraceIdSet() {
+		if ecs.Trace == nil {
+			ecs.Trace = &model.Tracing{}
+		}
+		ecs.Trace.Trace = &model.Tracing_Trace{Id: traceId}
+	}
+	if len(spanId) > 0 && !ecs.IsSpanIdSet() {
+		if ecs.Trace == nil {
+			ecs.Trace = &model.Tracing{}
+		}
+		ecs.Trace.Span = &model.Tracing_Span{Id: spanId}
+	}
+}
+
+// first the value of the first key that is set
+func first(values map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := values[key]; len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+func ts(msg *nats.Msg, entry logproto.Entry) *timestamppb.Timestamp {
+	if entry.Timestamp.IsZero() || entry.Timestamp.Unix() == 0 {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	return timestamppb.New(entry.Timestamp)
+}
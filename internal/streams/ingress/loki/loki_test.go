@@ -0,0 +1,147 @@
+package loki
+
+import (
+	"github.com/grafana/loki/pkg/push"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+	"time"
+)
+
+func lokiMsg(t *testing.T, request *logproto.PushRequest) *nats.Msg {
+	data, err := Encode(request)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	msg := nats.NewMsg("ingress.logs.loki")
+	msg.Header.Set("Content-Type", ContentTypeProtobuf)
+	msg.Data = data
+	return msg
+}
+
+func TestLokiLabelsToEcs(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	request := &logproto.PushRequest{
+		Streams: []logproto.Stream{
+			{
+				Labels: `{service_name="checkout", namespace="shop", host="worker-01", env="prod", level="warn", container="app", pod="checkout-7d9f", pattern_key="logfmt"}`,
+				Entries: []logproto.Entry{
+					{
+						Timestamp:          ts,
+						Line:               `level=warn msg="payment slow"`,
+						StructuredMetadata: push.LabelsAdapter{{Name: "trace_id", Value: "5b8efff798038103d269b633813fc60c"}},
+					},
+					{Timestamp: ts.Add(time.Second), Line: `level=info msg="payment done"`},
+				},
+			},
+			{
+				Labels:  `{job="varlogs"}`,
+				Entries: []logproto.Entry{{Timestamp: ts, Line: "plain line"}},
+			},
+		},
+	}
+	converter := LokiToEcsConverter{}
+	result := converter.ConvertToMetaLogs(lokiMsg(t, request))
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 entries but got %d", len(result))
+	}
+	first := result[0].MetaLog
+	if first.PatternKey != model.MetaLog_LogFmt {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_LogFmt, first.PatternKey)
+	}
+	if first.RawMessage != `level=warn msg="payment slow"` {
+		t.Errorf("Expected the log line as raw message but got %s", first.RawMessage)
+	}
+	ecs := first.EcsLogEntry
+	if ecs.Service.Name != "checkout" {
+		t.Errorf("Expected service name checkout but got %s", ecs.Service.Name)
+	}
+	if ecs.Service.Namespace != "shop" {
+		t.Errorf("Expected namespace shop but got %s", ecs.Service.Namespace)
+	}
+	if ecs.Host.Name != "worker-01" {
+		t.Errorf("Expected host worker-01 but got %s", ecs.Host.Name)
+	}
+	if ecs.Environment.Name != "prod" {
+		t.Errorf("Expected environment prod but got %s", ecs.Environment.Name)
+	}
+	if ecs.Log.Level != model.LogLevel_warn {
+		t.Errorf("Expected level warn but got %s", ecs.Log.Level)
+	}
+	if ecs.Container.Name != "app" || ecs.Container.Labels["pod"] != "checkout-7d9f" {
+		t.Errorf("Expected container app of pod checkout-7d9f but got %v", ecs.Container)
+	}
+	if ecs.Trace.Trace.Id != "5b8efff798038103d269b633813fc60c" {
+		t.Errorf("Expected the trace id of the structured metadata but got %s", ecs.Trace.Trace.Id)
+	}
+	if ecs.Labels["namespace"] != "shop" || ecs.Labels["trace_id"] != "5b8efff798038103d269b633813fc60c" {
+		t.Errorf("Expected stream labels and structured metadata as labels but got %v", ecs.Labels)
+	}
+	if _, ok := ecs.Labels[labelPatternKey]; ok {
+		t.Errorf("Expected no %s label", labelPatternKey)
+	}
+	if !ecs.Timestamp.AsTime().Equal(ts) {
+		t.Errorf("Expected timestamp %s but got %s", ts, ecs.Timestamp.AsTime())
+	}
+	if !result[1].MetaLog.EcsLogEntry.Timestamp.AsTime().Equal(ts.Add(time.Second)) {
+		t.Errorf("Expected the timestamp of the second entry but got %s", result[1].MetaLog.EcsLogEntry.Timestamp.AsTime())
+	}
+	last := result[2].MetaLog
+	if last.PatternKey != model.MetaLog_Nop {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_Nop, last.PatternKey)
+	}
+	if last.EcsLogEntry.Service.Name != "varlogs" {
+		t.Errorf("Expected service name varlogs but got %s", last.EcsLogEntry.Service.Name)
+	}
+	if last.EcsLogEntry.Log.Level != model.LogLevel_not_set {
+		t.Errorf("Expected level not_set but got %s", last.EcsLogEntry.Log.Level)
+	}
+}
+
+func TestLokiEcsLine(t *testing.T) {
+	request := &logproto.PushRequest{
+		Streams: []logproto.Stream{
+			{
+				Labels: `{app="billing", namespace="finance", pattern_key="ecs"}`,
+				Entries: []logproto.Entry{{
+					Timestamp: time.Now(),
+					Line:      `{"message":"invoice created","service":{"name":"invoice"},"log":{"level":"info"}}`,
+				}},
+			},
+		},
+	}
+	converter := LokiToEcsConverter{}
+	result := converter.ConvertToMetaLogs(lokiMsg(t, request))
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 entry but got %d", len(result))
+	}
+	ecs := result[0].MetaLog.EcsLogEntry
+	if result[0].MetaLog.PatternKey != model.MetaLog_Ecs {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_Ecs, result[0].MetaLog.PatternKey)
+	}
+	if ecs.Service.Name != "invoice" {
+		t.Errorf("Expected the service name of the ecs line but got %s", ecs.Service.Name)
+	}
+	if ecs.Service.Namespace != "finance" {
+		t.Errorf("Expected the missing namespace from the labels but got %s", ecs.Service.Namespace)
+	}
+	if ecs.Log.Level != model.LogLevel_info {
+		t.Errorf("Expected level info but got %s", ecs.Log.Level)
+	}
+}
+
+func TestLokiInvalid(t *testing.T) {
+	msg := nats.NewMsg("ingress.logs.loki")
+	msg.Data = []byte("no snappy")
+	converter := LokiToEcsConverter{}
+	result := converter.ConvertToMetaLogs(msg)
+	if len(result) != 1 || !result[0].MetaLog.EcsLogEntry.HasProcessError() {
+		t.Errorf("Expected one entry with a process error but got %v", result)
+	}
+	msg = lokiMsg(t, &logproto.PushRequest{})
+	result = converter.ConvertToMetaLogs(msg)
+	if len(result) != 1 || !result[0].Skip {
+		t.Errorf("Expected a skipped entry for an empty push request but got %v", result)
+	}
+}
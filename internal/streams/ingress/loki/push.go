@@ -0,0 +1,248 @@
+package loki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/rs/zerolog"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/httpingress"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PushPath            = "/loki/api/v1/push"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// PushHandler serves the loki push api and publishes the streams to the loki ingress subject
+// Promtail, Grafana Alloy and other loki clients can push to logunifier then
+type PushHandler struct {
+	subject   string
+	publisher ingress.Publisher
+	logger    zerolog.Logger
+}
+
+func NewPushHandler(subject string, publisher ingress.Publisher) *PushHandler {
+	return &PushHandler{
+		subject:   subject,
+		publisher: publisher,
+		logger:    config.Logger(),
+	}
+}
+
+// Mux the push endpoint and the ready endpoint that the loki clients use
+func (h *PushHandler) Mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(PushPath, h)
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+// ServeHTTP answers like loki. 204 on success, 4xx if the request is invalid and 5xx if the client should retry
+func (h *PushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := httpingress.ReadBody(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request, err := Decode(r.Header.Get(ingress.HeaderContentType), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(request.Streams) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	maxPayload, err := h.publisher.MaxPayload()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	msgs, err := h.messages(request, maxPayload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	err = h.publisher.Publish(msgs...)
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("Can't publish loki push request to %s", h.subject)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// messages the request split into nats messages that fit into the max payload
+func (h *PushHandler) messages(request *logproto.PushRequest, maxPayload int64) ([]*nats.Msg, error) {
+	// Snappy may expand incompressible data by 1/6 at most
+	limit := int(maxPayload-ingress.HeaderReserve) * 6 / 7
+	var msgs []*nats.Msg
+	for _, chunk := range chunks(request, limit) {
+		if chunk.Size() > limit {
+			return nil, fmt.Errorf("log entry of stream %s exceeds the max payload of %d bytes", chunk.Streams[0].Labels, maxPayload)
+		}
+		data, err := Encode(chunk)
+		if err != nil {
+			return nil, err
+		}
+		msg := nats.NewMsg(h.subject)
+		msg.Header.Set(ingress.HeaderContentType, ContentTypeProtobuf)
+		msg.Data = data
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// Decode a push request. Json if the content type is application/json. Snappy compressed protobuf otherwise
+func Decode(contentType string, data []byte) (*logproto.PushRequest, error) {
+	request := &logproto.PushRequest{}
+	if strings.HasPrefix(contentType, ingress.ContentTypeJson) {
+		err := decodeJson(data, request)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode json push request: %w", err)
+		}
+		return request, nil
+	}
+	decoded, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode snappy push request: %w", err)
+	}
+	err = request.Unmarshal(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode protobuf push request: %w", err)
+	}
+	return request, nil
+}
+
+// jsonPushRequest {"streams":[{"stream":{"label":"value"},"values":[["<unix epoch ns>","<line>",{"metadata":"value"}]]}]}
+type jsonPushRequest struct {
+	Streams []struct {
+		Stream map[string]string   `json:"stream"`
+		Values [][]json.RawMessage `json:"values"`
+	} `json:"streams"`
+}
+
+func decodeJson(data []byte, request *logproto.PushRequest) error {
+	jsonRequest := jsonPushRequest{}
+	err := json.Unmarshal(data, &jsonRequest)
+	if err != nil {
+		return err
+	}
+	for _, jsonStream := range jsonRequest.Streams {
+		stream := logproto.Stream{
+			Labels:  labels.FromMap(jsonStream.Stream).String(),
+			Entries: make([]logproto.Entry, 0, len(jsonStream.Values)),
+		}
+		for _, value := range jsonStream.Values {
+			entry, err := decodeJsonEntry(value)
+			if err != nil {
+				return err
+			}
+			stream.Entries = append(stream.Entries, entry)
+		}
+		request.Streams = append(request.Streams, stream)
+	}
+	return nil
+}
+
+func decodeJsonEntry(value []json.RawMessage) (logproto.Entry, error) {
+	entry := logproto.Entry{}
+	if len(value) < 2 || len(value) > 3 {
+		return entry, errors.New("expected a value of [timestamp, line] or [timestamp, line, metadata]")
+	}
+	var ts string
+	err := json.Unmarshal(value[0], &ts)
+	if err != nil {
+		return entry, err
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("invalid timestamp %s: %w", ts, err)
+	}
+	entry.Timestamp = time.Unix(0, nanos)
+	err = json.Unmarshal(value[1], &entry.Line)
+	if err != nil {
+		return entry, err
+	}
+	if len(value) == 3 {
+		metadata := make(map[string]string)
+		err = json.Unmarshal(value[2], &metadata)
+		if err != nil {
+			return entry, err
+		}
+		for name, v := range metadata {
+			entry.StructuredMetadata = append(entry.StructuredMetadata, logproto.LabelAdapter{Name: name, Value: v})
+		}
+		sort.Slice(entry.StructuredMetadata, func(i, j int) bool {
+			return entry.StructuredMetadata[i].Name < entry.StructuredMetadata[j].Name
+		})
+	}
+	return entry, nil
+}
+
+// Encode a push request as snappy compressed protobuf
+func Encode(request *logproto.PushRequest) ([]byte, error) {
+	data, err := request.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// chunks splits the request into requests with an encoded size below limit
+// A stream with too many entries is split into streams with the same labels
+// A chunk is still larger than the limit if a single entry exceeds it
+func chunks(request *logproto.PushRequest, limit int) []*logproto.PushRequest {
+	var result []*logproto.PushRequest
+	current := &logproto.PushRequest{}
+	currentSize := 0
+	for _, stream := range request.Streams {
+		for _, part := range splitStream(stream, limit) {
+			// The encoded request is the concatenation of its encoded streams
+			size := streamSize(part)
+			if len(current.Streams) > 0 && currentSize+size > limit {
+				result = append(result, current)
+				current = &logproto.PushRequest{}
+				currentSize = 0
+			}
+			current.Streams = append(current.Streams, part)
+			currentSize += size
+		}
+	}
+	if len(current.Streams) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+func splitStream(stream logproto.Stream, limit int) []logproto.Stream {
+	if len(stream.Entries) <= 1 || streamSize(stream) <= limit {
+		return []logproto.Stream{stream}
+	}
+	half := len(stream.Entries) / 2
+	return append(
+		splitStream(logproto.Stream{Labels: stream.Labels, Entries: stream.Entries[:half]}, limit),
+		splitStream(logproto.Stream{Labels: stream.Labels, Entries: stream.Entries[half:]}, limit)...,
+	)
+}
+
+func streamSize(stream logproto.Stream) int {
+	return (&logproto.PushRequest{Streams: []logproto.Stream{stream}}).Size()
+}
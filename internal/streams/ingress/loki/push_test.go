@@ -0,0 +1,141 @@
+package loki
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/grafana/loki/v3/pkg/logproto"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPushJson = `{"streams":[{"stream":{"app":"checkout","level":"error"},"values":[["1709294400000000000","payment failed",{"trace_id":"abc"}],["1709294401000000000","payment retried"]]}]}`
+
+func pushRequest(handler http.Handler, contentType string, encoding string, body []byte) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, PushPath, bytes.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	if len(encoding) > 0 {
+		request.Header.Set("Content-Encoding", encoding)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestPushJson(t *testing.T) {
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, _ = gzipWriter.Write([]byte(testPushJson))
+	_ = gzipWriter.Close()
+
+	tests := []struct {
+		pos      int
+		encoding string
+		body     []byte
+	}{
+		{pos: 1, body: []byte(testPushJson)},
+		{pos: 2, encoding: "gzip", body: gzipped.Bytes()},
+	}
+	for _, test := range tests {
+		publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+		handler := NewPushHandler("ingress.logs.loki", publisher).Mux()
+		recorder := pushRequest(handler, "application/json", test.encoding, test.body)
+		if recorder.Code != http.StatusNoContent {
+			t.Errorf("Pos %d: expected status %d but got %d %s", test.pos, http.StatusNoContent, recorder.Code, recorder.Body.String())
+			continue
+		}
+		if len(publisher.Msgs) != 1 {
+			t.Errorf("Pos %d: expected 1 published message but got %d", test.pos, len(publisher.Msgs))
+			continue
+		}
+		request, err := Decode(publisher.Msgs[0].Header.Get("Content-Type"), publisher.Msgs[0].Data)
+		if err != nil {
+			t.Errorf("Pos %d: expected no error but got %s", test.pos, err)
+			continue
+		}
+		stream := request.Streams[0]
+		if stream.Labels != `{app="checkout", level="error"}` {
+			t.Errorf("Pos %d: expected labels {app=\"checkout\", level=\"error\"} but got %s", test.pos, stream.Labels)
+		}
+		if len(stream.Entries) != 2 || stream.Entries[0].Line != "payment failed" || stream.Entries[0].StructuredMetadata[0].Value != "abc" {
+			t.Errorf("Pos %d: expected the entries of the json request but got %v", test.pos, stream.Entries)
+		}
+		if !stream.Entries[1].Timestamp.Equal(time.Unix(1709294401, 0)) {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, time.Unix(1709294401, 0), stream.Entries[1].Timestamp)
+		}
+	}
+}
+
+func TestPushProtobufChunks(t *testing.T) {
+	request := &logproto.PushRequest{}
+	for i := 0; i < 10; i++ {
+		stream := logproto.Stream{Labels: fmt.Sprintf(`{app="app-%d"}`, i)}
+		for j := 0; j < 20; j++ {
+			stream.Entries = append(stream.Entries, logproto.Entry{Timestamp: time.Now(), Line: strings.Repeat("x", 100)})
+		}
+		request.Streams = append(request.Streams, stream)
+	}
+	body, err := Encode(request)
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	publisher := &testingress.Publisher{MaxPayloadBytes: 4096}
+	handler := NewPushHandler("ingress.logs.loki", publisher).Mux()
+	recorder := pushRequest(handler, ContentTypeProtobuf, "", body)
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d but got %d %s", http.StatusNoContent, recorder.Code, recorder.Body.String())
+	}
+	if len(publisher.Msgs) < 2 {
+		t.Errorf("Expected the request is split into several messages but got %d", len(publisher.Msgs))
+	}
+	entries := 0
+	for _, msg := range publisher.Msgs {
+		if int64(len(msg.Data)) > publisher.MaxPayloadBytes {
+			t.Errorf("Expected a message smaller than %d but got %d", publisher.MaxPayloadBytes, len(msg.Data))
+		}
+		chunk, err := Decode(msg.Header.Get("Content-Type"), msg.Data)
+		if err != nil {
+			t.Fatalf("Expected no error but got %s", err)
+		}
+		for _, stream := range chunk.Streams {
+			entries += len(stream.Entries)
+		}
+	}
+	if entries != 200 {
+		t.Errorf("Expected 200 entries but got %d", entries)
+	}
+}
+
+func TestPushErrors(t *testing.T) {
+	tooLarge := &logproto.PushRequest{Streams: []logproto.Stream{{
+		Labels:  `{app="big"}`,
+		Entries: []logproto.Entry{{Timestamp: time.Now(), Line: strings.Repeat("x", 8192)}},
+	}}}
+	tooLargeBody, _ := Encode(tooLarge)
+	tests := []struct {
+		pos         int
+		contentType string
+		body        []byte
+		publishErr  error
+		expected    int
+	}{
+		{pos: 1, contentType: "application/json", body: []byte(`{"streams":[{"stream":{},"values":[["x","line"]]}]}`), expected: http.StatusBadRequest},
+		{pos: 2, contentType: ContentTypeProtobuf, body: []byte("no snappy"), expected: http.StatusBadRequest},
+		{pos: 3, contentType: ContentTypeProtobuf, body: tooLargeBody, expected: http.StatusRequestEntityTooLarge},
+		{pos: 4, contentType: "application/json", body: []byte(testPushJson), publishErr: errors.New("nats down"), expected: http.StatusInternalServerError},
+		{pos: 5, contentType: "application/json", body: []byte(`{"streams":[]}`), expected: http.StatusNoContent},
+	}
+	for _, test := range tests {
+		publisher := &testingress.Publisher{MaxPayloadBytes: 4096, Err: test.publishErr}
+		handler := NewPushHandler("ingress.logs.loki", publisher).Mux()
+		recorder := pushRequest(handler, test.contentType, "", test.body)
+		if recorder.Code != test.expected {
+			t.Errorf("Pos %d: expected status %d but got %d", test.pos, test.expected, recorder.Code)
+		}
+	}
+}
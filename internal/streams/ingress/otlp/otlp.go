@@ -0,0 +1,353 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OtlpToEcsConverter converts an OTLP ExportLogsServiceRequest to one MetaLog per log record
+// The payload is either protobuf or json encoded. See https://opentelemetry.io/docs/specs/otlp/#otlphttp
+// The request has the same wire and json format as logspb.LogsData. So the collector service stubs are not needed.
+type OtlpToEcsConverter struct {
+}
+
+func (r *OtlpToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	// Only called if the converter is not used as ingress.MultiMetaLogConverter
+	return r.ConvertToMetaLogs(msg)[0]
+}
+
+func (r *OtlpToEcsConverter) ConvertToMetaLogs(msg *nats.Msg) []ingress.IngressMsgContext {
+	request, err := unmarshal(msg)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return []ingress.IngressMsgContext{ingress.ProcessErrorContext(msg, model.MetaLog_Otlp, err)}
+	}
+	var result []ingress.IngressMsgContext
+	for _, resourceLogs := range request.GetResourceLogs() {
+		resource := attributesToMap(resourceLogs.GetResource().GetAttributes())
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			for _, record := range scopeLogs.GetLogRecords() {
+				result = append(result, ingress.IngressMsgContext{
+					NatsMsg: msg,
+					MetaLog: toMetaLog(msg, resource, scopeLogs.GetScope().GetName(), record),
+				})
+			}
+		}
+	}
+	if len(result) == 0 {
+		// An empty export request. Nothing to do
+		return []ingress.IngressMsgContext{{Skip: true, NatsMsg: msg}}
+	}
+	return result
+}
+
+func unmarshal(msg *nats.Msg) (*logspb.LogsData, error) {
+	request := &logspb.LogsData{}
+	if isJson(msg) {
+		data, err := hexIdsToBase64(msg.Data)
+		if err != nil {
+			return nil, err
+		}
+		return request, protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, request)
+	}
+	return request, proto.Unmarshal(msg.Data, request)
+}
+
+// isJson decides by the Content-Type header. Without header a json object is assumed if the payload starts with {
+func isJson(msg *nats.Msg) bool {
+	contentType := msg.Header.Get(ingress.HeaderContentType)
+	if len(contentType) > 0 {
+		return strings.HasPrefix(contentType, ingress.ContentTypeJson)
+	}
+	trimmed := bytes.TrimSpace(msg.Data)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// hexIdsToBase64 The OTLP json encoding uses hex for traceId and spanId instead of the base64 of protojson
+// The numbers are decoded as json.Number. So the nanos of timeUnixNano are not rounded by a float64
+func hexIdsToBase64(data []byte) ([]byte, error) {
+	var request map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&request)
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceLogs := range asSlice(request["resourceLogs"]) {
+		for _, scopeLogs := range asSlice(asMap(resourceLogs)["scopeLogs"]) {
+			for _, record := range asSlice(asMap(scopeLogs)["logRecords"]) {
+				recordMap := asMap(record)
+				for _, key := range []string{"traceId", "spanId", "trace_id", "span_id"} {
+					value, ok := recordMap[key].(string)
+					if !ok || len(value) == 0 {
+						continue
+					}
+					decoded, err := hex.DecodeString(value)
+					if err != nil {
+						return nil, errors.New("invalid hex " + key + " " + value)
+					}
+					recordMap[key] = base64.StdEncoding.EncodeToString(decoded)
+				}
+			}
+		}
+	}
+	return json.Marshal(request)
+}
+
+func asSlice(value any) []any {
+	slice, _ := value.([]any)
+	return slice
+}
+
+func asMap(value any) map[string]any {
+	m, _ := value.(map[string]any)
+	return m
+}
+
+func toMetaLog(msg *nats.Msg, resource map[string]string, scope string, record *logspb.LogRecord) *model.MetaLog {
+	level := toLogLevel(record)
+	message := anyValueToString(record.GetBody())
+	ecs := &model.EcsLogEntry{
+		Message:   message,
+		Labels:    make(map[string]string),
+		Timestamp: ts(msg, record),
+		Log: &model.Log{
+			Level:      level,
+			LevelEmoji: model.LogLevelToEmoji(level),
+			Logger:     scope,
+			PatternKey: model.MetaLog_Otlp.String(),
+			Ingress:    msg.Subject,
+		},
+		Service: &model.Service{
+			Name:      resource["service.name"],
+			Namespace: resource["service.namespace"],
+			Version:   resource["service.version"],
+			Id:        resource["service.instance.id"],
+			Type:      "otel",
+		},
+	}
+	if env := resource["deployment.environment.name"]; len(env) > 0 {
+		ecs.SetEnvironment(env)
+	} else if env := resource["deployment.environment"]; len(env) > 0 {
+		ecs.SetEnvironment(env)
+	}
+	resourceToHost(resource, ecs)
+	resourceToContainer(resource, ecs)
+	recordToTracing(record, ecs)
+	attributesToEcs(attributesToMap(record.GetAttributes()), ecs)
+	return &model.MetaLog{
+		PatternKey:  model.MetaLog_Otlp,
+		RawMessage:  message,
+		EcsLogEntry: ecs,
+	}
+}
+
+func resourceToHost(resource map[string]string, ecs *model.EcsLogEntry) {
+	hostName := resource["host.name"]
+	if len(hostName) == 0 {
+		hostName = resource["k8s.node.name"]
+	}
+	if len(hostName) == 0 {
+		return
+	}
+	ecs.SetHostName(hostName)
+	ecs.Host.Id = resource["host.id"]
+	ecs.Host.Architecture = resource["host.arch"]
+	ecs.Service.Node = &model.Service_Node{
+		Name: hostName,
+	}
+	if osType := resource["os.type"]; len(osType) > 0 {
+		ecs.Host.Os = &model.Host_Os{
+			Type:    osType,
+			Version: resource["os.version"],
+		}
+	}
+}
+
+// resourceToContainer the container.* and k8s.* attributes. The k8s attributes are kept as container labels
+func resourceToContainer(resource map[string]string, ecs *model.EcsLogEntry) {
+	labels := make(map[string]string)
+	for k, v := range resource {
+		if strings.HasPrefix(k, "k8s.") {
+			labels[k] = v
+		}
+	}
+	containerName := resource["container.name"]
+	if len(containerName) == 0 {
+		containerName = resource["k8s.container.name"]
+	}
+	if len(containerName) == 0 && len(resource["container.id"]) == 0 && len(labels) == 0 {
+		return
+	}
+	ecs.Container = &model.Container{
+		Id:      resource["container.id"],
+		Name:    containerName,
+		Runtime: resource["container.runtime"],
+		Labels:  labels,
+	}
+	if image := resource["container.image.name"]; len(image) > 0 {
+		ecs.Container.Image = &model.Container_Image{
+			Name: image,
+		}
+		if tag := resource["container.image.tag"]; len(tag) > 0 {
+			ecs.Container.Image.Tag = strings.Split(tag, ",")
+		}
+	}
+	if len(ecs.Service.Namespace) == 0 {
+		ecs.Service.Namespace = resource["k8s.namespace.name"]
+	}
+}
+
+func recordToTracing(record *logspb.LogRecord, ecs *model.EcsLogEntry) {
+	if len(record.GetTraceId()) == 0 && len(record.GetSpanId()) == 0 {
+		return
+	}
+	ecs.Trace = &model.Tracing{}
+	if len(record.GetTraceId()) > 0 {
+		ecs.Trace.Trace = &model.Tracing_Trace{
+			Id: hex.EncodeToString(record.GetTraceId()),
+		}
+	}
+	if len(record.GetSpanId()) > 0 {
+		ecs.Trace.Span = &model.Tracing_Span{
+			Id: hex.EncodeToString(record.GetSpanId()),
+		}
+	}
+}
+
+// attributesToEcs maps the semantic conventions for exceptions, code and thread. The other attributes are labels
+func attributesToEcs(attributes map[string]string, ecs *model.EcsLogEntry) {
+	for k, v := range attributes {
+		switch k {
+		case "exception.type":
+			errorOf(ecs).Type = v
+		case "exception.message":
+			errorOf(ecs).Message = v
+		case "exception.stacktrace":
+			errorOf(ecs).StackTrace = v
+		case "code.filepath", "code.file.path":
+			line := attributes["code.lineno"]
+			if len(line) == 0 {
+				line = attributes["code.line.number"]
+			}
+			ecs.SetOriginFile(v, line)
+		case "code.function", "code.function.name":
+			if ecs.Log.Origin == nil {
+				ecs.Log.Origin = &model.Log_Origin{}
+			}
+			ecs.Log.Origin.Function = v
+		case "thread.name":
+			ecs.Log.ThreadName = v
+		case "code.lineno", "code.line.number":
+			// Part of the origin file
+		default:
+			ecs.Labels[k] = v
+		}
+	}
+}
+
+func errorOf(ecs *model.EcsLogEntry) *model.Error {
+	if ecs.Error == nil {
+		ecs.Error = &model.Error{}
+	}
+	return ecs.Error
+}
+
+func ts(msg *nats.Msg, record *logspb.LogRecord) *timestamppb.Timestamp {
+	if record.GetTimeUnixNano() > 0 {
+		return timestamppb.New(time.Unix(0, int64(record.GetTimeUnixNano())))
+	}
+	if record.GetObservedTimeUnixNano() > 0 {
+		return timestamppb.New(time.Unix(0, int64(record.GetObservedTimeUnixNano())))
+	}
+	return ingress.TimestampFromIngestion(msg)
+}
+
+// toLogLevel See https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+func toLogLevel(record *logspb.LogRecord) model.LogLevel {
+	severity := record.GetSeverityNumber()
+	switch {
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_FATAL:
+		return model.LogLevel_fatal
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_ERROR:
+		return model.LogLevel_error
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_WARN:
+		return model.LogLevel_warn
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_INFO:
+		return model.LogLevel_info
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG:
+		return model.LogLevel_debug
+	case severity >= logspb.SeverityNumber_SEVERITY_NUMBER_TRACE:
+		return model.LogLevel_trace
+	}
+	if len(record.GetSeverityText()) > 0 {
+		return model.StringToLogLevel(record.GetSeverityText())
+	}
+	return model.LogLevel_not_set
+}
+
+func attributesToMap(attributes []*commonpb.KeyValue) map[string]string {
+	result := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		result[attribute.GetKey()] = anyValueToString(attribute.GetValue())
+	}
+	return result
+}
+
+// anyValueToString the string representation of an attribute value or body. Arrays and maps are json encoded
+func anyValueToString(value *commonpb.AnyValue) string {
+	if value == nil {
+		return ""
+	}
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64)
+	case *commonpb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	default:
+		marshal, err := json.Marshal(anyValueToNative(value))
+		if err != nil {
+			return value.String()
+		}
+		return string(marshal)
+	}
+}
+
+func anyValueToNative(value *commonpb.AnyValue) any {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_ArrayValue:
+		result := make([]any, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			result = append(result, anyValueToNative(item))
+		}
+		return result
+	case *commonpb.AnyValue_KvlistValue:
+		result := make(map[string]any, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			result[kv.GetKey()] = anyValueToNative(kv.GetValue())
+		}
+		return result
+	default:
+		return anyValueToString(value)
+	}
+}
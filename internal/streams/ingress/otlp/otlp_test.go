@@ -0,0 +1,174 @@
+package otlp
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+const testOtlpJson = `{
+  "resourceLogs": [{
+    "resource": {
+      "attributes": [
+        {"key": "service.name", "value": {"stringValue": "checkout"}},
+        {"key": "k8s.namespace.name", "value": {"stringValue": "shop"}},
+        {"key": "k8s.pod.name", "value": {"stringValue": "checkout-7d9f"}},
+        {"key": "k8s.container.name", "value": {"stringValue": "checkout"}},
+        {"key": "host.name", "value": {"stringValue": "worker-01"}}
+      ]
+    },
+    "scopeLogs": [{
+      "scope": {"name": "com.example.Checkout"},
+      "logRecords": [{
+        "timeUnixNano": "1696000000000000000",
+        "severityNumber": 17,
+        "severityText": "ERROR",
+        "body": {"stringValue": "payment failed"},
+        "traceId": "5b8efff798038103d269b633813fc60c",
+        "spanId": "eee19b7ec3c1b174",
+        "attributes": [
+          {"key": "exception.type", "value": {"stringValue": "java.lang.IllegalStateException"}},
+          {"key": "order.id", "value": {"intValue": "42"}}
+        ]
+      }]
+    }]
+  }]
+}`
+
+func stringAttribute(key string, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func TestOtlpProtobufFanOut(t *testing.T) {
+	request := &logspb.LogsData{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{
+					stringAttribute("service.name", "billing"),
+					stringAttribute("service.namespace", "finance"),
+					stringAttribute("service.version", "1.2.3"),
+					stringAttribute("host.name", "worker-02"),
+					stringAttribute("container.id", "abc123"),
+				},
+			},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				LogRecords: []*logspb.LogRecord{
+					{
+						TimeUnixNano:   uint64(time.Date(2023, 9, 29, 15, 6, 40, 0, time.UTC).UnixNano()),
+						SeverityNumber: logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+						Body:           &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "first"}},
+					},
+					{
+						SeverityNumber: logspb.SeverityNumber_SEVERITY_NUMBER_WARN2,
+						Body:           &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "second"}},
+					},
+				},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converter := &OtlpToEcsConverter{}
+	result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.otlp", Data: data})
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 log entries but got %d", len(result))
+	}
+	first := result[0].MetaLog.EcsLogEntry
+	if first.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", first.ProcessError.Reason)
+	}
+	if first.Message != "first" || first.Log.Level != model.LogLevel_info {
+		t.Errorf("Expected first/info but got %s/%s", first.Message, first.Log.Level)
+	}
+	if result[1].MetaLog.EcsLogEntry.Log.Level != model.LogLevel_warn {
+		t.Errorf("Expected level %s but got %s", model.LogLevel_warn, result[1].MetaLog.EcsLogEntry.Log.Level)
+	}
+	if first.Service.Name != "billing" || first.Service.Namespace != "finance" || first.Service.Version != "1.2.3" {
+		t.Errorf("Expected service billing/finance/1.2.3 but got %s/%s/%s", first.Service.Name, first.Service.Namespace, first.Service.Version)
+	}
+	if first.Host.Name != "worker-02" {
+		t.Errorf("Expected host worker-02 but got %s", first.Host.Name)
+	}
+	if first.Container == nil || first.Container.Id != "abc123" {
+		t.Errorf("Expected container abc123 but got %v", first.Container)
+	}
+	if !first.Timestamp.AsTime().Equal(time.Date(2023, 9, 29, 15, 6, 40, 0, time.UTC)) {
+		t.Errorf("Expected timestamp 2023-09-29T15:06:40Z but got %s", first.Timestamp.AsTime())
+	}
+}
+
+func TestOtlpJson(t *testing.T) {
+	converter := &OtlpToEcsConverter{}
+	msg := &nats.Msg{Subject: "ingress.logs.otlp", Data: []byte(testOtlpJson), Header: nats.Header{}}
+	msg.Header.Set("Content-Type", "application/json")
+	result := converter.ConvertToMetaLogs(msg)
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 log entry but got %d", len(result))
+	}
+	ecs := result[0].MetaLog.EcsLogEntry
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if ecs.Trace.Trace.Id != "5b8efff798038103d269b633813fc60c" {
+		t.Errorf("Expected trace id 5b8efff798038103d269b633813fc60c but got %s", ecs.Trace.Trace.Id)
+	}
+	if ecs.Trace.Span.Id != "eee19b7ec3c1b174" {
+		t.Errorf("Expected span id eee19b7ec3c1b174 but got %s", ecs.Trace.Span.Id)
+	}
+	if ecs.Log.Level != model.LogLevel_error {
+		t.Errorf("Expected level %s but got %s", model.LogLevel_error, ecs.Log.Level)
+	}
+	if ecs.Log.Logger != "com.example.Checkout" {
+		t.Errorf("Expected logger com.example.Checkout but got %s", ecs.Log.Logger)
+	}
+	if ecs.Error == nil || ecs.Error.Type != "java.lang.IllegalStateException" {
+		t.Errorf("Expected error type java.lang.IllegalStateException but got %v", ecs.Error)
+	}
+	if ecs.Labels["order.id"] != "42" {
+		t.Errorf("Expected label order.id=42 but got %s", ecs.Labels["order.id"])
+	}
+	if ecs.Service.Namespace != "shop" {
+		t.Errorf("Expected namespace shop but got %s", ecs.Service.Namespace)
+	}
+	if ecs.Container == nil || ecs.Container.Name != "checkout" || ecs.Container.Labels["k8s.pod.name"] != "checkout-7d9f" {
+		t.Errorf("Expected container checkout with pod label but got %v", ecs.Container)
+	}
+	if result[0].MetaLog.PatternKey != model.MetaLog_Otlp {
+		t.Errorf("Expected pattern key %s but got %s", model.MetaLog_Otlp, result[0].MetaLog.PatternKey)
+	}
+}
+
+func TestOtlpInvalidPayload(t *testing.T) {
+	converter := &OtlpToEcsConverter{}
+	result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.otlp", Data: []byte(`{"resourceLogs": 1}`)})
+	if len(result) != 1 || !result[0].MetaLog.EcsLogEntry.HasProcessError() {
+		t.Errorf("Expected one entry with process error but got %v", result)
+	}
+}
+
+func TestOtlpEmptyRequest(t *testing.T) {
+	converter := &OtlpToEcsConverter{}
+	result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.otlp", Data: []byte(`{}`)})
+	if len(result) != 1 || !result[0].Skip {
+		t.Errorf("Expected one skipped entry but got %v", result)
+	}
+}
+
+func TestOtlpJsonNumericTimestamp(t *testing.T) {
+	converter := &OtlpToEcsConverter{}
+	data := `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"timeUnixNano":1696000000123456789,"body":{"stringValue":"nanos"},"traceId":"5b8efff798038103d269b633813fc60c"}]}]}]}`
+	result := converter.ConvertToMetaLogs(&nats.Msg{Subject: "ingress.logs.otlp", Data: []byte(data)})
+	if len(result) != 1 || result[0].MetaLog.EcsLogEntry.HasProcessError() {
+		t.Fatalf("Expected one entry without process error but got %v", result)
+	}
+	if nanos := result[0].MetaLog.EcsLogEntry.Timestamp.AsTime().UnixNano(); nanos != 1696000000123456789 {
+		t.Errorf("Expected timestamp nanos 1696000000123456789 but got %d", nanos)
+	}
+}
@@ -0,0 +1,49 @@
+package ingress
+
+import (
+	"bytes"
+	"github.com/nats-io/nats.go"
+)
+
+// HeaderReserve the space of the nats message headers within the max payload
+const HeaderReserve = 1024
+
+// Publisher publishes the messages of an ingress listener to the ingress stream
+// For example the http endpoints, the syslog listener and the file tail
+// See bootstrap.IngressPublisher
+type Publisher interface {
+	Publish(msgs ...*nats.Msg) error
+	MaxPayload() (int64, error)
+}
+
+// NdjsonMessages batches the lines into ndjson messages that fit into the max payload
+// Returns the indexes of the lines that exceed the max payload on their own. They are not published
+func NdjsonMessages(subject string, lines [][]byte, maxPayload int64) ([]*nats.Msg, []int) {
+	limit := int(maxPayload - HeaderReserve)
+	var msgs []*nats.Msg
+	var tooLarge []int
+	var batch bytes.Buffer
+	flush := func() {
+		if batch.Len() == 0 {
+			return
+		}
+		msg := nats.NewMsg(subject)
+		msg.Header.Set(HeaderContentType, ContentTypeNdjson)
+		msg.Data = bytes.Clone(batch.Bytes())
+		msgs = append(msgs, msg)
+		batch.Reset()
+	}
+	for i, line := range lines {
+		if len(line)+1 > limit {
+			tooLarge = append(tooLarge, i)
+			continue
+		}
+		if batch.Len()+len(line)+1 > limit {
+			flush()
+		}
+		batch.Write(line)
+		batch.WriteByte('\n')
+	}
+	flush()
+	return msgs, tooLarge
+}
@@ -0,0 +1,111 @@
+package raw
+
+import (
+	"bytes"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// The nats headers of the metadata. For example
+// nats pub -H "Logu-Service:billing" -H "Logu-Pattern-Key:logfmt" ingress.logs.raw 'level=info msg="invoice sent"'
+const (
+	HeaderService    = "Logu-Service"
+	HeaderVersion    = "Logu-Version"
+	HeaderNamespace  = "Logu-Namespace"
+	HeaderStack      = "Logu-Stack"
+	HeaderEnv        = "Logu-Env"
+	HeaderOrg        = "Logu-Org"
+	HeaderHost       = "Logu-Host"
+	HeaderLevel      = "Logu-Level"
+	HeaderPatternKey = "Logu-Pattern-Key"
+	// HeaderTimestamp in RFC3339. The ingestion time if missing
+	HeaderTimestamp = "Logu-Timestamp"
+	// HeaderLabelPrefix every header Logu-Label-<name> is the label <name> in lower case
+	HeaderLabelPrefix = "Logu-Label-"
+)
+
+// RawToEcsConverter the body of the message is the log line. The metadata are carried by the nats headers
+// So small scripts and sidecars can publish without building a json envelope
+type RawToEcsConverter struct {
+}
+
+func (r *RawToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	line := bytes.TrimRight(msg.Data, "\r\n")
+	if len(line) == 0 {
+		// An empty line. Nothing to do
+		return ingress.IngressMsgContext{Skip: true, NatsMsg: msg}
+	}
+	headers := canonical(ingress.HeaderToMap(msg.Header))
+	patternKey := model.StringToLogPatternKey(headers[HeaderPatternKey])
+	if patternKey == model.MetaLog_Ecs {
+		// The line is a native ecs log. The headers fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, line)
+		headersToEcs(headers, ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, model.PatternKeyName(headers[HeaderPatternKey]), ingress.RawData(line), ts(headers, msg))
+	headersToEcs(headers, ctx.MetaLog.EcsLogEntry)
+	ctx.MetaLog.IngressLevel = ctx.MetaLog.EcsLogEntry.IsLogLevelSet()
+	return ctx
+}
+
+// headersToEcs fills the fields that are not set yet
+func headersToEcs(headers map[string]string, ecs *model.EcsLogEntry) {
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	for name, value := range headers {
+		if label, found := strings.CutPrefix(name, HeaderLabelPrefix); found && len(label) > 0 {
+			ecs.Labels[strings.ToLower(label)] = value
+		}
+	}
+	if value := headers[HeaderService]; len(value) > 0 && !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(value)
+	}
+	if value := headers[HeaderVersion]; len(value) > 0 && (ecs.Service == nil || len(ecs.Service.Version) == 0) {
+		if ecs.Service == nil {
+			ecs.Service = &model.Service{}
+		}
+		ecs.Service.Version = value
+	}
+	if value := headers[HeaderNamespace]; len(value) > 0 && !ecs.IsServiceNameSpaceSet() {
+		ecs.SetServiceNameSpace(value)
+	}
+	if value := headers[HeaderStack]; len(value) > 0 && !ecs.IsStackSet() {
+		ecs.SetStack(value)
+	}
+	if value := headers[HeaderEnv]; len(value) > 0 && !ecs.IsEnvironmentSet() {
+		ecs.SetEnvironment(value)
+	}
+	if value := headers[HeaderOrg]; len(value) > 0 && !ecs.IsOrgNameSet() {
+		ecs.SetOrgName(value)
+	}
+	if value := headers[HeaderHost]; len(value) > 0 && !ecs.IsHostNameSet() {
+		ecs.SetHostName(value)
+	}
+	if value := headers[HeaderLevel]; len(value) > 0 && !ecs.IsLogLevelSet() {
+		ecs.SetLogLevel(model.StringToLogLevel(value))
+	}
+}
+
+// canonical the header names in canonical form. Nats keeps the case of the names as published
+func canonical(headers map[string]string) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, value := range headers {
+		result[textproto.CanonicalMIMEHeaderKey(name)] = value
+	}
+	return result
+}
+
+func ts(headers map[string]string, msg *nats.Msg) *timestamppb.Timestamp {
+	if timestamp, err := time.Parse(time.RFC3339Nano, headers[HeaderTimestamp]); err == nil {
+		return timestamppb.New(timestamp)
+	}
+	return ingress.TimestampFromIngestion(msg)
+}
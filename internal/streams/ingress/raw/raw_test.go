@@ -0,0 +1,75 @@
+package raw
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+func rawMsg(data string, headers map[string]string) *nats.Msg {
+	msg := nats.NewMsg("ingress.logs.raw")
+	msg.Data = []byte(data)
+	for name, value := range headers {
+		msg.Header.Set(name, value)
+	}
+	return msg
+}
+
+func TestRawToEcs(t *testing.T) {
+	converter := RawToEcsConverter{}
+	result := converter.ConvertToMetaLog(rawMsg("level=warn msg=\"invoice delayed\"\n", map[string]string{
+		HeaderService:     "billing",
+		HeaderVersion:     "1.2.0",
+		HeaderEnv:         "prod",
+		HeaderOrg:         "acme",
+		"logu-host":       "edge-01",
+		HeaderPatternKey:  "logfmt",
+		HeaderTimestamp:   "2024-03-01T12:00:00.123Z",
+		"Logu-Label-Team": "payments",
+		"X-Other":         "ignored",
+	}))
+	metaLog := result.MetaLog
+	ecs := metaLog.EcsLogEntry
+	if metaLog.PatternKey != model.MetaLog_LogFmt || metaLog.RawMessage != `level=warn msg="invoice delayed"` {
+		t.Errorf("Expected the logfmt line but got %s %s", metaLog.PatternKey, metaLog.RawMessage)
+	}
+	if ecs.Service.Name != "billing" || ecs.Service.Version != "1.2.0" {
+		t.Errorf("Expected service billing 1.2.0 but got %v", ecs.Service)
+	}
+	if ecs.Environment.Name != "prod" || ecs.Organization.Name != "acme" || ecs.Host.Name != "edge-01" {
+		t.Errorf("Expected env prod, org acme and host edge-01 but got %v %v %v", ecs.Environment, ecs.Organization, ecs.Host)
+	}
+	if ecs.Timestamp.AsTime().UnixMilli() != 1709294400123 {
+		t.Errorf("Expected timestamp 1709294400123 but got %d", ecs.Timestamp.AsTime().UnixMilli())
+	}
+	if len(ecs.Labels) != 1 || ecs.Labels["team"] != "payments" {
+		t.Errorf("Expected the label team but got %v", ecs.Labels)
+	}
+	if ecs.Log.Level != model.LogLevel_not_set {
+		t.Errorf("Expected the level is left to the pattern parsing but got %s", ecs.Log.Level)
+	}
+}
+
+func TestRawToEcsNative(t *testing.T) {
+	converter := RawToEcsConverter{}
+	result := converter.ConvertToMetaLog(rawMsg(`{"@timestamp":"2024-03-01T12:00:00.000Z","message":"from ecs","service":{"name":"payment"}}`, map[string]string{
+		HeaderService:    "billing",
+		HeaderHost:       "edge-01",
+		HeaderLevel:      "error",
+		HeaderPatternKey: "ecs",
+	}))
+	ecs := result.MetaLog.EcsLogEntry
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if ecs.Message != "from ecs" || ecs.Service.Name != "payment" || ecs.Host.Name != "edge-01" || ecs.Log.Level != model.LogLevel_error {
+		t.Errorf("Expected the ecs log of payment on edge-01 with level error but got %s %v %v %s", ecs.Message, ecs.Service, ecs.Host, ecs.Log.Level)
+	}
+}
+
+func TestRawToEcsEmpty(t *testing.T) {
+	converter := RawToEcsConverter{}
+	if result := converter.ConvertToMetaLog(rawMsg("\n", nil)); !result.Skip {
+		t.Errorf("Expected an empty line is skipped")
+	}
+}
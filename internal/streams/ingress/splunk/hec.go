@@ -0,0 +1,224 @@
+package splunk
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/httpingress"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HEC status codes. See https://docs.splunk.com/Documentation/Splunk/latest/Data/TroubleshootHTTPEventCollector
+const (
+	codeSuccess       = 0
+	codeTokenRequired = 2
+	codeInvalidToken  = 4
+	codeNoData        = 5
+	codeInvalidFormat = 6
+	codeServerBusy    = 9
+	codeInternalError = 8
+	codeEventMissing  = 12
+	codeEventBlank    = 13
+	codeHealthy       = 17
+)
+
+var (
+	errEventMissing = errors.New("event field is required")
+	errEventBlank   = errors.New("event field cannot be blank")
+)
+
+// HecHandler serves the event and raw endpoints of the splunk http event collector
+// The events are published as ndjson to the splunk ingress subject and converted by SplunkToEcsConverter
+type HecHandler struct {
+	subject   string
+	tokens    []string
+	publisher ingress.Publisher
+	logger    zerolog.Logger
+}
+
+// hecError an error with the HEC status code
+type hecError struct {
+	status int
+	code   int
+	err    error
+}
+
+func NewHecHandler(subject string, tokens []string, publisher ingress.Publisher) *HecHandler {
+	return &HecHandler{
+		subject:   subject,
+		tokens:    tokens,
+		publisher: publisher,
+		logger:    config.Logger(),
+	}
+}
+
+// Mux the event, raw and health endpoints
+func (h *HecHandler) Mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, path := range []string{"/services/collector", "/services/collector/event", "/services/collector/event/1.0"} {
+		mux.HandleFunc("POST "+path, h.event)
+	}
+	for _, path := range []string{"/services/collector/raw", "/services/collector/raw/1.0"} {
+		mux.HandleFunc("POST "+path, h.raw)
+	}
+	mux.HandleFunc("GET /services/collector/health", func(w http.ResponseWriter, _ *http.Request) {
+		writeResponse(w, http.StatusOK, codeHealthy, "HEC is healthy")
+	})
+	return mux
+}
+
+// event the concatenated json events of the event endpoint
+func (h *HecHandler) event(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(body []byte) ([][]byte, *hecError) {
+		return parseEvents(body)
+	})
+}
+
+// raw every line of the raw endpoint is an event. The metadata are given by the query parameters
+func (h *HecHandler) raw(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(body []byte) ([][]byte, *hecError) {
+		query := r.URL.Query()
+		return rawEvents(body, Event{
+			Host:       query.Get("host"),
+			Source:     query.Get("source"),
+			SourceType: query.Get("sourcetype"),
+			Index:      query.Get("index"),
+		})
+	})
+}
+
+func (h *HecHandler) serve(w http.ResponseWriter, r *http.Request, parse func(body []byte) ([][]byte, *hecError)) {
+	if herr := h.authorize(r); herr != nil {
+		writeError(w, herr)
+		return
+	}
+	body, err := httpingress.ReadBody(w, r)
+	if err != nil {
+		writeError(w, &hecError{status: http.StatusBadRequest, code: codeInvalidFormat, err: err})
+		return
+	}
+	events, herr := parse(body)
+	if herr != nil {
+		writeError(w, herr)
+		return
+	}
+	maxPayload, err := h.publisher.MaxPayload()
+	if err != nil {
+		writeError(w, &hecError{status: http.StatusServiceUnavailable, code: codeServerBusy, err: err})
+		return
+	}
+	msgs, tooLarge := ingress.NdjsonMessages(h.subject, events, maxPayload)
+	if len(tooLarge) > 0 {
+		writeError(w, &hecError{
+			status: http.StatusRequestEntityTooLarge,
+			code:   codeInvalidFormat,
+			err:    fmt.Errorf("event %d exceeds the max payload of %d bytes", tooLarge[0], maxPayload),
+		})
+		return
+	}
+	err = h.publisher.Publish(msgs...)
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("Can't publish splunk hec request to %s", h.subject)
+		writeError(w, &hecError{status: http.StatusInternalServerError, code: codeInternalError, err: err})
+		return
+	}
+	writeResponse(w, http.StatusOK, codeSuccess, "Success")
+}
+
+// authorize the token of the Authorization header "Splunk <token>" or the password of the basic auth
+// All requests are rejected if no token is configured
+func (h *HecHandler) authorize(r *http.Request) *hecError {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Splunk ")
+	if !found {
+		token, found = basicAuthPassword(r)
+	}
+	if !found || len(token) == 0 {
+		return &hecError{status: http.StatusUnauthorized, code: codeTokenRequired, err: errors.New("Token is required")}
+	}
+	for _, t := range h.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return &hecError{status: http.StatusForbidden, code: codeInvalidToken, err: errors.New("Invalid token")}
+}
+
+func basicAuthPassword(r *http.Request) (string, bool) {
+	_, password, ok := r.BasicAuth()
+	return password, ok
+}
+
+// parseEvents the events are json objects one after the other. Not a json array
+func parseEvents(body []byte) ([][]byte, *hecError) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var events [][]byte
+	for {
+		event := Event{}
+		err := decoder.Decode(&event)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeInvalidFormat, err: errors.New("Invalid data format")}
+		}
+		if len(event.Event) == 0 || bytes.Equal(event.Event, []byte("null")) {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeEventMissing, err: errEventMissing}
+		}
+		if bytes.Equal(event.Event, []byte(`""`)) {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeEventBlank, err: errEventBlank}
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeInvalidFormat, err: err}
+		}
+		events = append(events, data)
+	}
+	if len(events) == 0 {
+		return nil, &hecError{status: http.StatusBadRequest, code: codeNoData, err: errors.New("No data")}
+	}
+	return events, nil
+}
+
+// rawEvents every non empty line is an event with the given metadata
+func rawEvents(body []byte, metadata Event) ([][]byte, *hecError) {
+	var events [][]byte
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		event := metadata
+		message, err := json.Marshal(ingress.RawData(line))
+		if err != nil {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeInvalidFormat, err: err}
+		}
+		event.Event = message
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, &hecError{status: http.StatusBadRequest, code: codeInvalidFormat, err: err}
+		}
+		events = append(events, data)
+	}
+	if len(events) == 0 {
+		return nil, &hecError{status: http.StatusBadRequest, code: codeNoData, err: errors.New("No data")}
+	}
+	return events, nil
+}
+
+func writeError(w http.ResponseWriter, herr *hecError) {
+	writeResponse(w, herr.status, herr.code, herr.err.Error())
+}
+
+func writeResponse(w http.ResponseWriter, status int, code int, text string) {
+	w.Header().Set(ingress.HeaderContentType, ingress.ContentTypeJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"text": text, "code": code})
+}
@@ -0,0 +1,134 @@
+package splunk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testHecResponse struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+const testEvents = `{"time":1426279439.123,"host":"worker-01","source":"billing","sourcetype":"logfmt","event":"level=info msg=first"}
+{"event":{"message":"second"},"sourcetype":"ecs","fields":{"region":"eu"}}`
+
+func hecRequest(handler http.Handler, path string, authorization string, body string) (*httptest.ResponseRecorder, testHecResponse) {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if len(authorization) > 0 {
+		request.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	response := testHecResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	return recorder, response
+}
+
+func TestHecEvent(t *testing.T) {
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	handler := NewHecHandler("ingress.logs.splunk", []string{"secret"}, publisher).Mux()
+	recorder, response := hecRequest(handler, "/services/collector/event", "Splunk secret", testEvents)
+	if recorder.Code != http.StatusOK || response.Code != codeSuccess {
+		t.Fatalf("Expected status %d but got %d %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if len(publisher.Msgs) != 1 {
+		t.Fatalf("Expected 1 published message but got %d", len(publisher.Msgs))
+	}
+	msg := publisher.Msgs[0]
+	if msg.Subject != "ingress.logs.splunk" || msg.Header.Get(ingress.HeaderContentType) != ingress.ContentTypeNdjson {
+		t.Errorf("Expected a ndjson message to ingress.logs.splunk but got %s %v", msg.Subject, msg.Header)
+	}
+	result := ingress.NewBatchConverter(&SplunkToEcsConverter{}).ConvertToMetaLogs(msg)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entries but got %d", len(result))
+	}
+	first := result[0].MetaLog.EcsLogEntry
+	if first.Host.Name != "worker-01" || first.Timestamp.AsTime().UnixMilli() != 1426279439123 {
+		t.Errorf("Expected host worker-01 and timestamp 1426279439123 but got %v %d", first.Host, first.Timestamp.AsTime().UnixMilli())
+	}
+	second := result[1].MetaLog.EcsLogEntry
+	if second.Message != "second" || second.Labels["region"] != "eu" {
+		t.Errorf("Expected the ecs event second with label region but got %s %v", second.Message, second.Labels)
+	}
+}
+
+func TestHecRaw(t *testing.T) {
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	handler := NewHecHandler("ingress.logs.splunk", []string{"secret"}, publisher).Mux()
+	request := httptest.NewRequest(http.MethodPost, "/services/collector/raw?host=worker-02&sourcetype=logfmt&index=main", strings.NewReader("level=info msg=first\r\n\nlevel=warn msg=second\n"))
+	request.SetBasicAuth("x", "secret")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d but got %d %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	result := ingress.NewBatchConverter(&SplunkToEcsConverter{}).ConvertToMetaLogs(publisher.Msgs[0])
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entries but got %d", len(result))
+	}
+	for i, expected := range []string{"level=info msg=first", "level=warn msg=second"} {
+		metaLog := result[i].MetaLog
+		if metaLog.RawMessage != expected || metaLog.EcsLogEntry.Host.Name != "worker-02" || metaLog.EcsLogEntry.Labels["index"] != "main" {
+			t.Errorf("Expected %s of worker-02 in main but got %s %v", expected, metaLog.RawMessage, metaLog.EcsLogEntry.Labels)
+		}
+	}
+}
+
+func TestHecErrors(t *testing.T) {
+	tests := []struct {
+		pos           int
+		path          string
+		authorization string
+		body          string
+		publishErr    error
+		maxPayload    int64
+		status        int
+		code          int
+	}{
+		{pos: 1, path: "/services/collector/event", body: testEvents, status: http.StatusUnauthorized, code: codeTokenRequired},
+		{pos: 2, path: "/services/collector/event", authorization: "Splunk wrong", body: testEvents, status: http.StatusForbidden, code: codeInvalidToken},
+		{pos: 3, path: "/services/collector/event", authorization: "Splunk secret", body: "", status: http.StatusBadRequest, code: codeNoData},
+		{pos: 4, path: "/services/collector/event", authorization: "Splunk secret", body: "no json", status: http.StatusBadRequest, code: codeInvalidFormat},
+		{pos: 5, path: "/services/collector/event", authorization: "Splunk secret", body: `{"host":"worker-01"}`, status: http.StatusBadRequest, code: codeEventMissing},
+		{pos: 6, path: "/services/collector/event", authorization: "Splunk secret", body: `{"event":""}`, status: http.StatusBadRequest, code: codeEventBlank},
+		{pos: 7, path: "/services/collector/raw", authorization: "Splunk secret", body: "\n\n", status: http.StatusBadRequest, code: codeNoData},
+		{pos: 8, path: "/services/collector/event", authorization: "Splunk secret", body: testEvents, publishErr: errors.New("nats down"), status: http.StatusInternalServerError, code: codeInternalError},
+		{pos: 9, path: "/services/collector/raw", authorization: "Splunk secret", body: strings.Repeat("x", 2048), maxPayload: 2048, status: http.StatusRequestEntityTooLarge, code: codeInvalidFormat},
+	}
+	for _, test := range tests {
+		publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024, Err: test.publishErr}
+		if test.maxPayload > 0 {
+			publisher.MaxPayloadBytes = test.maxPayload
+		}
+		handler := NewHecHandler("ingress.logs.splunk", []string{"secret"}, publisher).Mux()
+		recorder, response := hecRequest(handler, test.path, test.authorization, test.body)
+		if recorder.Code != test.status || response.Code != test.code {
+			t.Errorf("Pos %d: expected status %d with code %d but got %d %s", test.pos, test.status, test.code, recorder.Code, recorder.Body.String())
+		}
+		if test.status != http.StatusOK && len(publisher.Msgs) > 0 {
+			t.Errorf("Pos %d: expected nothing is published", test.pos)
+		}
+	}
+	// Without tokens all requests are rejected
+	handler := NewHecHandler("ingress.logs.splunk", nil, &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}).Mux()
+	if recorder, _ := hecRequest(handler, "/services/collector/event", "Splunk secret", testEvents); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d without tokens but got %d", http.StatusForbidden, recorder.Code)
+	}
+}
+
+func TestHecHealth(t *testing.T) {
+	handler := NewHecHandler("ingress.logs.splunk", nil, &testingress.Publisher{}).Mux()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/services/collector/health", nil))
+	if recorder.Code != http.StatusOK || !bytes.Contains(recorder.Body.Bytes(), []byte("HEC is healthy")) {
+		t.Errorf("Expected a healthy response but got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,150 @@
+package splunk
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/internal/streams/ingress/ecs"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"strings"
+	"time"
+)
+
+// Event a HEC event. See https://docs.splunk.com/Documentation/Splunk/latest/Data/FormateventsforHTTPEventCollector
+// The raw endpoint events are published in the same format
+type Event struct {
+	Time       json.Number     `json:"time,omitempty"`
+	Host       string          `json:"host,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	SourceType string          `json:"sourcetype,omitempty"`
+	Index      string          `json:"index,omitempty"`
+	Event      json.RawMessage `json:"event"`
+	Fields     map[string]any  `json:"fields,omitempty"`
+}
+
+// SplunkToEcsConverter converts one HEC event. The HEC endpoint publishes the events as ndjson batches
+type SplunkToEcsConverter struct {
+}
+
+// fieldPatternKey the indexed field that selects the extractor. Overrides the pattern key of the sourcetype
+const fieldPatternKey = "pattern_key"
+
+// sourceTypePatternKeys the extractors of well known sourcetypes
+// Other sourcetypes are looked up by model.StringToLogPatternKey
+var sourceTypePatternKeys = map[string]model.MetaLog_PatternKey{
+	"ecs":          model.MetaLog_Ecs,
+	"ecs_json":     model.MetaLog_Ecs,
+	"logfmt":       model.MetaLog_LogFmt,
+	"traefik":      model.MetaLog_Traefik,
+	"envoy":        model.MetaLog_Envoy,
+	"envoy_access": model.MetaLog_Envoy,
+	// The apache access logs of the splunk pretrained sourcetypes
+	"access_common":   model.MetaLog_Clf,
+	"access_combined": model.MetaLog_Clf,
+}
+
+func (r *SplunkToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	event := Event{}
+	err := json.Unmarshal(msg.Data, &event)
+	if err != nil {
+		// The parsing error is shipped to the output
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	patternKey := event.patternKey()
+	message := event.message()
+	if patternKey == model.MetaLog_Ecs {
+		// The event is a native ecs log. The HEC metadata fill only the fields that are missing there
+		ctx := ecs.ConvertEmbedded(msg, []byte(message))
+		event.toEcs(ctx.MetaLog.EcsLogEntry)
+		return ctx
+	}
+	ctx := ingress.NewMetaLogContext(msg, patternKey, event.patternKeyName(), message, event.ts(msg))
+	event.toEcs(ctx.MetaLog.EcsLogEntry)
+	ctx.MetaLog.IngressLevel = ctx.MetaLog.EcsLogEntry.IsLogLevelSet()
+	return ctx
+}
+
+// toEcs host is the ecs host, source the service name and sourcetype the service type if not set yet
+// index, source, sourcetype and the indexed fields are kept as labels
+func (e *Event) toEcs(ecs *model.EcsLogEntry) {
+	if ecs.Labels == nil {
+		ecs.Labels = make(map[string]string)
+	}
+	for name, value := range e.Fields {
+		if name != fieldPatternKey {
+			ecs.Labels[name] = fieldToString(value)
+		}
+	}
+	for name, value := range map[string]string{"index": e.Index, "source": e.Source, "sourcetype": e.SourceType} {
+		if len(value) > 0 {
+			ecs.Labels[name] = value
+		}
+	}
+	if len(e.Host) > 0 && !ecs.IsHostNameSet() {
+		ecs.SetHostName(e.Host)
+	}
+	if len(e.Source) > 0 && !ecs.IsServiceNameSet() {
+		ecs.SetSetServiceName(e.Source)
+	}
+	if len(e.SourceType) > 0 && !ecs.IsServiceTypeSet() {
+		ecs.SetServiceType(e.SourceType)
+	}
+	if level, ok := e.Fields["level"].(string); ok && !ecs.IsLogLevelSet() {
+		ecs.SetLogLevel(model.StringToLogLevel(level))
+	}
+}
+
+func (e *Event) patternKey() model.MetaLog_PatternKey {
+	if key, ok := e.Fields[fieldPatternKey].(string); ok {
+		return model.StringToLogPatternKey(key)
+	}
+	if key, ok := sourceTypePatternKeys[strings.ToLower(e.SourceType)]; ok {
+		return key
+	}
+	return model.StringToLogPatternKey(e.SourceType)
+}
+
+// patternKeyName the name of a user defined pattern requested by the pattern_key field. The name of the patternKey otherwise
+func (e *Event) patternKeyName() string {
+	if key, ok := e.Fields[fieldPatternKey].(string); ok {
+		return model.PatternKeyName(key)
+	}
+	return e.patternKey().String()
+}
+
+// message the event string or the event object as json
+func (e *Event) message() string {
+	var message string
+	if err := json.Unmarshal(e.Event, &message); err == nil {
+		return message
+	}
+	return string(e.Event)
+}
+
+// ts the time of the event in epoch seconds with optional milliseconds
+func (e *Event) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	seconds, err := e.Time.Float64()
+	if err != nil || seconds <= 0 {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	whole, fraction := math.Modf(seconds)
+	return timestamppb.New(time.Unix(int64(whole), int64(math.Round(fraction*1000))*int64(time.Millisecond)))
+}
+
+func fieldToString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			values = append(values, fieldToString(element))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
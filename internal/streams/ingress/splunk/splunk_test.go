@@ -0,0 +1,95 @@
+package splunk
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+)
+
+func TestSplunkToEcs(t *testing.T) {
+	tests := []struct {
+		pos        int
+		data       string
+		patternKey model.MetaLog_PatternKey
+		message    string
+		service    string
+		level      model.LogLevel
+	}{
+		{
+			pos:        1,
+			data:       `{"time":"1426279439.123","host":"worker-01","source":"billing","sourcetype":"logfmt","index":"main","event":"level=warn msg=\"invoice delayed\"","fields":{"region":"eu","status":200}}`,
+			patternKey: model.MetaLog_LogFmt,
+			message:    `level=warn msg="invoice delayed"`,
+			service:    "billing",
+			level:      model.LogLevel_not_set,
+		},
+		{
+			pos:        2,
+			data:       `{"time":1426279439,"host":"worker-01","source":"billing","sourcetype":"access_combined","event":"GET / 200","fields":{"pattern_key":"traefik","level":"error"}}`,
+			patternKey: model.MetaLog_Traefik,
+			message:    "GET / 200",
+			service:    "billing",
+			level:      model.LogLevel_error,
+		},
+		{
+			pos:        3,
+			data:       `{"time":1426279439,"host":"worker-01","source":"billing","sourcetype":"ecs_json","event":{"@timestamp":"2015-03-13T20:43:59.000Z","message":"from ecs","service":{"name":"payment"},"log":{"level":"info"}}}`,
+			patternKey: model.MetaLog_Ecs,
+			message:    "from ecs",
+			service:    "payment",
+			level:      model.LogLevel_info,
+		},
+	}
+	converter := SplunkToEcsConverter{}
+	for _, test := range tests {
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.splunk", Data: []byte(test.data)})
+		metaLog := result.MetaLog
+		ecs := metaLog.EcsLogEntry
+		if metaLog.PatternKey != test.patternKey {
+			t.Errorf("Pos %d: expected pattern key %s but got %s", test.pos, test.patternKey, metaLog.PatternKey)
+		}
+		message := metaLog.RawMessage
+		if test.patternKey == model.MetaLog_Ecs {
+			message = ecs.Message
+		}
+		if message != test.message {
+			t.Errorf("Pos %d: expected message %s but got %s", test.pos, test.message, message)
+		}
+		if ecs.Host.Name != "worker-01" {
+			t.Errorf("Pos %d: expected host worker-01 but got %v", test.pos, ecs.Host)
+		}
+		if ecs.Service.Name != test.service {
+			t.Errorf("Pos %d: expected service %s but got %s", test.pos, test.service, ecs.Service.Name)
+		}
+		if ecs.Log.Level != test.level {
+			t.Errorf("Pos %d: expected level %s but got %s", test.pos, test.level, ecs.Log.Level)
+		}
+		if test.patternKey != model.MetaLog_Ecs && ecs.Timestamp.AsTime().Unix() != 1426279439 {
+			t.Errorf("Pos %d: expected timestamp 1426279439 but got %d", test.pos, ecs.Timestamp.AsTime().Unix())
+		}
+		if ecs.Labels["source"] != "billing" || len(ecs.Labels["sourcetype"]) == 0 {
+			t.Errorf("Pos %d: expected the source and sourcetype labels but got %v", test.pos, ecs.Labels)
+		}
+		if _, ok := ecs.Labels[fieldPatternKey]; ok {
+			t.Errorf("Pos %d: expected no %s label", test.pos, fieldPatternKey)
+		}
+	}
+	first := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.splunk", Data: []byte(tests[0].data)}).MetaLog.EcsLogEntry
+	if first.Labels["index"] != "main" || first.Labels["region"] != "eu" || first.Labels["status"] != "200" {
+		t.Errorf("Expected the index and field labels but got %v", first.Labels)
+	}
+	if first.Timestamp.AsTime().UnixMilli() != 1426279439123 {
+		t.Errorf("Expected timestamp 1426279439123 but got %d", first.Timestamp.AsTime().UnixMilli())
+	}
+	if first.Service.Type != "logfmt" {
+		t.Errorf("Expected service type logfmt but got %s", first.Service.Type)
+	}
+}
+
+func TestSplunkToEcsInvalid(t *testing.T) {
+	converter := SplunkToEcsConverter{}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.splunk", Data: []byte("no json")})
+	if !result.MetaLog.EcsLogEntry.HasProcessError() {
+		t.Errorf("Expected a process error")
+	}
+}
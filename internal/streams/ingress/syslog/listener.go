@@ -0,0 +1,238 @@
+package syslog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/suikast42/logunifier/internal/config"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// maxFrameSize of a syslog message. A longer newline framed message is split
+	maxFrameSize = 64 << 10
+	// maxBatch the messages published at once
+	maxBatch = 512
+	// defaultIdleTimeout closes a tcp connection without a message for this duration
+	defaultIdleTimeout = 5 * time.Minute
+	// maxRetryDelay the max delay between the retries of a failed batch
+	maxRetryDelay = 30 * time.Second
+	// dropReport the interval of the warning about dropped udp datagrams
+	dropReport = time.Minute
+)
+
+// Listener receives syslog messages over tcp and udp and publishes them to the syslog ingress subject
+// Every message gets a Nats-Msg-Id. So a retried publish is not duplicated by the stream
+// The publishing blocks the tcp connections if the stream is not available. Udp has no back pressure. The datagrams
+// are dropped and counted then
+type Listener struct {
+	subject   string
+	publisher ingress.Publisher
+	msgs      chan *nats.Msg
+	dropped   atomic.Int64
+	// idleTimeout of a tcp connection
+	idleTimeout time.Duration
+	logger      zerolog.Logger
+}
+
+func NewListener(subject string, publisher ingress.Publisher) *Listener {
+	return &Listener{
+		subject:     subject,
+		publisher:   publisher,
+		msgs:        make(chan *nats.Msg, maxBatch),
+		idleTimeout: defaultIdleTimeout,
+		logger:      config.Logger(),
+	}
+}
+
+// Start listens on the tcp and udp ports. A port is disabled if 0
+// The tcp listener uses tls if a certificate is given
+func (l *Listener) Start(tcpPort int, udpPort int, certFile string, keyFile string) error {
+	if tcpPort > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", tcpPort))
+		if err != nil {
+			return err
+		}
+		if len(certFile) > 0 || len(keyFile) > 0 {
+			certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				_ = listener.Close()
+				return fmt.Errorf("can't load syslog tls certificate: %w", err)
+			}
+			listener = tls.NewListener(listener, &tls.Config{
+				Certificates: []tls.Certificate{certificate},
+				MinVersion:   tls.VersionTLS12,
+			})
+		}
+		l.logger.Info().Msgf("Start syslog tcp listener on port %d", tcpPort)
+		go l.ServeTcp(listener)
+	}
+	if udpPort > 0 {
+		conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", udpPort))
+		if err != nil {
+			return err
+		}
+		l.logger.Info().Msgf("Start syslog udp listener on port %d", udpPort)
+		go l.ServeUdp(conn)
+		go l.reportDropped()
+	}
+	go l.publish()
+	return nil
+}
+
+// ServeTcp accepts the connections until the listener is closed
+func (l *Listener) ServeTcp(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				l.logger.Error().Err(err).Msg("Can't accept syslog connection")
+			}
+			return
+		}
+		go l.serveConn(conn)
+	}
+}
+
+// serveConn reads the messages of a connection until it is closed or idle
+func (l *Listener) serveConn(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReaderSize(conn, maxFrameSize)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(l.idleTimeout))
+		frame, err := readFrame(reader)
+		if msg := l.toMsg(frame); msg != nil {
+			l.msgs <- msg
+		}
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				l.logger.Debug().Msgf("Close idle syslog connection of %s", conn.RemoteAddr())
+				return
+			}
+			if !errors.Is(err, io.EOF) {
+				l.logger.Error().Err(err).Msgf("Close syslog connection of %s", conn.RemoteAddr())
+			}
+			return
+		}
+	}
+}
+
+// ServeUdp every datagram is one message. A datagram is dropped if the publishing is behind
+func (l *Listener) ServeUdp(conn net.PacketConn) {
+	buffer := make([]byte, maxFrameSize)
+	for {
+		n, _, err := conn.ReadFrom(buffer)
+		if msg := l.toMsg(bytes.Clone(buffer[:n])); msg != nil {
+			select {
+			case l.msgs <- msg:
+			default:
+				l.dropped.Add(1)
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				l.logger.Error().Err(err).Msg("Can't read syslog datagram")
+			}
+			return
+		}
+	}
+}
+
+// toMsg the message of a frame. nil if the frame is empty
+func (l *Listener) toMsg(frame []byte) *nats.Msg {
+	frame = bytes.TrimRight(frame, "\r\n\x00")
+	if len(frame) == 0 {
+		return nil
+	}
+	msg := nats.NewMsg(l.subject)
+	msg.Header.Set(nats.MsgIdHdr, model.UUID())
+	msg.Data = frame
+	return msg
+}
+
+// reportDropped logs the count of the dropped udp datagrams
+func (l *Listener) reportDropped() {
+	for range time.Tick(dropReport) {
+		if dropped := l.dropped.Swap(0); dropped > 0 {
+			l.logger.Warn().Msgf("Dropped %d syslog datagrams in the last %s. The publishing to %s is behind", dropped, dropReport, l.subject)
+		}
+	}
+}
+
+// publish the received messages in batches. A failed batch is retried until the stream accepts it
+// The delay between the retries doubles up to maxRetryDelay
+func (l *Listener) publish() {
+	for msg := range l.msgs {
+		batch := []*nats.Msg{msg}
+	collect:
+		for len(batch) < maxBatch {
+			select {
+			case next := <-l.msgs:
+				batch = append(batch, next)
+			default:
+				break collect
+			}
+		}
+		for delay := time.Second; ; delay = min(2*delay, maxRetryDelay) {
+			err := l.publisher.Publish(batch...)
+			if err == nil {
+				break
+			}
+			l.logger.Error().Err(err).Msgf("Can't publish %d syslog messages to %s. Retry in %s", len(batch), l.subject, delay)
+			time.Sleep(delay)
+		}
+	}
+}
+
+// readFrame a message of a stream. See https://datatracker.ietf.org/doc/html/rfc6587#section-3.4
+// Octet counting if the frame starts with a digit: MSG-LEN SP SYSLOG-MSG
+// Newline framing otherwise. A syslog message starts with < and never with a digit
+func readFrame(reader *bufio.Reader) ([]byte, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if first[0] < '0' || first[0] > '9' {
+		line, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			err = nil
+		}
+		return bytes.Clone(line), err
+	}
+	// MSG-LEN has at most 5 digits for maxFrameSize
+	var length []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("octet counted frame without length: %w", err)
+		}
+		if b == ' ' {
+			break
+		}
+		length = append(length, b)
+		if len(length) > 5 {
+			return nil, fmt.Errorf("invalid octet count %q", length)
+		}
+	}
+	size, err := strconv.Atoi(string(length))
+	if err != nil || size > maxFrameSize {
+		return nil, fmt.Errorf("invalid octet count %q", length)
+	}
+	frame := make([]byte, size)
+	_, err = io.ReadFull(reader, frame)
+	if err != nil {
+		return nil, fmt.Errorf("octet counted frame of %d bytes is truncated: %w", size, err)
+	}
+	return frame, nil
+}
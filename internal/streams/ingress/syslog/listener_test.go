@@ -0,0 +1,197 @@
+package syslog
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress/testingress"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// frames the data of the published messages. Every message has a unique message id
+func frames(t *testing.T, publisher *testingress.Publisher, count int) []string {
+	var result []string
+	ids := make(map[string]bool)
+	for _, msg := range publisher.Wait(t, count) {
+		result = append(result, string(msg.Data))
+		ids[msg.Header.Get(nats.MsgIdHdr)] = true
+	}
+	if len(ids) != len(result) {
+		t.Errorf("Expected a unique %s per message but got %v", nats.MsgIdHdr, ids)
+	}
+	return result
+}
+
+func expectFrames(t *testing.T, expected []string, actual []string) {
+	if strings.Join(actual, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v but got %v", expected, actual)
+	}
+}
+
+const (
+	testFrame1 = "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed for lonvick"
+	testFrame2 = "<13>Feb  5 17:32:18 10.0.0.99 myapp: hello"
+)
+
+func TestReadFrame(t *testing.T) {
+	tests := []struct {
+		pos      int
+		data     string
+		expected []string
+		err      bool
+	}{
+		{pos: 1, data: testFrame1 + "\n" + testFrame2 + "\n", expected: []string{testFrame1 + "\n", testFrame2 + "\n"}},
+		{pos: 2, data: "11 <13>a\nb c d16 <13>second frame", expected: []string{"<13>a\nb c d", "<13>second frame"}},
+		{pos: 3, data: testFrame2, expected: []string{testFrame2}},
+		{pos: 4, data: "99 <13>short", err: true},
+		{pos: 5, data: "9999999 <13>", err: true},
+		{pos: 6, data: "12a <13>", err: true},
+	}
+	for _, test := range tests {
+		reader := bufio.NewReaderSize(strings.NewReader(test.data), maxFrameSize)
+		var frames []string
+		var err error
+		for {
+			var frame []byte
+			frame, err = readFrame(reader)
+			if len(frame) > 0 {
+				frames = append(frames, string(frame))
+			}
+			if err != nil {
+				break
+			}
+		}
+		if test.err {
+			if err == nil || errors.Is(err, io.EOF) {
+				t.Errorf("Pos %d: expected a framing error but got %v", test.pos, err)
+			}
+			continue
+		}
+		if strings.Join(frames, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("Pos %d: expected %q but got %q", test.pos, test.expected, frames)
+		}
+	}
+}
+
+func TestListenerTcp(t *testing.T) {
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024, Fail: 1}
+	listener := NewListener("ingress.logs.syslog", publisher)
+	go listener.publish()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go listener.ServeTcp(ln)
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = conn.Write([]byte(testFrame1 + "\r\n" + strconv.Itoa(len(testFrame2)) + " " + testFrame2))
+	_ = conn.Close()
+	// The first publish fails and is retried
+	expectFrames(t, []string{testFrame1, testFrame2}, frames(t, publisher, 2))
+	converted := (&SyslogToEcsConverter{}).ConvertToMetaLog(publisher.Wait(t, 2)[1])
+	if converted.MetaLog.EcsLogEntry.HasProcessError() || converted.MetaLog.RawMessage != "hello" {
+		t.Errorf("Expected the syslog message hello but got %s", converted.MetaLog.RawMessage)
+	}
+}
+
+func TestListenerTls(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.StartTLS()
+	defer server.Close()
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	listener := NewListener("ingress.logs.syslog", publisher)
+	go listener.publish()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go listener.ServeTcp(tls.NewListener(ln, &tls.Config{Certificates: server.TLS.Certificates}))
+	conn, err := tls.Dial("tcp", ln.Addr().String(), server.Client().Transport.(*http.Transport).TLSClientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = conn.Write([]byte(testFrame2 + "\n"))
+	_ = conn.Close()
+	expectFrames(t, []string{testFrame2}, frames(t, publisher, 1))
+}
+
+func TestListenerUdp(t *testing.T) {
+	publisher := &testingress.Publisher{MaxPayloadBytes: 1024 * 1024}
+	listener := NewListener("ingress.logs.syslog", publisher)
+	go listener.publish()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go listener.ServeUdp(conn)
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	_, _ = client.Write([]byte(testFrame1 + "\n"))
+	_, _ = client.Write([]byte(testFrame2))
+	expectFrames(t, []string{testFrame1, testFrame2}, frames(t, publisher, 2))
+}
+
+func TestListenerTcpIdle(t *testing.T) {
+	listener := NewListener("ingress.logs.syslog", &testingress.Publisher{MaxPayloadBytes: 1024 * 1024})
+	listener.idleTimeout = 50 * time.Millisecond
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go listener.ServeTcp(ln)
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// The listener closes the idle connection
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the idle connection is closed but got %v", err)
+	}
+}
+
+func TestListenerUdpDrop(t *testing.T) {
+	listener := NewListener("ingress.logs.syslog", &testingress.Publisher{MaxPayloadBytes: 1024 * 1024})
+	// Nothing is published. The channel is full after the first datagram
+	listener.msgs = make(chan *nats.Msg, 1)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go listener.ServeUdp(conn)
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	for i := 0; i < 3; i++ {
+		_, _ = client.Write([]byte(testFrame2))
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for listener.dropped.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if dropped := listener.dropped.Load(); dropped != 2 {
+		t.Errorf("Expected 2 dropped datagrams but got %d", dropped)
+	}
+}
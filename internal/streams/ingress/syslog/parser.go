@@ -0,0 +1,243 @@
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The relay of a rfc3164 message without PRI part have to use user.notice
+// See https://datatracker.ietf.org/doc/html/rfc3164#section-4.3.3
+const defaultPriority = 13
+
+const nilValue = "-"
+
+// syslogMessage the parts of a rfc5424 or rfc3164 message
+type syslogMessage struct {
+	priority int
+	// version is 0 for rfc3164 messages
+	version        int
+	timestamp      time.Time
+	hostname       string
+	appName        string
+	procId         string
+	msgId          string
+	structuredData []structuredDataElement
+	message        string
+}
+
+type structuredDataElement struct {
+	id     string
+	params map[string]string
+}
+
+func (m *syslogMessage) facility() int {
+	return m.priority / 8
+}
+
+func (m *syslogMessage) severity() int {
+	return m.priority % 8
+}
+
+// parse a rfc5424 or rfc3164 message. A rfc5424 message is identified by the version after the PRI part
+// now is used for the missing year of the rfc3164 timestamp
+func parse(line string, now time.Time) (*syslogMessage, error) {
+	line = strings.TrimRight(line, "\r\n\x00")
+	if len(line) == 0 {
+		return nil, errors.New("empty syslog message")
+	}
+	priority, rest, err := parsePriority(line)
+	if err != nil {
+		return nil, err
+	}
+	if isRfc5424(rest) {
+		return parseRfc5424(priority, rest)
+	}
+	return parseRfc3164(priority, rest, now), nil
+}
+
+func parsePriority(line string) (int, string, error) {
+	if line[0] != '<' {
+		return defaultPriority, line, nil
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return 0, "", fmt.Errorf("invalid PRI part in %q", line)
+	}
+	priority, err := strconv.Atoi(line[1:end])
+	if err != nil || priority < 0 || priority > 191 {
+		return 0, "", fmt.Errorf("invalid PRI value %q", line[1:end])
+	}
+	return priority, line[end+1:], nil
+}
+
+// isRfc5424 the PRI part is followed by a version number and a space. A rfc3164 timestamp starts with the month name
+func isRfc5424(rest string) bool {
+	space := strings.IndexByte(rest, ' ')
+	if space < 1 || space > 3 {
+		return false
+	}
+	_, err := strconv.Atoi(rest[:space])
+	return err == nil
+}
+
+// parseRfc5424 VERSION SP TIMESTAMP SP HOSTNAME SP APP-NAME SP PROCID SP MSGID SP STRUCTURED-DATA [SP MSG]
+func parseRfc5424(priority int, rest string) (*syslogMessage, error) {
+	parts := strings.SplitN(rest, " ", 7)
+	if len(parts) < 7 {
+		return nil, fmt.Errorf("incomplete rfc5424 header %q", rest)
+	}
+	version, _ := strconv.Atoi(parts[0])
+	result := &syslogMessage{
+		priority: priority,
+		version:  version,
+		hostname: nilToEmpty(parts[2]),
+		appName:  nilToEmpty(parts[3]),
+		procId:   nilToEmpty(parts[4]),
+		msgId:    nilToEmpty(parts[5]),
+	}
+	if parts[1] != nilValue {
+		ts, err := time.Parse(time.RFC3339Nano, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rfc5424 timestamp %q", parts[1])
+		}
+		result.timestamp = ts
+	}
+	structuredData, msg, err := parseStructuredData(parts[6])
+	if err != nil {
+		return nil, err
+	}
+	result.structuredData = structuredData
+	// The message may start with an utf-8 BOM
+	result.message = strings.TrimPrefix(msg, "\xEF\xBB\xBF")
+	return result, nil
+}
+
+// parseStructuredData parses the SD-ELEMENTs [id name="value" ...] and returns the remaining message
+func parseStructuredData(sd string) ([]structuredDataElement, string, error) {
+	if strings.HasPrefix(sd, nilValue) {
+		return nil, strings.TrimPrefix(strings.TrimPrefix(sd, nilValue), " "), nil
+	}
+	var result []structuredDataElement
+	pos := 0
+	for pos < len(sd) && sd[pos] == '[' {
+		pos++
+		idEnd := strings.IndexAny(sd[pos:], " ]")
+		if idEnd < 1 {
+			return nil, "", fmt.Errorf("invalid SD-ID in %q", sd)
+		}
+		element := structuredDataElement{
+			id:     sd[pos : pos+idEnd],
+			params: make(map[string]string),
+		}
+		pos += idEnd
+		for pos < len(sd) && sd[pos] == ' ' {
+			pos++
+			nameEnd := strings.Index(sd[pos:], "=\"")
+			if nameEnd < 1 {
+				return nil, "", fmt.Errorf("invalid SD-PARAM of %s in %q", element.id, sd)
+			}
+			name := sd[pos : pos+nameEnd]
+			pos += nameEnd + 2
+			var value strings.Builder
+			closed := false
+			for pos < len(sd) {
+				c := sd[pos]
+				if c == '\\' && pos+1 < len(sd) && strings.IndexByte(`"\]`, sd[pos+1]) >= 0 {
+					value.WriteByte(sd[pos+1])
+					pos += 2
+					continue
+				}
+				pos++
+				if c == '"' {
+					closed = true
+					break
+				}
+				value.WriteByte(c)
+			}
+			if !closed {
+				return nil, "", fmt.Errorf("unterminated SD-PARAM %s of %s", name, element.id)
+			}
+			element.params[name] = value.String()
+		}
+		if pos >= len(sd) || sd[pos] != ']' {
+			return nil, "", fmt.Errorf("unterminated SD-ELEMENT %s", element.id)
+		}
+		pos++
+		result = append(result, element)
+	}
+	if len(result) == 0 {
+		return nil, "", fmt.Errorf("invalid STRUCTURED-DATA %q", sd)
+	}
+	return result, strings.TrimPrefix(sd[pos:], " "), nil
+}
+
+// parseRfc3164 TIMESTAMP SP HOSTNAME SP TAG[PID]: MSG
+// rfc3164 is not strict. A message without a valid timestamp is taken as content without header
+// rfc3164Year the rfc3164 timestamp has no year. It is the latest year the timestamp is not in the future
+// A day of clock skew is tolerated. Feb 29 goes back to the last leap year
+func rfc3164Year(ts time.Time, now time.Time) time.Time {
+	latest := now.AddDate(0, 0, 1)
+	for year := latest.Year(); ; year-- {
+		candidate := time.Date(year, ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), now.Location())
+		// time.Date normalizes Feb 29 of a non leap year to Mar 1
+		if candidate.Month() == ts.Month() && !candidate.After(latest) {
+			return candidate
+		}
+	}
+}
+
+func parseRfc3164(priority int, rest string, now time.Time) *syslogMessage {
+	result := &syslogMessage{
+		priority: priority,
+	}
+	if len(rest) < len(time.Stamp) {
+		result.message = rest
+		return result
+	}
+	ts, err := time.ParseInLocation(time.Stamp, rest[:len(time.Stamp)], now.Location())
+	if err != nil {
+		result.message = rest
+		return result
+	}
+	result.timestamp = rfc3164Year(ts, now)
+	rest = strings.TrimPrefix(rest[len(time.Stamp):], " ")
+
+	hostEnd := strings.IndexByte(rest, ' ')
+	if hostEnd < 0 {
+		result.hostname = rest
+		return result
+	}
+	result.hostname = rest[:hostEnd]
+	rest = rest[hostEnd+1:]
+
+	// TAG ends with [ for a pid or : for the content. A space means there is no tag
+	tagEnd := strings.IndexAny(rest, "[: ")
+	if tagEnd < 1 || rest[tagEnd] == ' ' {
+		result.message = rest
+		return result
+	}
+	appName := rest[:tagEnd]
+	content := rest[tagEnd:]
+	if content[0] == '[' {
+		pidEnd := strings.IndexByte(content, ']')
+		if pidEnd < 0 {
+			result.message = rest
+			return result
+		}
+		result.procId = content[1:pidEnd]
+		content = content[pidEnd+1:]
+	}
+	result.appName = appName
+	result.message = strings.TrimPrefix(strings.TrimPrefix(content, ":"), " ")
+	return result
+}
+
+func nilToEmpty(value string) string {
+	if value == nilValue {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+package syslog
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"time"
+)
+
+// SyslogToEcsConverter converts raw rfc5424 or rfc3164 lines forwarded by vector
+// The message part is not parsed further (model.MetaLog_Nop)
+type SyslogToEcsConverter struct {
+}
+
+// See https://datatracker.ietf.org/doc/html/rfc5424#section-6.2.1
+var facilityNames = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var severityNames = []string{
+	"Emergency", "Alert", "Critical", "Error", "Warning", "Notice", "Informational", "Debug",
+}
+
+func (r *SyslogToEcsConverter) ConvertToMetaLog(msg *nats.Msg) ingress.IngressMsgContext {
+	parsed, err := parse(string(msg.Data), time.Now())
+	if err != nil {
+		// The parsing error is shipped to the output
+		return ingress.ProcessErrorContext(msg, model.MetaLog_Nop, err)
+	}
+	return ingress.IngressMsgContext{
+		NatsMsg: msg,
+		MetaLog: &model.MetaLog{
+			PatternKey:  model.MetaLog_Nop,
+			RawMessage:  parsed.message,
+			EcsLogEntry: parsed.toEcs(msg),
+			// The severity is the level
+			IngressLevel: true,
+		},
+	}
+}
+
+func (m *syslogMessage) toEcs(msg *nats.Msg) *model.EcsLogEntry {
+	level := m.toLogLevel()
+	ecs := &model.EcsLogEntry{
+		Labels:    m.labels(),
+		Timestamp: m.ts(msg),
+		Log: &model.Log{
+			// The pattern parsing keeps a level defined by the ingress
+			Level:      level,
+			LevelEmoji: model.LogLevelToEmoji(level),
+			PatternKey: model.MetaLog_Nop.String(),
+			Ingress:    msg.Subject,
+			Syslog: &model.Log_Syslog{
+				Facility: &model.Log_Syslog_Facility{
+					Code: strconv.Itoa(m.facility()),
+					Name: facilityNames[m.facility()],
+				},
+				Priority: strconv.Itoa(m.priority),
+				Severity: &model.Log_Syslog_Severity{
+					Code: strconv.Itoa(m.severity()),
+					Name: severityNames[m.severity()],
+				},
+				Appname:  m.appName,
+				Hostname: m.hostname,
+				Msgid:    m.msgId,
+				Procid:   m.procId,
+				Version:  int32(m.version),
+			},
+		},
+		Service: &model.Service{
+			Name: m.appName,
+			// The facility is in log.syslog.facility
+			Type: string(ingress.JobTypeDaemon),
+			Node: &model.Service_Node{
+				Name: m.hostname,
+			},
+		},
+		ProcessError: &model.ProcessError{
+			RawData: ingress.RawData(msg.Data),
+			Subject: msg.Subject,
+		},
+	}
+	if len(m.hostname) > 0 {
+		ecs.SetHostName(m.hostname)
+	}
+	if len(m.appName) > 0 || len(m.procId) > 0 {
+		ecs.Process = &model.Process{
+			Name: m.appName,
+		}
+		// PROCID is not always a pid. Keep it in log.syslog.procid then
+		if pid, err := strconv.ParseInt(m.procId, 10, 64); err == nil {
+			ecs.Process.Pid = pid
+		}
+	}
+	return ecs
+}
+
+// labels the params of the structured data elements with the key syslog_<SD-ID>_<PARAM-NAME>
+func (m *syslogMessage) labels() map[string]string {
+	labels := make(map[string]string)
+	for _, element := range m.structuredData {
+		if len(element.params) == 0 {
+			labels["syslog_"+element.id] = ""
+			continue
+		}
+		for name, value := range element.params {
+			labels["syslog_"+element.id+"_"+name] = value
+		}
+	}
+	return labels
+}
+
+func (m *syslogMessage) ts(msg *nats.Msg) *timestamppb.Timestamp {
+	if m.timestamp.IsZero() {
+		return ingress.TimestampFromIngestion(msg)
+	}
+	return timestamppb.New(m.timestamp)
+}
+
+func (m *syslogMessage) toLogLevel() model.LogLevel {
+	switch m.severity() {
+	case 0, 1, 2:
+		return model.LogLevel_fatal
+	case 3:
+		return model.LogLevel_error
+	case 4:
+		return model.LogLevel_warn
+	case 5, 6:
+		return model.LogLevel_info
+	default:
+		return model.LogLevel_debug
+	}
+}
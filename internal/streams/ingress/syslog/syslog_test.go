@@ -0,0 +1,218 @@
+package syslog
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/patterns"
+	"testing"
+	"time"
+)
+
+func init() {
+	_, err := patterns.Initialize()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRfc5424(t *testing.T) {
+	line := `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high \"x\" \]"] ` + "\xEF\xBB\xBF" + `An application event log entry...`
+	converter := &SyslogToEcsConverter{}
+	result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.syslog", Data: []byte(line)})
+	ecs := patterns.Instance().Parse(result.MetaLog)
+
+	if ecs.HasProcessError() {
+		t.Fatalf("Expected no process error but got %s", ecs.ProcessError.Reason)
+	}
+	if ecs.Message != "An application event log entry..." {
+		t.Errorf("Expected message 'An application event log entry...' but got '%s'", ecs.Message)
+	}
+	// local4.notice
+	if ecs.Log.Level != model.LogLevel_info {
+		t.Errorf("Expected level %s but got %s", model.LogLevel_info, ecs.Log.Level)
+	}
+	if ecs.Log.Syslog.Facility.Name != "local4" || ecs.Log.Syslog.Facility.Code != "20" {
+		t.Errorf("Expected facility local4(20) but got %s(%s)", ecs.Log.Syslog.Facility.Name, ecs.Log.Syslog.Facility.Code)
+	}
+	if ecs.Log.Syslog.Severity.Name != "Notice" || ecs.Log.Syslog.Severity.Code != "5" {
+		t.Errorf("Expected severity Notice(5) but got %s(%s)", ecs.Log.Syslog.Severity.Name, ecs.Log.Syslog.Severity.Code)
+	}
+	if ecs.Log.Syslog.Msgid != "ID47" {
+		t.Errorf("Expected msgid ID47 but got %s", ecs.Log.Syslog.Msgid)
+	}
+	if ecs.Service.Name != "evntslog" {
+		t.Errorf("Expected service name evntslog but got %s", ecs.Service.Name)
+	}
+	if ecs.Service.Type != string(ingress.JobTypeDaemon) {
+		t.Errorf("Expected service type %s but got %s", ingress.JobTypeDaemon, ecs.Service.Type)
+	}
+	if ecs.Process.Pid != 1234 || ecs.Process.Name != "evntslog" {
+		t.Errorf("Expected process evntslog(1234) but got %s(%d)", ecs.Process.Name, ecs.Process.Pid)
+	}
+	if ecs.Host.Name != "mymachine.example.com" {
+		t.Errorf("Expected host mymachine.example.com but got %s", ecs.Host.Name)
+	}
+	expectedTs := time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)
+	if !ecs.Timestamp.AsTime().Equal(expectedTs) {
+		t.Errorf("Expected timestamp %s but got %s", expectedTs, ecs.Timestamp.AsTime())
+	}
+	expectedLabels := map[string]string{
+		"syslog_exampleSDID@32473_iut":         "3",
+		"syslog_exampleSDID@32473_eventSource": "Application",
+		"syslog_exampleSDID@32473_eventID":     "1011",
+		"syslog_examplePriority@32473_class":   `high "x" ]`,
+	}
+	for k, v := range expectedLabels {
+		if ecs.Labels[k] != v {
+			t.Errorf("Expected label %s=%s but got %s", k, v, ecs.Labels[k])
+		}
+	}
+}
+
+func TestRfc5424NilValues(t *testing.T) {
+	parsed, err := parse("<34>1 - - - - - -", time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err)
+	}
+	if !parsed.timestamp.IsZero() || len(parsed.hostname) > 0 || len(parsed.appName) > 0 || len(parsed.message) > 0 {
+		t.Errorf("Expected empty fields but got %+v", parsed)
+	}
+	if parsed.facility() != 4 || parsed.severity() != 2 {
+		t.Errorf("Expected auth.crit but got %d.%d", parsed.facility(), parsed.severity())
+	}
+}
+
+func TestRfc3164(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		pos      int
+		line     string
+		ts       time.Time
+		hostname string
+		appName  string
+		procId   string
+		message  string
+	}{
+		{
+			pos:      1,
+			line:     "<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8",
+			ts:       time.Date(2023, 10, 11, 22, 14, 15, 0, time.UTC),
+			hostname: "mymachine",
+			appName:  "su",
+			message:  "'su root' failed for lonvick on /dev/pts/8",
+		},
+		{
+			pos:      2,
+			line:     "<13>Jan  1 09:59:00 fw01 sshd[4711]: Accepted publickey for root\n",
+			ts:       time.Date(2024, 1, 1, 9, 59, 0, 0, time.UTC),
+			hostname: "fw01",
+			appName:  "sshd",
+			procId:   "4711",
+			message:  "Accepted publickey for root",
+		},
+		{
+			pos:      3,
+			line:     "<13>Jan  1 09:59:00 fw01 link down on port 4",
+			ts:       time.Date(2024, 1, 1, 9, 59, 0, 0, time.UTC),
+			hostname: "fw01",
+			message:  "link down on port 4",
+		},
+		{
+			pos:     4,
+			line:    "no header at all",
+			message: "no header at all",
+		},
+	}
+	for _, test := range tests {
+		parsed, err := parse(test.line, now)
+		if err != nil {
+			t.Errorf("Pos %d: expected no error but got %s", test.pos, err)
+			continue
+		}
+		if !parsed.timestamp.Equal(test.ts) {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, parsed.timestamp)
+		}
+		if parsed.hostname != test.hostname {
+			t.Errorf("Pos %d: expected hostname %s but got %s", test.pos, test.hostname, parsed.hostname)
+		}
+		if parsed.appName != test.appName {
+			t.Errorf("Pos %d: expected app name %s but got %s", test.pos, test.appName, parsed.appName)
+		}
+		if parsed.procId != test.procId {
+			t.Errorf("Pos %d: expected procid %s but got %s", test.pos, test.procId, parsed.procId)
+		}
+		if parsed.message != test.message {
+			t.Errorf("Pos %d: expected message %s but got %s", test.pos, test.message, parsed.message)
+		}
+	}
+}
+
+func TestRfc3164Year(t *testing.T) {
+	tests := []struct {
+		pos  int
+		line string
+		now  time.Time
+		ts   time.Time
+	}{
+		{
+			// Turn of the year
+			pos:  1,
+			line: "<13>Dec 31 23:59:50 fw01 link down",
+			now:  time.Date(2025, 1, 1, 0, 0, 10, 0, time.UTC),
+			ts:   time.Date(2024, 12, 31, 23, 59, 50, 0, time.UTC),
+		},
+		{
+			// Clock skew
+			pos:  2,
+			line: "<13>Jan  1 00:00:10 fw01 link down",
+			now:  time.Date(2024, 12, 31, 23, 59, 50, 0, time.UTC),
+			ts:   time.Date(2025, 1, 1, 0, 0, 10, 0, time.UTC),
+		},
+		{
+			pos:  3,
+			line: "<13>Feb 29 23:59:50 fw01 link down",
+			now:  time.Date(2024, 3, 1, 0, 0, 10, 0, time.UTC),
+			ts:   time.Date(2024, 2, 29, 23, 59, 50, 0, time.UTC),
+		},
+		{
+			pos:  4,
+			line: "<13>Mar  1 00:00:10 fw01 link down",
+			now:  time.Date(2024, 3, 1, 0, 0, 20, 0, time.UTC),
+			ts:   time.Date(2024, 3, 1, 0, 0, 10, 0, time.UTC),
+		},
+		{
+			// Feb 29 in a non leap year
+			pos:  5,
+			line: "<13>Feb 29 23:59:50 fw01 link down",
+			now:  time.Date(2025, 3, 1, 0, 0, 10, 0, time.UTC),
+			ts:   time.Date(2024, 2, 29, 23, 59, 50, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		parsed, err := parse(test.line, test.now)
+		if err != nil {
+			t.Errorf("Pos %d: expected no error but got %s", test.pos, err)
+			continue
+		}
+		if !parsed.timestamp.Equal(test.ts) {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, parsed.timestamp)
+		}
+	}
+}
+
+func TestInvalidSyslog(t *testing.T) {
+	tests := []string{
+		"",
+		"<999>1 - - - - - -",
+		"<34>1 2003-10-11 host app - - -",
+		"<34>1 - host app - - [unterminated",
+	}
+	for i, line := range tests {
+		converter := &SyslogToEcsConverter{}
+		result := converter.ConvertToMetaLog(&nats.Msg{Subject: "ingress.logs.syslog", Data: []byte(line)})
+		if !result.MetaLog.EcsLogEntry.HasProcessError() {
+			t.Errorf("Pos %d: expected process error for %q but got none", i+1, line)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package testingress the test fakes of the ingress listeners
+package testingress
+
+import (
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/internal/streams/ingress"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ErrNatsDown the error of the Publisher.Fail publishes
+var ErrNatsDown = errors.New("nats down")
+
+var _ ingress.Publisher = (*Publisher)(nil)
+
+// Publisher records the published messages instead of publishing them
+// The listeners publish in their own go routines. Use Wait or lock Mtx to read Msgs then
+type Publisher struct {
+	Mtx sync.Mutex
+	// Msgs the published messages
+	Msgs []*nats.Msg
+	// Failed the messages of the failed publishes
+	Failed []*nats.Msg
+	// MaxPayloadBytes the max payload of the nats server
+	MaxPayloadBytes int64
+	// Err fails every publish
+	Err error
+	// Fail fails the next publishes with ErrNatsDown
+	Fail int
+}
+
+func (p *Publisher) Publish(msgs ...*nats.Msg) error {
+	p.Mtx.Lock()
+	defer p.Mtx.Unlock()
+	if p.Fail > 0 {
+		p.Fail--
+		p.Failed = append(p.Failed, msgs...)
+		return ErrNatsDown
+	}
+	if p.Err != nil {
+		p.Failed = append(p.Failed, msgs...)
+		return p.Err
+	}
+	p.Msgs = append(p.Msgs, msgs...)
+	return nil
+}
+
+func (p *Publisher) MaxPayload() (int64, error) {
+	return p.MaxPayloadBytes, nil
+}
+
+// Wait for the expected count of published messages
+func (p *Publisher) Wait(t *testing.T, count int) []*nats.Msg {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		p.Mtx.Lock()
+		msgs := append([]*nats.Msg(nil), p.Msgs...)
+		p.Mtx.Unlock()
+		if len(msgs) >= count {
+			return msgs
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d published messages but got %d", count, len(msgs))
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,12 +1,16 @@
 package ingress
 
 import (
+	"encoding/base64"
+	"fmt"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 	"github.com/suikast42/logunifier/pkg/model"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 type MetaLogConverter interface {
@@ -17,10 +21,111 @@ type MetaLogConverter interface {
 	ConvertToMetaLog(msg *nats.Msg) IngressMsgContext
 }
 
+// MultiMetaLogConverter a converter that yields several log entries out of one nats message
+// For example an OTLP export request. Other converters are wrapped by the BatchConverter
+type MultiMetaLogConverter interface {
+	MetaLogConverter
+	ConvertToMetaLogs(msg *nats.Msg) []IngressMsgContext
+}
+
 type IngressMsgContext struct {
 	Skip    bool
 	NatsMsg *nats.Msg
 	MetaLog *model.MetaLog
+	// ackGroup is set if NatsMsg is shared with other contexts
+	ackGroup *AckGroup
+	// index of this context in the ackGroup
+	index int
+}
+
+// Ack acks the nats message. If the message is shared then the ack is done after all contexts are done
+func (c IngressMsgContext) Ack() error {
+	if c.ackGroup == nil {
+		return c.NatsMsg.Ack()
+	}
+	return c.ackGroup.done(false)
+}
+
+// NakWithDelay nacks the nats message. If the message is shared then the nack is done after all contexts are done
+func (c IngressMsgContext) NakWithDelay(delay time.Duration) error {
+	if c.ackGroup == nil {
+		return c.NatsMsg.NakWithDelay(delay)
+	}
+	c.ackGroup.nakDelay(delay)
+	return c.ackGroup.done(true)
+}
+
+// MsgId a unique id of a context that shares its nats message with others. Empty otherwise
+// Used as Nats-Msg-Id for the egress, so a redelivery does not duplicate the entries that are already published
+func (c IngressMsgContext) MsgId() string {
+	if c.ackGroup == nil || len(c.ackGroup.id) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", c.ackGroup.id, c.index)
+}
+
+// AckGroup acks a nats message shared by several IngressMsgContext once.
+// The message is nacked if at least one context fails
+type AckGroup struct {
+	msg     *nats.Msg
+	id      string
+	mtx     sync.Mutex
+	pending int
+	failed  bool
+	delay   time.Duration
+}
+
+// Share links the contexts to one AckGroup of msg
+// The skipped contexts are dropped. The nats message is acked immediately if nothing is left
+func Share(msg *nats.Msg, contexts []IngressMsgContext) ([]IngressMsgContext, error) {
+	result := make([]IngressMsgContext, 0, len(contexts))
+	for _, ctx := range contexts {
+		if !ctx.Skip {
+			result = append(result, ctx)
+		}
+	}
+	if len(result) == 0 {
+		return result, msg.Ack()
+	}
+	for i := range result {
+		// A converter may create its own nats message. The ack goes to the received one
+		result[i].NatsMsg = msg
+	}
+	if len(result) == 1 {
+		return result, nil
+	}
+	group := &AckGroup{
+		msg:     msg,
+		pending: len(result),
+	}
+	if metadata, err := msg.Metadata(); err == nil {
+		group.id = fmt.Sprintf("%s-%d", metadata.Stream, metadata.Sequence.Stream)
+	}
+	for i := range result {
+		result[i].ackGroup = group
+		result[i].index = i
+	}
+	return result, nil
+}
+
+func (g *AckGroup) nakDelay(delay time.Duration) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.delay = delay
+}
+
+func (g *AckGroup) done(failed bool) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.failed = g.failed || failed
+	g.pending--
+	if g.pending != 0 {
+		return nil
+	}
+	if g.failed {
+		return g.msg.NakWithDelay(g.delay)
+	}
+	return g.msg.Ack()
 }
 
 // LabelStatic. Labels can be emmited during ingress phase
@@ -28,9 +133,10 @@ type IngressMsgContext struct {
 type JobType string
 
 const (
-	JobTypeNomadJob  JobType = "nomad_job"
-	JobTypeContainer JobType = "container"
-	JobTypeDaemon    JobType = "daemon"
+	JobTypeNomadJob      JobType = "nomad_job"
+	JobTypeContainer     JobType = "container"
+	JobTypeDaemon        JobType = "daemon"
+	JobTypeKubernetesPod JobType = "kubernetes_pod"
 )
 
 func TimestampFromIngestion(msg *nats.Msg) *timestamppb.Timestamp {
@@ -41,6 +147,68 @@ func TimestampFromIngestion(msg *nats.Msg) *timestamppb.Timestamp {
 	}
 	return timestamppb.New(metadata.Timestamp)
 }
+
+// ProcessErrorContext a log entry that ships the error of an unprocessable nats message to the output
+func ProcessErrorContext(msg *nats.Msg, patternKey model.MetaLog_PatternKey, err error) IngressMsgContext {
+	return IngressMsgContext{
+		NatsMsg: msg,
+		MetaLog: &model.MetaLog{
+			PatternKey: patternKey,
+			RawMessage: RawData(msg.Data),
+			EcsLogEntry: &model.EcsLogEntry{
+				Timestamp: TimestampFromIngestion(msg),
+				Log: &model.Log{
+					Level:      model.LogLevel_not_set,
+					LevelEmoji: model.LogLevelToEmoji(model.LogLevel_not_set),
+					PatternKey: patternKey.String(),
+					Ingress:    msg.Subject,
+				},
+				ProcessError: &model.ProcessError{
+					Reason:  err.Error(),
+					RawData: RawData(msg.Data),
+					Subject: msg.Subject,
+				},
+			},
+		},
+	}
+}
+
+// NewMetaLogContext the log entry of a converter that leaves the parsing of the message to the pattern factory
+// The level is not set. The converter fills the metadata of its ingress
+func NewMetaLogContext(msg *nats.Msg, patternKey model.MetaLog_PatternKey, patternKeyName string, message string, timestamp *timestamppb.Timestamp) IngressMsgContext {
+	return IngressMsgContext{
+		NatsMsg: msg,
+		MetaLog: &model.MetaLog{
+			PatternKey: patternKey,
+			RawMessage: message,
+			EcsLogEntry: &model.EcsLogEntry{
+				Labels:    make(map[string]string),
+				Timestamp: timestamp,
+				Log: &model.Log{
+					Level:      model.LogLevel_not_set,
+					LevelEmoji: model.LogLevelToEmoji(model.LogLevel_not_set),
+					PatternKey: patternKeyName,
+					Ingress:    msg.Subject,
+				},
+				Service: &model.Service{},
+				ProcessError: &model.ProcessError{
+					RawData: RawData(msg.Data),
+					Subject: msg.Subject,
+				},
+			},
+		},
+	}
+}
+
+// RawData the string representation of a payload for model.ProcessError
+// Binary payloads like compressed or protobuf data are base64 encoded. A proto string must be valid utf-8
+func RawData(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	return "base64:" + base64.StdEncoding.EncodeToString(data)
+}
+
 func HeaderToMap(header nats.Header) map[string]string {
 	m := make(map[string]string)
 	for k, v := range header {
@@ -2,6 +2,7 @@ package process
 
 import (
 	"context"
+	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
 	"github.com/suikast42/logunifier/internal/bootstrap"
 	"github.com/suikast42/logunifier/internal/config"
@@ -17,7 +18,8 @@ type LogProcessor struct {
 	logger         *zerolog.Logger
 	processChannel <-chan ingress.IngressMsgContext
 	ackTimeout     time.Duration
-	pushSubject    string
+	pushSubjects   []string
+	stages         []Stage
 	maxAxPendings  int
 	channelName    string
 }
@@ -26,7 +28,9 @@ var lock = &sync.Mutex{}
 
 //var instance *LogProcessor
 
-func Start(processChannel <-chan ingress.IngressMsgContext, channelName string, pushSubject string, maxAxPendings int) error {
+// Start a processor that parses the received ingress messages, runs the given stages on the result
+// and publishes the ecs log entry to every push subject
+func Start(processChannel <-chan ingress.IngressMsgContext, channelName string, pushSubjects []string, stages []Stage, maxAxPendings int) error {
 	lock.Lock()
 	defer lock.Unlock()
 	cfg, _ := config.Instance()
@@ -37,7 +41,8 @@ func Start(processChannel <-chan ingress.IngressMsgContext, channelName string,
 		logger:         &logger,
 		processChannel: processChannel,
 		ackTimeout:     time.Second * time.Duration(cfg.AckTimeoutS()),
-		pushSubject:    pushSubject,
+		pushSubjects:   pushSubjects,
+		stages:         stages,
 		maxAxPendings:  maxAxPendings,
 		channelName:    channelName,
 	}
@@ -85,49 +90,30 @@ func (eg *LogProcessor) startReceiving() {
 				return
 			}
 			ecsLog := patternFactory.Parse(receivedCtx.MetaLog)
-			ValidateAndFix(ecsLog, receivedCtx.NatsMsg)
+			for _, stage := range eg.stages {
+				stage(ecsLog, receivedCtx.NatsMsg)
+			}
 
 			marshal, err := ecsLog.ToJson()
 
 			if err != nil {
 				eg.logger.Error().Err(err).Msgf("Can't unmarshal outgoing message: %v", ecsLog)
-				err = receivedCtx.NatsMsg.Ack()
+				err = receivedCtx.Ack()
 				if err != nil {
 					eg.logger.Error().Err(err).Msg("Can't ack message")
 				}
 				continue
 			}
-			ack, sendErr := egressStream.PublishAsync(eg.pushSubject, marshal)
-			if sendErr != nil {
-				eg.logger.Error().Err(sendErr).Msg("Can't publish message")
-				ackErr := receivedCtx.NatsMsg.NakWithDelay(eg.ackTimeout)
-				if ackErr != nil {
-					eg.logger.Error().Err(ackErr).Msg("Can't nack message. Message lost")
-				}
-				continue
-			}
-			select {
-			case _ack := <-ack.Ok():
-				err = receivedCtx.NatsMsg.Ack()
+			if eg.publish(egressStream, marshal, receivedCtx.MsgId()) {
+				err = receivedCtx.Ack()
 				if err != nil {
 					eg.logger.Error().Err(err).Msg("Can't ack message")
 				}
-				if _ack.Duplicate {
-					eg.logger.Debug().Msg("Duplicate message ")
-				}
-
-			case err, _ := <-ack.Err():
-				eg.logger.Error().Err(err).Msgf("Can't to egress %s. Try to nack with a delay of %v", eg.pushSubject, eg.ackTimeout)
-				err = receivedCtx.NatsMsg.NakWithDelay(eg.ackTimeout)
-				if err != nil {
-					eg.logger.Error().Err(err).Msg("Can't nack message")
-				}
-			case <-time.After(eg.ackTimeout + 1*time.Second):
-				eg.logger.Error().Msgf("This should not happened. Timeout on send msg after  %v ", eg.ackTimeout+time.Second*1)
-				err = receivedCtx.NatsMsg.NakWithDelay(eg.ackTimeout)
-				if err != nil {
-					eg.logger.Error().Err(err).Msgf("Can't nack message. Message lost. [%s]", string(receivedCtx.NatsMsg.Data))
-				}
+				continue
+			}
+			err = receivedCtx.NakWithDelay(eg.ackTimeout)
+			if err != nil {
+				eg.logger.Error().Err(err).Msgf("Can't nack message. Message lost. [%s]", string(receivedCtx.NatsMsg.Data))
 			}
 		case <-time.After(eg.ackTimeout):
 			eg.logger.Warn().Msgf("Processor %s Nothing received after %v", eg.channelName, eg.ackTimeout)
@@ -136,3 +122,38 @@ func (eg *LogProcessor) startReceiving() {
 	}
 
 }
+
+// publish the marshalled ecs log to every push subject and wait for the acks of the egress stream
+// Returns false if at least one subject does not accept the message
+// The msgId is used for the deduplication of the egress stream if not empty
+func (eg *LogProcessor) publish(egressStream nats.JetStreamContext, marshal []byte, msgId string) bool {
+	acks := make([]nats.PubAckFuture, 0, len(eg.pushSubjects))
+	for _, subject := range eg.pushSubjects {
+		var opts []nats.PubOpt
+		if len(msgId) > 0 {
+			opts = append(opts, nats.MsgId(msgId+"@"+subject))
+		}
+		ack, sendErr := egressStream.PublishAsync(subject, marshal, opts...)
+		if sendErr != nil {
+			eg.logger.Error().Err(sendErr).Msgf("Can't publish message to %s", subject)
+			return false
+		}
+		acks = append(acks, ack)
+	}
+	published := true
+	for _, ack := range acks {
+		select {
+		case _ack := <-ack.Ok():
+			if _ack.Duplicate {
+				eg.logger.Debug().Msg("Duplicate message ")
+			}
+		case err := <-ack.Err():
+			eg.logger.Error().Err(err).Msgf("Can't to egress %s. Try to nack with a delay of %v", ack.Msg().Subject, eg.ackTimeout)
+			published = false
+		case <-time.After(eg.ackTimeout + 1*time.Second):
+			eg.logger.Error().Msgf("This should not happened. Timeout on send msg after  %v ", eg.ackTimeout+time.Second*1)
+			published = false
+		}
+	}
+	return published
+}
@@ -0,0 +1,133 @@
+package process
+
+import (
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stackTraceRunTimeout a stack trace that is logged line by line ends if its source logs nothing for this duration
+const stackTraceRunTimeout = 5 * time.Second
+
+// stackTraceRun the exception of a stack trace that is logged line by line
+type stackTraceRun struct {
+	err      *model.Error
+	lastSeen time.Time
+}
+
+// stackTraceDetector the stack trace stage of a pipeline
+type stackTraceDetector struct {
+	mtx sync.Mutex
+	// runs the open stack traces keyed by the source of the log
+	runs map[string]*stackTraceRun
+	// pruned the last time the timed out runs were removed
+	pruned time.Time
+}
+
+func newStackTraceDetector() *stackTraceDetector {
+	return &stackTraceDetector{
+		runs: make(map[string]*stackTraceRun),
+	}
+}
+
+// DetectStackTrace fills the ecs error with the stack trace found in the message
+// A stack trace logged as one multiline message is parsed completely. The lines of a stack trace that is logged line
+// by line share the error id, type and message of the exception header and carry their own frame
+func (d *stackTraceDetector) DetectStackTrace(ecs *model.EcsLogEntry) {
+	if ecs.HasExceptionStackStrace() {
+		// Already provided. For example by a native ecs log. Add the frames only
+		if len(ecs.Error.Frames) == 0 {
+			if trace, ok := utils.ParseStackTrace(ecs.Error.StackTrace); ok {
+				ecs.Error.Frames = trace.Frames
+				fillError(ecs.Error, trace.Type, trace.Message)
+			}
+		}
+		return
+	}
+	if trace, ok := utils.ParseStackTrace(ecs.Message); ok {
+		if ecs.Error == nil {
+			ecs.Error = &model.Error{}
+		}
+		ecs.Error.StackTrace = trace.Trace
+		ecs.Error.Frames = trace.Frames
+		fillError(ecs.Error, trace.Type, trace.Message)
+		return
+	}
+	d.detectStackTraceRun(ecs, time.Now())
+}
+
+// detectStackTraceRun an exception header opens a run of its source. The following continuation lines belong to it
+func (d *stackTraceDetector) detectStackTraceRun(ecs *model.EcsLogEntry, now time.Time) {
+	source := stackTraceSource(ecs)
+	line := strings.TrimRight(ecs.Message, "\r\n")
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.prune(now)
+	run, ok := d.runs[source]
+	// Only frames and causes continue the run. An indented line or a function call of another log doesn't
+	frame, isFrame := utils.ParseStackFrame(line)
+	if ok && now.Sub(run.lastSeen) <= stackTraceRunTimeout && (isFrame || strings.HasPrefix(line, "Caused by:")) {
+		run.lastSeen = now
+		ecs.Error = &model.Error{
+			Id:         run.err.Id,
+			Type:       run.err.Type,
+			Message:    run.err.Message,
+			StackTrace: line,
+		}
+		if isFrame {
+			ecs.Error.Frames = []*model.Error_Frame{frame}
+		}
+		return
+	}
+	delete(d.runs, source)
+	errorType, message, ok := utils.ParseExceptionHeader(line)
+	if !ok {
+		return
+	}
+	if ecs.Error == nil {
+		ecs.Error = &model.Error{}
+	}
+	if len(ecs.Error.Id) == 0 {
+		ecs.Error.Id = model.UUID()
+	}
+	fillError(ecs.Error, errorType, message)
+	d.runs[source] = &stackTraceRun{err: ecs.Error, lastSeen: now}
+}
+
+// prune removes the runs of the sources that logged nothing since the timeout. At most once per timeout
+func (d *stackTraceDetector) prune(now time.Time) {
+	if now.Sub(d.pruned) <= stackTraceRunTimeout {
+		return
+	}
+	d.pruned = now
+	for source, run := range d.runs {
+		if now.Sub(run.lastSeen) > stackTraceRunTimeout {
+			delete(d.runs, source)
+		}
+	}
+}
+
+// stackTraceSource the service, host and container of the log
+func stackTraceSource(ecs *model.EcsLogEntry) string {
+	var source strings.Builder
+	source.WriteString(ecs.Log.GetIngress())
+	source.WriteString("|")
+	source.WriteString(ecs.Service.GetName())
+	source.WriteString("|")
+	source.WriteString(ecs.Host.GetName())
+	source.WriteString("|")
+	source.WriteString(ecs.Container.GetId())
+	return source.String()
+}
+
+// fillError sets the type and the message if they are not set
+func fillError(err *model.Error, errorType string, message string) {
+	if len(err.Type) == 0 {
+		err.Type = errorType
+	}
+	if len(err.Message) == 0 {
+		err.Message = message
+	}
+}
@@ -0,0 +1,109 @@
+package process
+
+import (
+	"github.com/suikast42/logunifier/pkg/model"
+	"testing"
+	"time"
+)
+
+func TestDetectStackTraceRun(t *testing.T) {
+	entry := func(message string) *model.EcsLogEntry {
+		return &model.EcsLogEntry{
+			Message: message,
+			Log:     &model.Log{Ingress: "ingress.logs.test"},
+			Service: &model.Service{Name: "billing"},
+		}
+	}
+	detector := newStackTraceDetector()
+	now := time.Now()
+	header := entry("java.lang.IllegalStateException: invoice closed")
+	detector.detectStackTraceRun(header, now)
+	if header.Error == nil || header.Error.Type != "java.lang.IllegalStateException" || len(header.Error.Id) == 0 {
+		t.Fatalf("Expected the exception header but got %v", header.Error)
+	}
+	frame := entry("\tat com.acme.Invoice.create(Invoice.java:42)")
+	detector.detectStackTraceRun(frame, now.Add(time.Second))
+	if !frame.HasExceptionStackStrace() || frame.Error.Id != header.Error.Id || frame.Error.Message != "invoice closed" {
+		t.Fatalf("Expected the frame of the exception but got %v", frame.Error)
+	}
+	if len(frame.Error.Frames) != 1 || frame.Error.Frames[0].Line != "42" {
+		t.Errorf("Expected the parsed frame but got %v", frame.Error.Frames)
+	}
+	other := entry("\tat com.acme.Other.run(Other.java:1)")
+	other.Service.Name = "other"
+	detector.detectStackTraceRun(other, now.Add(time.Second))
+	if other.Error != nil {
+		t.Errorf("Expected no error for another service but got %v", other.Error)
+	}
+	late := entry("\tat com.acme.Invoice.create(Invoice.java:42)")
+	detector.detectStackTraceRun(late, now.Add(time.Second+stackTraceRunTimeout+time.Millisecond))
+	if late.Error != nil {
+		t.Errorf("Expected the run is timed out but got %v", late.Error)
+	}
+}
+
+func TestDetectStackTrace(t *testing.T) {
+	ecs := &model.EcsLogEntry{
+		Message: "Failed\njava.lang.IllegalStateException: invoice closed\n\tat com.acme.Invoice.create(Invoice.java:42)",
+		Log:     &model.Log{},
+	}
+	detector := newStackTraceDetector()
+	detector.DetectStackTrace(ecs)
+	if !ecs.HasExceptionStackStrace() || ecs.Error.Type != "java.lang.IllegalStateException" || len(ecs.Error.Frames) != 1 {
+		t.Errorf("Expected the stack trace but got %v", ecs.Error)
+	}
+	// The stack trace of a native ecs log gets the frames
+	native := &model.EcsLogEntry{
+		Error: &model.Error{Type: "Custom", StackTrace: ecs.Error.StackTrace},
+		Log:   &model.Log{},
+	}
+	detector.DetectStackTrace(native)
+	if native.Error.Type != "Custom" || native.Error.Message != "invoice closed" || len(native.Error.Frames) != 1 {
+		t.Errorf("Expected the frames of the native stack trace but got %v", native.Error)
+	}
+}
+
+func TestDetectStackTraceRunEndsOnNonFrames(t *testing.T) {
+	entry := func(message string) *model.EcsLogEntry {
+		return &model.EcsLogEntry{
+			Message: message,
+			Log:     &model.Log{Ingress: "ingress.logs.test"},
+			Service: &model.Service{Name: "shipping"},
+		}
+	}
+	detector := newStackTraceDetector()
+	now := time.Now()
+	header := entry("java.lang.IllegalStateException: parcel lost")
+	detector.detectStackTraceRun(header, now)
+	cause := entry("Caused by: java.io.IOException: timeout")
+	detector.detectStackTraceRun(cause, now)
+	if cause.Error == nil || cause.Error.Id != header.Error.Id {
+		t.Fatalf("Expected the cause continues the exception but got %v", cause.Error)
+	}
+	for _, message := range []string{"    indented text of another log", "print(x)"} {
+		line := entry(message)
+		detector.detectStackTraceRun(line, now)
+		if line.Error != nil {
+			t.Errorf("Expected no error for '%s' but got %v", message, line.Error)
+		}
+	}
+}
+
+func TestDetectStackTraceRunPrune(t *testing.T) {
+	detector := newStackTraceDetector()
+	now := time.Now()
+	detector.detectStackTraceRun(&model.EcsLogEntry{
+		Message: "java.lang.IllegalStateException: invoice closed",
+		Log:     &model.Log{Ingress: "ingress.logs.test"},
+		Service: &model.Service{Name: "billing"},
+	}, now)
+	// The log of another source removes the timed out run
+	detector.detectStackTraceRun(&model.EcsLogEntry{
+		Message: "started",
+		Log:     &model.Log{Ingress: "ingress.logs.test"},
+		Service: &model.Service{Name: "shipping"},
+	}, now.Add(2*stackTraceRunTimeout))
+	if len(detector.runs) != 0 {
+		t.Errorf("Expected the timed out run is removed but got %d runs", len(detector.runs))
+	}
+}
@@ -0,0 +1,55 @@
+package process
+
+import (
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/suikast42/logunifier/pkg/model"
+)
+
+// Stage a processing step that runs after the pattern parsing on the ecs log entry
+// before the entry is pushed to the egress subject(s)
+type Stage func(ecs *model.EcsLogEntry, msg *nats.Msg)
+
+const (
+	StageValidate   = "validate"
+	StageStackTrace = "stacktrace"
+)
+
+// stages create the stage of a pipeline. A stage with state, like the stack trace runs, is not shared by the pipelines
+var stages = map[string]func() Stage{
+	StageValidate: func() Stage {
+		return ValidateAndFix
+	},
+	StageStackTrace: func() Stage {
+		detector := newStackTraceDetector()
+		return func(ecs *model.EcsLogEntry, _ *nats.Msg) {
+			detector.DetectStackTrace(ecs)
+		}
+	},
+}
+
+// DefaultStages the stages used by a pipeline if no processors are declared
+// The stack trace detection must be declared explicitly. It runs before the validation that sets the marker emojis
+func DefaultStages() []string {
+	return []string{StageValidate}
+}
+
+// StagesFor resolve the stage names declared by a pipeline in the given order
+// Every call creates new stages
+func StagesFor(names []string) ([]Stage, error) {
+	result := make([]Stage, 0, len(names))
+	for _, name := range names {
+		newStage, ok := stages[name]
+		if !ok {
+			return nil, fmt.Errorf("processor %s is not registered", name)
+		}
+		result = append(result, newStage())
+	}
+	return result, nil
+}
+
+// IsStageRegistered reports if a stage with the given name exists
+func IsStageRegistered(name string) bool {
+	_, ok := stages[name]
+	return ok
+}
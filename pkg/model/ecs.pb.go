@@ -117,6 +117,11 @@ type EcsLogEntry struct {
 	Event           *Event            `protobuf:"bytes,17,opt,name=event,proto3" json:"event,omitempty"`
 	Environment     *Environment      `protobuf:"bytes,18,opt,name=environment,proto3" json:"environment,omitempty"`
 	ValidationError *ValidationError  `protobuf:"bytes,19,opt,name=validationError,proto3" json:"validationError,omitempty"`
+	Process         *Process          `protobuf:"bytes,20,opt,name=process,proto3" json:"process,omitempty"`
+	Http            *Http             `protobuf:"bytes,21,opt,name=http,proto3" json:"http,omitempty"`
+	Url             *Url              `protobuf:"bytes,22,opt,name=url,proto3" json:"url,omitempty"`
+	Source          *Source           `protobuf:"bytes,23,opt,name=source,proto3" json:"source,omitempty"`
+	UserAgent       *UserAgent        `protobuf:"bytes,24,opt,name=user_agent,proto3" json:"user_agent,omitempty"`
 }
 
 func (x *EcsLogEntry) Reset() {
@@ -284,6 +289,97 @@ func (x *EcsLogEntry) GetValidationError() *ValidationError {
 	return nil
 }
 
+func (x *EcsLogEntry) GetProcess() *Process {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+func (x *EcsLogEntry) GetHttp() *Http {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *EcsLogEntry) GetUrl() *Url {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *EcsLogEntry) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *EcsLogEntry) GetUserAgent() *UserAgent {
+	if x != nil {
+		return x.UserAgent
+	}
+	return nil
+}
+
+// The process that emits the log
+type Process struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid  int64  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Process) Reset() {
+	*x = Process{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Process) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Process) ProtoMessage() {}
+
+func (x *Process) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Process.ProtoReflect.Descriptor instead.
+func (*Process) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Process) GetPid() int64 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *Process) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type Environment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -295,7 +391,7 @@ type Environment struct {
 func (x *Environment) Reset() {
 	*x = Environment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[1]
+		mi := &file_pkg_model_ecs_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -308,7 +404,7 @@ func (x *Environment) String() string {
 func (*Environment) ProtoMessage() {}
 
 func (x *Environment) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[1]
+	mi := &file_pkg_model_ecs_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,7 +417,7 @@ func (x *Environment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Environment.ProtoReflect.Descriptor instead.
 func (*Environment) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{1}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Environment) GetName() string {
@@ -337,12 +433,21 @@ type Event struct {
 	unknownFields protoimpl.UnknownFields
 
 	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// What happened. For example the CloudEvents type
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Source of the event. For example the CloudEvents source
+	Provider string `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Duration of the event in nanoseconds. For example the duration of a request
+	Duration int64 `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Why the event happened. For example the response flags of an envoy access log
+	Reason string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[2]
+		mi := &file_pkg_model_ecs_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -355,7 +460,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[2]
+	mi := &file_pkg_model_ecs_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,7 +473,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{2}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Event) GetKind() string {
@@ -378,6 +483,41 @@ func (x *Event) GetKind() string {
 	return ""
 }
 
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Event) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Event) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type User struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -389,7 +529,7 @@ type User struct {
 func (x *User) Reset() {
 	*x = User{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[3]
+		mi := &file_pkg_model_ecs_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +542,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[3]
+	mi := &file_pkg_model_ecs_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +555,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{3}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *User) GetName() string {
@@ -443,7 +583,7 @@ type Container struct {
 func (x *Container) Reset() {
 	*x = Container{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[4]
+		mi := &file_pkg_model_ecs_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -456,7 +596,7 @@ func (x *Container) String() string {
 func (*Container) ProtoMessage() {}
 
 func (x *Container) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[4]
+	mi := &file_pkg_model_ecs_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,7 +609,7 @@ func (x *Container) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Container.ProtoReflect.Descriptor instead.
 func (*Container) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{4}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Container) GetId() string {
@@ -539,7 +679,7 @@ type Agent struct {
 func (x *Agent) Reset() {
 	*x = Agent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[5]
+		mi := &file_pkg_model_ecs_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -552,7 +692,7 @@ func (x *Agent) String() string {
 func (*Agent) ProtoMessage() {}
 
 func (x *Agent) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[5]
+	mi := &file_pkg_model_ecs_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +705,7 @@ func (x *Agent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Agent.ProtoReflect.Descriptor instead.
 func (*Agent) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{5}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Agent) GetBuild() *Agent_Build {
@@ -632,7 +772,7 @@ type Host struct {
 func (x *Host) Reset() {
 	*x = Host{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[6]
+		mi := &file_pkg_model_ecs_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -645,7 +785,7 @@ func (x *Host) String() string {
 func (*Host) ProtoMessage() {}
 
 func (x *Host) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[6]
+	mi := &file_pkg_model_ecs_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,7 +798,7 @@ func (x *Host) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Host.ProtoReflect.Descriptor instead.
 func (*Host) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Host) GetArchitecture() string {
@@ -752,7 +892,7 @@ type Tracing struct {
 func (x *Tracing) Reset() {
 	*x = Tracing{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[7]
+		mi := &file_pkg_model_ecs_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -765,7 +905,7 @@ func (x *Tracing) String() string {
 func (*Tracing) ProtoMessage() {}
 
 func (x *Tracing) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[7]
+	mi := &file_pkg_model_ecs_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -778,7 +918,7 @@ func (x *Tracing) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
 func (*Tracing) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Tracing) GetSpan() *Tracing_Span {
@@ -815,7 +955,7 @@ type Organization struct {
 func (x *Organization) Reset() {
 	*x = Organization{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[8]
+		mi := &file_pkg_model_ecs_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -828,7 +968,7 @@ func (x *Organization) String() string {
 func (*Organization) ProtoMessage() {}
 
 func (x *Organization) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[8]
+	mi := &file_pkg_model_ecs_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +981,7 @@ func (x *Organization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Organization.ProtoReflect.Descriptor instead.
 func (*Organization) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{8}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Organization) GetId() string {
@@ -858,6 +998,245 @@ func (x *Organization) GetName() string {
 	return ""
 }
 
+// The http request and response of an access log
+type Http struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request  *Http_Request  `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Response *Http_Response `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	Version  string         `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Http) Reset() {
+	*x = Http{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Http) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Http) ProtoMessage() {}
+
+func (x *Http) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Http.ProtoReflect.Descriptor instead.
+func (*Http) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Http) GetRequest() *Http_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Http) GetResponse() *Http_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *Http) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// The requested url of an access log
+type Url struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Original string `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	Path     string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Query    string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *Url) Reset() {
+	*x = Url{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Url) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Url) ProtoMessage() {}
+
+func (x *Url) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Url.ProtoReflect.Descriptor instead.
+func (*Url) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Url) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *Url) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Url) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// The client of an access log
+type Source struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Ip      string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Domain  string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Source) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Source) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Source) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type UserAgent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Original string `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+}
+
+func (x *UserAgent) Reset() {
+	*x = UserAgent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAgent) ProtoMessage() {}
+
+func (x *UserAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAgent.ProtoReflect.Descriptor instead.
+func (*UserAgent) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UserAgent) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
 // Service information
 type Service struct {
 	state         protoimpl.MessageState
@@ -872,7 +1251,7 @@ type Service struct {
 	Type        string        `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
 	Version     string        `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
 	Stack       string        `protobuf:"bytes,8,opt,name=stack,proto3" json:"stack,omitempty"`
-	// Nomad task group
+	//Nomad task group
 	Group     string `protobuf:"bytes,9,opt,name=group,proto3" json:"group,omitempty"`
 	Namespace string `protobuf:"bytes,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
@@ -880,7 +1259,7 @@ type Service struct {
 func (x *Service) Reset() {
 	*x = Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[9]
+		mi := &file_pkg_model_ecs_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -893,7 +1272,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[9]
+	mi := &file_pkg_model_ecs_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,7 +1285,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{9}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Service) GetEphemeralId() string {
@@ -985,17 +1364,18 @@ type Error struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code       string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Message    string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	StackTrace string `protobuf:"bytes,4,opt,name=stack_trace,proto3" json:"stack_trace,omitempty"`
-	Type       string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Code       string         `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Id         string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Message    string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	StackTrace string         `protobuf:"bytes,4,opt,name=stack_trace,proto3" json:"stack_trace,omitempty"`
+	Type       string         `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Frames     []*Error_Frame `protobuf:"bytes,6,rep,name=frames,proto3" json:"frames,omitempty"`
 }
 
 func (x *Error) Reset() {
 	*x = Error{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[10]
+		mi := &file_pkg_model_ecs_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1008,7 +1388,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[10]
+	mi := &file_pkg_model_ecs_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1021,7 +1401,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{10}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Error) GetCode() string {
@@ -1059,6 +1439,13 @@ func (x *Error) GetType() string {
 	return ""
 }
 
+func (x *Error) GetFrames() []*Error_Frame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
 // Meta information about the log
 type Log struct {
 	state         protoimpl.MessageState
@@ -1080,7 +1467,7 @@ type Log struct {
 func (x *Log) Reset() {
 	*x = Log{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[11]
+		mi := &file_pkg_model_ecs_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1093,7 +1480,7 @@ func (x *Log) String() string {
 func (*Log) ProtoMessage() {}
 
 func (x *Log) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[11]
+	mi := &file_pkg_model_ecs_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,7 +1493,7 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log.ProtoReflect.Descriptor instead.
 func (*Log) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Log) GetFile() *Log_File {
@@ -1195,7 +1582,7 @@ type ProcessError struct {
 func (x *ProcessError) Reset() {
 	*x = ProcessError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[12]
+		mi := &file_pkg_model_ecs_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1208,7 +1595,7 @@ func (x *ProcessError) String() string {
 func (*ProcessError) ProtoMessage() {}
 
 func (x *ProcessError) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[12]
+	mi := &file_pkg_model_ecs_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1221,7 +1608,7 @@ func (x *ProcessError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessError.ProtoReflect.Descriptor instead.
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{12}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ProcessError) GetReason() string {
@@ -1257,7 +1644,7 @@ type ValidationError struct {
 func (x *ValidationError) Reset() {
 	*x = ValidationError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[13]
+		mi := &file_pkg_model_ecs_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1270,7 +1657,7 @@ func (x *ValidationError) String() string {
 func (*ValidationError) ProtoMessage() {}
 
 func (x *ValidationError) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[13]
+	mi := &file_pkg_model_ecs_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1283,7 +1670,7 @@ func (x *ValidationError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
 func (*ValidationError) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{13}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ValidationError) GetErrors() string {
@@ -1305,7 +1692,7 @@ type Container_Image struct {
 func (x *Container_Image) Reset() {
 	*x = Container_Image{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[15]
+		mi := &file_pkg_model_ecs_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1318,7 +1705,7 @@ func (x *Container_Image) String() string {
 func (*Container_Image) ProtoMessage() {}
 
 func (x *Container_Image) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[15]
+	mi := &file_pkg_model_ecs_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1331,7 +1718,7 @@ func (x *Container_Image) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Container_Image.ProtoReflect.Descriptor instead.
 func (*Container_Image) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{4, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{5, 0}
 }
 
 func (x *Container_Image) GetName() string {
@@ -1359,7 +1746,7 @@ type Agent_Build struct {
 func (x *Agent_Build) Reset() {
 	*x = Agent_Build{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[17]
+		mi := &file_pkg_model_ecs_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1372,7 +1759,7 @@ func (x *Agent_Build) String() string {
 func (*Agent_Build) ProtoMessage() {}
 
 func (x *Agent_Build) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[17]
+	mi := &file_pkg_model_ecs_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1385,7 +1772,7 @@ func (x *Agent_Build) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Agent_Build.ProtoReflect.Descriptor instead.
 func (*Agent_Build) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{5, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *Agent_Build) GetOriginal() string {
@@ -1412,7 +1799,7 @@ type Host_Os struct {
 func (x *Host_Os) Reset() {
 	*x = Host_Os{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[18]
+		mi := &file_pkg_model_ecs_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1425,7 +1812,7 @@ func (x *Host_Os) String() string {
 func (*Host_Os) ProtoMessage() {}
 
 func (x *Host_Os) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[18]
+	mi := &file_pkg_model_ecs_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1438,7 +1825,7 @@ func (x *Host_Os) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Host_Os.ProtoReflect.Descriptor instead.
 func (*Host_Os) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *Host_Os) GetFamily() string {
@@ -1508,7 +1895,7 @@ type Host_User struct {
 func (x *Host_User) Reset() {
 	*x = Host_User{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[19]
+		mi := &file_pkg_model_ecs_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1521,7 +1908,7 @@ func (x *Host_User) String() string {
 func (*Host_User) ProtoMessage() {}
 
 func (x *Host_User) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[19]
+	mi := &file_pkg_model_ecs_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1534,7 +1921,7 @@ func (x *Host_User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Host_User.ProtoReflect.Descriptor instead.
 func (*Host_User) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6, 1}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *Host_User) GetDomain() string {
@@ -1606,7 +1993,7 @@ type Host_User_Group struct {
 func (x *Host_User_Group) Reset() {
 	*x = Host_User_Group{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[20]
+		mi := &file_pkg_model_ecs_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1619,7 +2006,7 @@ func (x *Host_User_Group) String() string {
 func (*Host_User_Group) ProtoMessage() {}
 
 func (x *Host_User_Group) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[20]
+	mi := &file_pkg_model_ecs_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1632,7 +2019,7 @@ func (x *Host_User_Group) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Host_User_Group.ProtoReflect.Descriptor instead.
 func (*Host_User_Group) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{6, 1, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 1, 0}
 }
 
 func (x *Host_User_Group) GetDomain() string {
@@ -1667,7 +2054,7 @@ type Tracing_Transaction struct {
 func (x *Tracing_Transaction) Reset() {
 	*x = Tracing_Transaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[21]
+		mi := &file_pkg_model_ecs_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1679,8 +2066,152 @@ func (x *Tracing_Transaction) String() string {
 
 func (*Tracing_Transaction) ProtoMessage() {}
 
-func (x *Tracing_Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[21]
+func (x *Tracing_Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracing_Transaction.ProtoReflect.Descriptor instead.
+func (*Tracing_Transaction) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *Tracing_Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Apm span
+type Tracing_Span struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Tracing_Span) Reset() {
+	*x = Tracing_Span{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tracing_Span) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracing_Span) ProtoMessage() {}
+
+func (x *Tracing_Span) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracing_Span.ProtoReflect.Descriptor instead.
+func (*Tracing_Span) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *Tracing_Span) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Tracing_Trace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Tracing_Trace) Reset() {
+	*x = Tracing_Trace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tracing_Trace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracing_Trace) ProtoMessage() {}
+
+func (x *Tracing_Trace) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracing_Trace.ProtoReflect.Descriptor instead.
+func (*Tracing_Trace) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{8, 2}
+}
+
+func (x *Tracing_Trace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Http_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method   string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Referrer string `protobuf:"bytes,2,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Bytes    int64  `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *Http_Request) Reset() {
+	*x = Http_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_model_ecs_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Http_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Http_Request) ProtoMessage() {}
+
+func (x *Http_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1691,44 +2222,58 @@ func (x *Tracing_Transaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tracing_Transaction.ProtoReflect.Descriptor instead.
-func (*Tracing_Transaction) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 0}
+// Deprecated: Use Http_Request.ProtoReflect.Descriptor instead.
+func (*Http_Request) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{10, 0}
 }
 
-func (x *Tracing_Transaction) GetId() string {
+func (x *Http_Request) GetMethod() string {
 	if x != nil {
-		return x.Id
+		return x.Method
 	}
 	return ""
 }
 
-// Apm span
-type Tracing_Span struct {
+func (x *Http_Request) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *Http_Request) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type Http_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StatusCode int64 `protobuf:"varint,1,opt,name=status_code,proto3" json:"status_code,omitempty"`
+	Bytes      int64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
 }
 
-func (x *Tracing_Span) Reset() {
-	*x = Tracing_Span{}
+func (x *Http_Response) Reset() {
+	*x = Http_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[22]
+		mi := &file_pkg_model_ecs_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Tracing_Span) String() string {
+func (x *Http_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tracing_Span) ProtoMessage() {}
+func (*Http_Response) ProtoMessage() {}
 
-func (x *Tracing_Span) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[22]
+func (x *Http_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1739,43 +2284,50 @@ func (x *Tracing_Span) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tracing_Span.ProtoReflect.Descriptor instead.
-func (*Tracing_Span) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 1}
+// Deprecated: Use Http_Response.ProtoReflect.Descriptor instead.
+func (*Http_Response) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{10, 1}
 }
 
-func (x *Tracing_Span) GetId() string {
+func (x *Http_Response) GetStatusCode() int64 {
 	if x != nil {
-		return x.Id
+		return x.StatusCode
 	}
-	return ""
+	return 0
 }
 
-type Tracing_Trace struct {
+func (x *Http_Response) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type Service_Node struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Tracing_Trace) Reset() {
-	*x = Tracing_Trace{}
+func (x *Service_Node) Reset() {
+	*x = Service_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[23]
+		mi := &file_pkg_model_ecs_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Tracing_Trace) String() string {
+func (x *Service_Node) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tracing_Trace) ProtoMessage() {}
+func (*Service_Node) ProtoMessage() {}
 
-func (x *Tracing_Trace) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[23]
+func (x *Service_Node) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1786,43 +2338,46 @@ func (x *Tracing_Trace) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tracing_Trace.ProtoReflect.Descriptor instead.
-func (*Tracing_Trace) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{7, 2}
+// Deprecated: Use Service_Node.ProtoReflect.Descriptor instead.
+func (*Service_Node) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{14, 0}
 }
 
-func (x *Tracing_Trace) GetId() string {
+func (x *Service_Node) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type Service_Node struct {
+// A parsed frame of the stack trace
+type Error_Frame struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	File     string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Line     string `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`
 }
 
-func (x *Service_Node) Reset() {
-	*x = Service_Node{}
+func (x *Error_Frame) Reset() {
+	*x = Error_Frame{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[24]
+		mi := &file_pkg_model_ecs_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Service_Node) String() string {
+func (x *Error_Frame) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Service_Node) ProtoMessage() {}
+func (*Error_Frame) ProtoMessage() {}
 
-func (x *Service_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[24]
+func (x *Error_Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_model_ecs_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1833,14 +2388,28 @@ func (x *Service_Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Service_Node.ProtoReflect.Descriptor instead.
-func (*Service_Node) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{9, 0}
+// Deprecated: Use Error_Frame.ProtoReflect.Descriptor instead.
+func (*Error_Frame) Descriptor() ([]byte, []int) {
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *Service_Node) GetName() string {
+func (x *Error_Frame) GetFunction() string {
 	if x != nil {
-		return x.Name
+		return x.Function
+	}
+	return ""
+}
+
+func (x *Error_Frame) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Error_Frame) GetLine() string {
+	if x != nil {
+		return x.Line
 	}
 	return ""
 }
@@ -1856,7 +2425,7 @@ type Log_File struct {
 func (x *Log_File) Reset() {
 	*x = Log_File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[25]
+		mi := &file_pkg_model_ecs_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1869,7 +2438,7 @@ func (x *Log_File) String() string {
 func (*Log_File) ProtoMessage() {}
 
 func (x *Log_File) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[25]
+	mi := &file_pkg_model_ecs_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1882,7 +2451,7 @@ func (x *Log_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_File.ProtoReflect.Descriptor instead.
 func (*Log_File) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 0}
 }
 
 func (x *Log_File) GetPath() string {
@@ -1904,7 +2473,7 @@ type Log_Origin struct {
 func (x *Log_Origin) Reset() {
 	*x = Log_Origin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[26]
+		mi := &file_pkg_model_ecs_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1917,7 +2486,7 @@ func (x *Log_Origin) String() string {
 func (*Log_Origin) ProtoMessage() {}
 
 func (x *Log_Origin) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[26]
+	mi := &file_pkg_model_ecs_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,7 +2499,7 @@ func (x *Log_Origin) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Origin.ProtoReflect.Descriptor instead.
 func (*Log_Origin) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 1}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 1}
 }
 
 func (x *Log_Origin) GetFile() *Log_Origin_File {
@@ -1955,12 +2524,17 @@ type Log_Syslog struct {
 	Facility *Log_Syslog_Facility `protobuf:"bytes,1,opt,name=facility,proto3" json:"facility,omitempty"`
 	Priority string               `protobuf:"bytes,2,opt,name=priority,proto3" json:"priority,omitempty"`
 	Severity *Log_Syslog_Severity `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Appname  string               `protobuf:"bytes,4,opt,name=appname,proto3" json:"appname,omitempty"`
+	Hostname string               `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Msgid    string               `protobuf:"bytes,6,opt,name=msgid,proto3" json:"msgid,omitempty"`
+	Procid   string               `protobuf:"bytes,7,opt,name=procid,proto3" json:"procid,omitempty"`
+	Version  int32                `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *Log_Syslog) Reset() {
 	*x = Log_Syslog{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[27]
+		mi := &file_pkg_model_ecs_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1973,7 +2547,7 @@ func (x *Log_Syslog) String() string {
 func (*Log_Syslog) ProtoMessage() {}
 
 func (x *Log_Syslog) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[27]
+	mi := &file_pkg_model_ecs_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1986,7 +2560,7 @@ func (x *Log_Syslog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Syslog.ProtoReflect.Descriptor instead.
 func (*Log_Syslog) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 2}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 2}
 }
 
 func (x *Log_Syslog) GetFacility() *Log_Syslog_Facility {
@@ -2010,6 +2584,41 @@ func (x *Log_Syslog) GetSeverity() *Log_Syslog_Severity {
 	return nil
 }
 
+func (x *Log_Syslog) GetAppname() string {
+	if x != nil {
+		return x.Appname
+	}
+	return ""
+}
+
+func (x *Log_Syslog) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Log_Syslog) GetMsgid() string {
+	if x != nil {
+		return x.Msgid
+	}
+	return ""
+}
+
+func (x *Log_Syslog) GetProcid() string {
+	if x != nil {
+		return x.Procid
+	}
+	return ""
+}
+
+func (x *Log_Syslog) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type Log_Origin_File struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2022,7 +2631,7 @@ type Log_Origin_File struct {
 func (x *Log_Origin_File) Reset() {
 	*x = Log_Origin_File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[28]
+		mi := &file_pkg_model_ecs_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2035,7 +2644,7 @@ func (x *Log_Origin_File) String() string {
 func (*Log_Origin_File) ProtoMessage() {}
 
 func (x *Log_Origin_File) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[28]
+	mi := &file_pkg_model_ecs_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2048,7 +2657,7 @@ func (x *Log_Origin_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Origin_File.ProtoReflect.Descriptor instead.
 func (*Log_Origin_File) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 1, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 1, 0}
 }
 
 func (x *Log_Origin_File) GetLine() string {
@@ -2077,7 +2686,7 @@ type Log_Syslog_Facility struct {
 func (x *Log_Syslog_Facility) Reset() {
 	*x = Log_Syslog_Facility{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[29]
+		mi := &file_pkg_model_ecs_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2090,7 +2699,7 @@ func (x *Log_Syslog_Facility) String() string {
 func (*Log_Syslog_Facility) ProtoMessage() {}
 
 func (x *Log_Syslog_Facility) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[29]
+	mi := &file_pkg_model_ecs_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2103,7 +2712,7 @@ func (x *Log_Syslog_Facility) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Syslog_Facility.ProtoReflect.Descriptor instead.
 func (*Log_Syslog_Facility) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 2, 0}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 2, 0}
 }
 
 func (x *Log_Syslog_Facility) GetCode() string {
@@ -2132,7 +2741,7 @@ type Log_Syslog_Severity struct {
 func (x *Log_Syslog_Severity) Reset() {
 	*x = Log_Syslog_Severity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_model_ecs_proto_msgTypes[30]
+		mi := &file_pkg_model_ecs_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2145,7 +2754,7 @@ func (x *Log_Syslog_Severity) String() string {
 func (*Log_Syslog_Severity) ProtoMessage() {}
 
 func (x *Log_Syslog_Severity) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_model_ecs_proto_msgTypes[30]
+	mi := &file_pkg_model_ecs_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2158,7 +2767,7 @@ func (x *Log_Syslog_Severity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Syslog_Severity.ProtoReflect.Descriptor instead.
 func (*Log_Syslog_Severity) Descriptor() ([]byte, []int) {
-	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{11, 2, 1}
+	return file_pkg_model_ecs_proto_rawDescGZIP(), []int{16, 2, 1}
 }
 
 func (x *Log_Syslog_Severity) GetCode() string {
@@ -2183,7 +2792,7 @@ var file_pkg_model_ecs_proto_rawDesc = []byte{
 	0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x65, 0x63, 0x73, 0x63, 0x6f, 0x6e, 0x73, 0x74,
 	0x61, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdb, 0x06, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9d, 0x08, 0x0a, 0x0b,
 	0x45, 0x63, 0x73, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x74,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
@@ -2233,211 +2842,280 @@ var file_pkg_model_ecs_proto_rawDesc = []byte{
 	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
 	0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x56, 0x61,
 	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x0f, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x39,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x28,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48,
+	0x74, 0x74, 0x70, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x1c, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x55,
+	0x72, 0x6c, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x30,
+	0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2f, 0x0a, 0x07, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x21, 0x0a, 0x0b,
+	0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x93, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x1a, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0xe5, 0x02, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x2c, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a,
+	0x2d, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x1a, 0x39,
 	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
 	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
 	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x21, 0x0a, 0x0b, 0x45, 0x6e, 0x76,
-	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x1b, 0x0a, 0x05,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x1a, 0x0a, 0x04, 0x55, 0x73, 0x65,
-	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xe5, 0x02, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67,
-	0x65, 0x12, 0x34, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72,
-	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x75,
-	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74,
-	0x61, 0x67, 0x73, 0x1a, 0x2d, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74,
-	0x61, 0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcc, 0x01,
-	0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72,
-	0x61, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x23, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xd5, 0x05, 0x0a,
-	0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65,
-	0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63,
-	0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10, 0x0a,
-	0x03, 0x6d, 0x61, 0x63, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x4f, 0x73, 0x52,
-	0x02, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12,
-	0x24, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x1a, 0xa6, 0x01, 0x0a, 0x02, 0x4f, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61,
-	0x6d, 0x69, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e,
-	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x93,
-	0x02, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c,
-	0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x1a,
-	0x43, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0xec, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67,
-	0x12, 0x27, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x53,
-	0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x2a, 0x0a, 0x05, 0x74, 0x72, 0x61,
-	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
-	0x2e, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x05,
-	0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x1a, 0x1d, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x1a, 0x16, 0x0a, 0x04, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x17, 0x0a, 0x05, 0x54, 0x72,
-	0x61, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x22, 0x32, 0x0a, 0x0c, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa4, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d,
-	0x65, 0x72, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x6e,
-	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04,
-	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x63,
-	0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x14,
-	0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x1a, 0x1a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x7b,
-	0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x74,
-	0x72, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x63,
-	0x6b, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xf5, 0x05, 0x0a, 0x03,
-	0x4c, 0x6f, 0x67, 0x12, 0x23, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65,
-	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
-	0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12,
-	0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c,
-	0x12, 0x29, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x53, 0x79, 0x73,
-	0x6c, 0x6f, 0x67, 0x52, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x6c,
-	0x65, 0x76, 0x65, 0x6c, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x69,
-	0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e,
-	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
-	0x4b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65,
-	0x72, 0x6e, 0x4b, 0x65, 0x79, 0x1a, 0x1a, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
-	0x68, 0x1a, 0x80, 0x01, 0x0a, 0x06, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x04,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x6e, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x2e, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0xfc, 0x01, 0x0a, 0x06, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12,
-	0x36, 0x0a, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x53, 0x79,
-	0x73, 0x6c, 0x6f, 0x67, 0x2e, 0x46, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x08, 0x66,
-	0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
-	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
-	0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f,
-	0x67, 0x2e, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
-	0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x32, 0x0a, 0x08, 0x46,
-	0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a,
-	0x32, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcc, 0x01, 0x0a, 0x05, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x69,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x1a, 0x23, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xd5, 0x05, 0x0a, 0x04, 0x48, 0x6f, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65,
+	0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x4f, 0x73, 0x52, 0x02, 0x6f, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x1a, 0xa6, 0x01, 0x0a, 0x02, 0x4f, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69,
+	0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x75, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x93, 0x02, 0x0a, 0x04, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x2c, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x1a, 0x43, 0x0a, 0x05, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0xec, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x04,
+	0x73, 0x70, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52,
+	0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x2a, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x1d, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x16,
+	0x0a, 0x04, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x17, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x32, 0x0a, 0x0c, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0x5a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72,
-	0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61,
-	0x77, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22,
-	0x29, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2a, 0x72, 0x0a, 0x08, 0x4c, 0x6f,
-	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x6e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x07, 0x6e, 0x6f, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x10, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x74, 0x72, 0x61,
-	0x63, 0x65, 0x10, 0x64, 0x12, 0x0a, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x10, 0xc8, 0x01,
-	0x12, 0x09, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x10, 0xac, 0x02, 0x12, 0x09, 0x0a, 0x04, 0x77,
-	0x61, 0x72, 0x6e, 0x10, 0x90, 0x03, 0x12, 0x0a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x10,
-	0xf4, 0x03, 0x12, 0x0a, 0x0a, 0x05, 0x66, 0x61, 0x74, 0x61, 0x6c, 0x10, 0xd8, 0x04, 0x42, 0x56,
-	0x0a, 0x25, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x73, 0x75, 0x69,
-	0x6b, 0x61, 0x73, 0x74, 0x34, 0x32, 0x2e, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x01, 0x50, 0x01, 0x5a, 0x29, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x75, 0x69, 0x6b, 0x61, 0x73, 0x74, 0x34,
-	0x32, 0x2f, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6d, 0x65, 0x22, 0x9a, 0x02, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x2d, 0x0a, 0x07,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x53, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x1a, 0x42, 0x0a, 0x08,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x22, 0x4b, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x4a, 0x0a,
+	0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x27, 0x0a, 0x09, 0x55, 0x73, 0x65,
+	0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x22, 0xa4, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x5f,
+	0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x1a, 0x0a,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xf4, 0x01, 0x0a, 0x05, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x72,
+	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x73, 0x1a, 0x4b, 0x0a, 0x05, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x22, 0xf3, 0x06, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x23, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c,
+	0x6f, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x25, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29,
+	0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f,
+	0x67, 0x2e, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x52, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x45, 0x6d, 0x6f, 0x6a, 0x69,
+	0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x4b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4b, 0x65, 0x79, 0x1a, 0x1a, 0x0a, 0x04, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x80, 0x01, 0x0a, 0x06, 0x4f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x12, 0x2a, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x2e, 0x0a, 0x04, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0xfa, 0x02, 0x0a, 0x06, 0x53, 0x79,
+	0x73, 0x6c, 0x6f, 0x67, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4c,
+	0x6f, 0x67, 0x2e, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x2e, 0x46, 0x61, 0x63, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x2e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x69, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x6f, 0x63, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x6f, 0x63, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x32,
+	0x0a, 0x08, 0x46, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x1a, 0x32, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x72, 0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x22, 0x29, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2a, 0x72, 0x0a,
+	0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0b, 0x0a, 0x07, 0x75, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x07, 0x6e, 0x6f, 0x74, 0x5f, 0x73, 0x65,
+	0x74, 0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x10, 0x64, 0x12, 0x0a, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x10, 0xc8, 0x01, 0x12, 0x09, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x10, 0xac, 0x02, 0x12, 0x09,
+	0x0a, 0x04, 0x77, 0x61, 0x72, 0x6e, 0x10, 0x90, 0x03, 0x12, 0x0a, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x10, 0xf4, 0x03, 0x12, 0x0a, 0x0a, 0x05, 0x66, 0x61, 0x74, 0x61, 0x6c, 0x10, 0xd8,
+	0x04, 0x42, 0x56, 0x0a, 0x25, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x73, 0x75, 0x69, 0x6b, 0x61, 0x73, 0x74, 0x34, 0x32, 0x2e, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x01, 0x50, 0x01, 0x5a, 0x29,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x75, 0x69, 0x6b, 0x61,
+	0x73, 0x74, 0x34, 0x32, 0x2f, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2453,83 +3131,99 @@ func file_pkg_model_ecs_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_model_ecs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_model_ecs_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_pkg_model_ecs_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_pkg_model_ecs_proto_goTypes = []any{
 	(LogLevel)(0),                 // 0: model.LogLevel
 	(*EcsLogEntry)(nil),           // 1: model.EcsLogEntry
-	(*Environment)(nil),           // 2: model.Environment
-	(*Event)(nil),                 // 3: model.Event
-	(*User)(nil),                  // 4: model.User
-	(*Container)(nil),             // 5: model.Container
-	(*Agent)(nil),                 // 6: model.Agent
-	(*Host)(nil),                  // 7: model.Host
-	(*Tracing)(nil),               // 8: model.Tracing
-	(*Organization)(nil),          // 9: model.Organization
-	(*Service)(nil),               // 10: model.Service
-	(*Error)(nil),                 // 11: model.Error
-	(*Log)(nil),                   // 12: model.Log
-	(*ProcessError)(nil),          // 13: model.ProcessError
-	(*ValidationError)(nil),       // 14: model.ValidationError
-	nil,                           // 15: model.EcsLogEntry.LabelsEntry
-	(*Container_Image)(nil),       // 16: model.Container.Image
-	nil,                           // 17: model.Container.LabelsEntry
-	(*Agent_Build)(nil),           // 18: model.Agent.Build
-	(*Host_Os)(nil),               // 19: model.Host.Os
-	(*Host_User)(nil),             // 20: model.Host.User
-	(*Host_User_Group)(nil),       // 21: model.Host.User.Group
-	(*Tracing_Transaction)(nil),   // 22: model.Tracing.Transaction
-	(*Tracing_Span)(nil),          // 23: model.Tracing.Span
-	(*Tracing_Trace)(nil),         // 24: model.Tracing.Trace
-	(*Service_Node)(nil),          // 25: model.Service.Node
-	(*Log_File)(nil),              // 26: model.Log.File
-	(*Log_Origin)(nil),            // 27: model.Log.Origin
-	(*Log_Syslog)(nil),            // 28: model.Log.Syslog
-	(*Log_Origin_File)(nil),       // 29: model.Log.Origin.File
-	(*Log_Syslog_Facility)(nil),   // 30: model.Log.Syslog.Facility
-	(*Log_Syslog_Severity)(nil),   // 31: model.Log.Syslog.Severity
-	(*timestamppb.Timestamp)(nil), // 32: google.protobuf.Timestamp
-	(*Constants_Ecs)(nil),         // 33: model.Constants.Ecs
+	(*Process)(nil),               // 2: model.Process
+	(*Environment)(nil),           // 3: model.Environment
+	(*Event)(nil),                 // 4: model.Event
+	(*User)(nil),                  // 5: model.User
+	(*Container)(nil),             // 6: model.Container
+	(*Agent)(nil),                 // 7: model.Agent
+	(*Host)(nil),                  // 8: model.Host
+	(*Tracing)(nil),               // 9: model.Tracing
+	(*Organization)(nil),          // 10: model.Organization
+	(*Http)(nil),                  // 11: model.Http
+	(*Url)(nil),                   // 12: model.Url
+	(*Source)(nil),                // 13: model.Source
+	(*UserAgent)(nil),             // 14: model.UserAgent
+	(*Service)(nil),               // 15: model.Service
+	(*Error)(nil),                 // 16: model.Error
+	(*Log)(nil),                   // 17: model.Log
+	(*ProcessError)(nil),          // 18: model.ProcessError
+	(*ValidationError)(nil),       // 19: model.ValidationError
+	nil,                           // 20: model.EcsLogEntry.LabelsEntry
+	(*Container_Image)(nil),       // 21: model.Container.Image
+	nil,                           // 22: model.Container.LabelsEntry
+	(*Agent_Build)(nil),           // 23: model.Agent.Build
+	(*Host_Os)(nil),               // 24: model.Host.Os
+	(*Host_User)(nil),             // 25: model.Host.User
+	(*Host_User_Group)(nil),       // 26: model.Host.User.Group
+	(*Tracing_Transaction)(nil),   // 27: model.Tracing.Transaction
+	(*Tracing_Span)(nil),          // 28: model.Tracing.Span
+	(*Tracing_Trace)(nil),         // 29: model.Tracing.Trace
+	(*Http_Request)(nil),          // 30: model.Http.Request
+	(*Http_Response)(nil),         // 31: model.Http.Response
+	(*Service_Node)(nil),          // 32: model.Service.Node
+	(*Error_Frame)(nil),           // 33: model.Error.Frame
+	(*Log_File)(nil),              // 34: model.Log.File
+	(*Log_Origin)(nil),            // 35: model.Log.Origin
+	(*Log_Syslog)(nil),            // 36: model.Log.Syslog
+	(*Log_Origin_File)(nil),       // 37: model.Log.Origin.File
+	(*Log_Syslog_Facility)(nil),   // 38: model.Log.Syslog.Facility
+	(*Log_Syslog_Severity)(nil),   // 39: model.Log.Syslog.Severity
+	(*timestamppb.Timestamp)(nil), // 40: google.protobuf.Timestamp
+	(*Constants_Ecs)(nil),         // 41: model.Constants.Ecs
 }
 var file_pkg_model_ecs_proto_depIdxs = []int32{
-	32, // 0: model.EcsLogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	15, // 1: model.EcsLogEntry.labels:type_name -> model.EcsLogEntry.LabelsEntry
-	33, // 2: model.EcsLogEntry.version:type_name -> model.Constants.Ecs
-	5,  // 3: model.EcsLogEntry.container:type_name -> model.Container
-	6,  // 4: model.EcsLogEntry.agent:type_name -> model.Agent
-	7,  // 5: model.EcsLogEntry.host:type_name -> model.Host
-	8,  // 6: model.EcsLogEntry.trace:type_name -> model.Tracing
-	9,  // 7: model.EcsLogEntry.organization:type_name -> model.Organization
-	10, // 8: model.EcsLogEntry.service:type_name -> model.Service
-	11, // 9: model.EcsLogEntry.error:type_name -> model.Error
-	12, // 10: model.EcsLogEntry.log:type_name -> model.Log
-	13, // 11: model.EcsLogEntry.processError:type_name -> model.ProcessError
-	4,  // 12: model.EcsLogEntry.user:type_name -> model.User
-	3,  // 13: model.EcsLogEntry.event:type_name -> model.Event
-	2,  // 14: model.EcsLogEntry.environment:type_name -> model.Environment
-	14, // 15: model.EcsLogEntry.validationError:type_name -> model.ValidationError
-	16, // 16: model.Container.image:type_name -> model.Container.Image
-	17, // 17: model.Container.labels:type_name -> model.Container.LabelsEntry
-	32, // 18: model.Container.createdAt:type_name -> google.protobuf.Timestamp
-	18, // 19: model.Agent.build:type_name -> model.Agent.Build
-	19, // 20: model.Host.os:type_name -> model.Host.Os
-	20, // 21: model.Host.user:type_name -> model.Host.User
-	23, // 22: model.Tracing.span:type_name -> model.Tracing.Span
-	24, // 23: model.Tracing.trace:type_name -> model.Tracing.Trace
-	22, // 24: model.Tracing.transaction:type_name -> model.Tracing.Transaction
-	25, // 25: model.Service.node:type_name -> model.Service.Node
-	26, // 26: model.Log.file:type_name -> model.Log.File
-	0,  // 27: model.Log.level:type_name -> model.LogLevel
-	27, // 28: model.Log.origin:type_name -> model.Log.Origin
-	28, // 29: model.Log.syslog:type_name -> model.Log.Syslog
-	21, // 30: model.Host.User.group:type_name -> model.Host.User.Group
-	29, // 31: model.Log.Origin.file:type_name -> model.Log.Origin.File
-	30, // 32: model.Log.Syslog.facility:type_name -> model.Log.Syslog.Facility
-	31, // 33: model.Log.Syslog.severity:type_name -> model.Log.Syslog.Severity
-	34, // [34:34] is the sub-list for method output_type
-	34, // [34:34] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
+	40, // 0: model.EcsLogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 1: model.EcsLogEntry.labels:type_name -> model.EcsLogEntry.LabelsEntry
+	41, // 2: model.EcsLogEntry.version:type_name -> model.Constants.Ecs
+	6,  // 3: model.EcsLogEntry.container:type_name -> model.Container
+	7,  // 4: model.EcsLogEntry.agent:type_name -> model.Agent
+	8,  // 5: model.EcsLogEntry.host:type_name -> model.Host
+	9,  // 6: model.EcsLogEntry.trace:type_name -> model.Tracing
+	10, // 7: model.EcsLogEntry.organization:type_name -> model.Organization
+	15, // 8: model.EcsLogEntry.service:type_name -> model.Service
+	16, // 9: model.EcsLogEntry.error:type_name -> model.Error
+	17, // 10: model.EcsLogEntry.log:type_name -> model.Log
+	18, // 11: model.EcsLogEntry.processError:type_name -> model.ProcessError
+	5,  // 12: model.EcsLogEntry.user:type_name -> model.User
+	4,  // 13: model.EcsLogEntry.event:type_name -> model.Event
+	3,  // 14: model.EcsLogEntry.environment:type_name -> model.Environment
+	19, // 15: model.EcsLogEntry.validationError:type_name -> model.ValidationError
+	2,  // 16: model.EcsLogEntry.process:type_name -> model.Process
+	11, // 17: model.EcsLogEntry.http:type_name -> model.Http
+	12, // 18: model.EcsLogEntry.url:type_name -> model.Url
+	13, // 19: model.EcsLogEntry.source:type_name -> model.Source
+	14, // 20: model.EcsLogEntry.user_agent:type_name -> model.UserAgent
+	21, // 21: model.Container.image:type_name -> model.Container.Image
+	22, // 22: model.Container.labels:type_name -> model.Container.LabelsEntry
+	40, // 23: model.Container.createdAt:type_name -> google.protobuf.Timestamp
+	23, // 24: model.Agent.build:type_name -> model.Agent.Build
+	24, // 25: model.Host.os:type_name -> model.Host.Os
+	25, // 26: model.Host.user:type_name -> model.Host.User
+	28, // 27: model.Tracing.span:type_name -> model.Tracing.Span
+	29, // 28: model.Tracing.trace:type_name -> model.Tracing.Trace
+	27, // 29: model.Tracing.transaction:type_name -> model.Tracing.Transaction
+	30, // 30: model.Http.request:type_name -> model.Http.Request
+	31, // 31: model.Http.response:type_name -> model.Http.Response
+	32, // 32: model.Service.node:type_name -> model.Service.Node
+	33, // 33: model.Error.frames:type_name -> model.Error.Frame
+	34, // 34: model.Log.file:type_name -> model.Log.File
+	0,  // 35: model.Log.level:type_name -> model.LogLevel
+	35, // 36: model.Log.origin:type_name -> model.Log.Origin
+	36, // 37: model.Log.syslog:type_name -> model.Log.Syslog
+	26, // 38: model.Host.User.group:type_name -> model.Host.User.Group
+	37, // 39: model.Log.Origin.file:type_name -> model.Log.Origin.File
+	38, // 40: model.Log.Syslog.facility:type_name -> model.Log.Syslog.Facility
+	39, // 41: model.Log.Syslog.severity:type_name -> model.Log.Syslog.Severity
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_pkg_model_ecs_proto_init() }
@@ -2552,7 +3246,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Environment); i {
+			switch v := v.(*Process); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2564,7 +3258,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*Event); i {
+			switch v := v.(*Environment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2576,7 +3270,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*User); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2588,7 +3282,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*Container); i {
+			switch v := v.(*User); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2600,7 +3294,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*Agent); i {
+			switch v := v.(*Container); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2612,7 +3306,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*Host); i {
+			switch v := v.(*Agent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2624,7 +3318,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*Tracing); i {
+			switch v := v.(*Host); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2636,7 +3330,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*Organization); i {
+			switch v := v.(*Tracing); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2648,7 +3342,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*Service); i {
+			switch v := v.(*Organization); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2660,7 +3354,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*Error); i {
+			switch v := v.(*Http); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2672,7 +3366,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*Log); i {
+			switch v := v.(*Url); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2684,7 +3378,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*ProcessError); i {
+			switch v := v.(*Source); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2696,7 +3390,19 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*ValidationError); i {
+			switch v := v.(*UserAgent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*Service); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2708,7 +3414,19 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*Container_Image); i {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*Log); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2720,7 +3438,7 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*Agent_Build); i {
+			switch v := v.(*ProcessError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2732,6 +3450,42 @@ func file_pkg_model_ecs_proto_init() {
 			}
 		}
 		file_pkg_model_ecs_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidationError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*Container_Image); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*Agent_Build); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[23].Exporter = func(v any, i int) any {
 			switch v := v.(*Host_Os); i {
 			case 0:
 				return &v.state
@@ -2743,7 +3497,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[19].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*Host_User); i {
 			case 0:
 				return &v.state
@@ -2755,7 +3509,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[20].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[25].Exporter = func(v any, i int) any {
 			switch v := v.(*Host_User_Group); i {
 			case 0:
 				return &v.state
@@ -2767,7 +3521,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[21].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[26].Exporter = func(v any, i int) any {
 			switch v := v.(*Tracing_Transaction); i {
 			case 0:
 				return &v.state
@@ -2779,7 +3533,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[22].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[27].Exporter = func(v any, i int) any {
 			switch v := v.(*Tracing_Span); i {
 			case 0:
 				return &v.state
@@ -2791,7 +3545,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[23].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[28].Exporter = func(v any, i int) any {
 			switch v := v.(*Tracing_Trace); i {
 			case 0:
 				return &v.state
@@ -2803,7 +3557,31 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[24].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*Http_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*Http_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[31].Exporter = func(v any, i int) any {
 			switch v := v.(*Service_Node); i {
 			case 0:
 				return &v.state
@@ -2815,7 +3593,19 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[25].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*Error_Frame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_model_ecs_proto_msgTypes[33].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_File); i {
 			case 0:
 				return &v.state
@@ -2827,7 +3617,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[26].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[34].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Origin); i {
 			case 0:
 				return &v.state
@@ -2839,7 +3629,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[27].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[35].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Syslog); i {
 			case 0:
 				return &v.state
@@ -2851,7 +3641,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[28].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[36].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Origin_File); i {
 			case 0:
 				return &v.state
@@ -2863,7 +3653,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[29].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[37].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Syslog_Facility); i {
 			case 0:
 				return &v.state
@@ -2875,7 +3665,7 @@ func file_pkg_model_ecs_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_model_ecs_proto_msgTypes[30].Exporter = func(v any, i int) any {
+		file_pkg_model_ecs_proto_msgTypes[38].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Syslog_Severity); i {
 			case 0:
 				return &v.state
@@ -2894,7 +3684,7 @@ func file_pkg_model_ecs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_model_ecs_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   31,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -37,19 +37,31 @@ const (
 	MetaLog_Clf MetaLog_PatternKey = 6
 	// Pattern of treafik logs
 	MetaLog_Traefik MetaLog_PatternKey = 7
+	// OpenTelemetry log records. Already structured like Ecs
+	MetaLog_Otlp MetaLog_PatternKey = 8
+	// Structured json of a logging library. For example zap, logrus, pino, bunyan, Serilog or slog
+	MetaLog_Json MetaLog_PatternKey = 9
+	// Default console layout of Spring Boot, Logback and Log4j2
+	MetaLog_SpringBoot MetaLog_PatternKey = 10
+	// Text and json logs of the HashiCorp hclog library. For example the logs of Nomad, Consul and Vault
+	MetaLog_Hclog MetaLog_PatternKey = 11
 )
 
 // Enum value maps for MetaLog_PatternKey.
 var (
 	MetaLog_PatternKey_name = map[int32]string{
-		0: "Unknown",
-		1: "Nop",
-		2: "LogFmt",
-		3: "Ecs",
-		4: "TsLevelMsg",
-		5: "Envoy",
-		6: "Clf",
-		7: "Traefik",
+		0:  "Unknown",
+		1:  "Nop",
+		2:  "LogFmt",
+		3:  "Ecs",
+		4:  "TsLevelMsg",
+		5:  "Envoy",
+		6:  "Clf",
+		7:  "Traefik",
+		8:  "Otlp",
+		9:  "Json",
+		10: "SpringBoot",
+		11: "Hclog",
 	}
 	MetaLog_PatternKey_value = map[string]int32{
 		"Unknown":    0,
@@ -60,6 +72,10 @@ var (
 		"Envoy":      5,
 		"Clf":        6,
 		"Traefik":    7,
+		"Otlp":       8,
+		"Json":       9,
+		"SpringBoot": 10,
+		"Hclog":      11,
 	}
 )
 
@@ -103,6 +119,9 @@ type MetaLog struct {
 	// The log message
 	RawMessage  string       `protobuf:"bytes,2,opt,name=rawMessage,proto3" json:"rawMessage,omitempty"`
 	EcsLogEntry *EcsLogEntry `protobuf:"bytes,3,opt,name=ecsLogEntry,proto3" json:"ecsLogEntry,omitempty"`
+	// The ingress defines the log level. For example the syslog severity
+	// A pattern that finds no level keeps it. Otherwise the level is unknown
+	IngressLevel bool `protobuf:"varint,4,opt,name=ingressLevel,proto3" json:"ingressLevel,omitempty"`
 }
 
 func (x *MetaLog) Reset() {
@@ -158,13 +177,20 @@ func (x *MetaLog) GetEcsLogEntry() *EcsLogEntry {
 	return nil
 }
 
+func (x *MetaLog) GetIngressLevel() bool {
+	if x != nil {
+		return x.IngressLevel
+	}
+	return false
+}
+
 var File_pkg_model_metalog_proto protoreflect.FileDescriptor
 
 var file_pkg_model_metalog_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x6d, 0x65, 0x74, 0x61,
 	0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
 	0x1a, 0x13, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x65, 0x63, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x84, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x74, 0x61, 0x4c, 0x6f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd8, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x74, 0x61, 0x4c, 0x6f,
 	0x67, 0x12, 0x39, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4b, 0x65, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4d, 0x65,
 	0x74, 0x61, 0x4c, 0x6f, 0x67, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4b, 0x65, 0x79,
@@ -174,19 +200,24 @@ var file_pkg_model_metalog_proto_rawDesc = []byte{
 	0x65, 0x63, 0x73, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x45, 0x63, 0x73, 0x4c, 0x6f, 0x67,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x65, 0x63, 0x73, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x22, 0x68, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4b, 0x65, 0x79,
-	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x07, 0x0a,
-	0x03, 0x4e, 0x6f, 0x70, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x46, 0x6d, 0x74,
-	0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x63, 0x73, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x54,
-	0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x73, 0x67, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x45,
-	0x6e, 0x76, 0x6f, 0x79, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x6c, 0x66, 0x10, 0x06, 0x12,
-	0x0b, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x65, 0x66, 0x69, 0x6b, 0x10, 0x07, 0x42, 0x56, 0x0a, 0x25,
-	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x73, 0x75, 0x69, 0x6b, 0x61,
-	0x73, 0x74, 0x34, 0x32, 0x2e, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65, 0x72, 0x2e,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x01, 0x50, 0x01, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x75, 0x69, 0x6b, 0x61, 0x73, 0x74, 0x34, 0x32, 0x2f,
-	0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d,
-	0x6f, 0x64, 0x65, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x97, 0x01, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x6f, 0x70, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4c,
+	0x6f, 0x67, 0x46, 0x6d, 0x74, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x63, 0x73, 0x10, 0x03,
+	0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x73, 0x67, 0x10, 0x04,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x6e, 0x76, 0x6f, 0x79, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x43,
+	0x6c, 0x66, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x65, 0x66, 0x69, 0x6b, 0x10,
+	0x07, 0x12, 0x08, 0x0a, 0x04, 0x4f, 0x74, 0x6c, 0x70, 0x10, 0x08, 0x12, 0x08, 0x0a, 0x04, 0x4a,
+	0x73, 0x6f, 0x6e, 0x10, 0x09, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x70, 0x72, 0x69, 0x6e, 0x67, 0x42,
+	0x6f, 0x6f, 0x74, 0x10, 0x0a, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x63, 0x6c, 0x6f, 0x67, 0x10, 0x0b,
+	0x42, 0x56, 0x0a, 0x25, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x73,
+	0x75, 0x69, 0x6b, 0x61, 0x73, 0x74, 0x34, 0x32, 0x2e, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x01, 0x50, 0x01, 0x5a, 0x29, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x75, 0x69, 0x6b, 0x61, 0x73,
+	0x74, 0x34, 0x32, 0x2f, 0x6c, 0x6f, 0x67, 0x75, 0x6e, 0x69, 0x66, 0x69, 0x65, 0x72, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
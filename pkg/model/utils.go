@@ -2,6 +2,7 @@ package model
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 )
@@ -46,13 +47,54 @@ func StringToLogPatternKey(pattern string) MetaLog_PatternKey {
 	return key
 }
 
+// IsBuiltInPatternKey true if the pattern names a key of MetaLog_PatternKey
+func IsBuiltInPatternKey(pattern string) bool {
+	_, found := logPatternStringMap[strings.ToLower(pattern)]
+	return found
+}
+
+var (
+	userPatternKeysMtx sync.RWMutex
+	// userPatternKeys the names of the patterns that are not a MetaLog_PatternKey. For example declared by configuration
+	userPatternKeys = map[string]bool{}
+)
+
+// RegisterUserPatternKey a pattern declared by configuration. StringToLogPatternKey maps it to MetaLog_Nop
+// PatternKeyName keeps its name. So the pattern factory finds it by Log.PatternKey
+func RegisterUserPatternKey(pattern string) {
+	userPatternKeysMtx.Lock()
+	defer userPatternKeysMtx.Unlock()
+	userPatternKeys[strings.ToLower(pattern)] = true
+}
+
+// IsUserPatternKey true if the pattern is registered by RegisterUserPatternKey
+func IsUserPatternKey(pattern string) bool {
+	userPatternKeysMtx.RLock()
+	defer userPatternKeysMtx.RUnlock()
+	return userPatternKeys[strings.ToLower(pattern)]
+}
+
+// PatternKeyName the value of Log.PatternKey for a pattern requested by the log metadata
+// The lower case name of a user defined pattern. The name of the MetaLog_PatternKey otherwise
+func PatternKeyName(pattern string) string {
+	if IsUserPatternKey(pattern) {
+		return strings.ToLower(pattern)
+	}
+	return StringToLogPatternKey(pattern).String()
+}
+
 var logPatternStringMap = map[string]MetaLog_PatternKey{
 	"nop":        MetaLog_Nop,
 	"logfmt":     MetaLog_LogFmt,
 	"ecs":        MetaLog_Ecs,
 	"tslevelmsg": MetaLog_TsLevelMsg,
 	"envoy":      MetaLog_Envoy,
+	"clf":        MetaLog_Clf,
 	"traefik":    MetaLog_Traefik,
+	"otlp":       MetaLog_Otlp,
+	"json":       MetaLog_Json,
+	"springboot": MetaLog_SpringBoot,
+	"hclog":      MetaLog_Hclog,
 }
 
 var stringToLogLevelMap = map[string]LogLevel{
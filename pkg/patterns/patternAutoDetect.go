@@ -0,0 +1,151 @@
+package patterns
+
+import (
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// autoDetectCandidates the extractors tried for a log without pattern key. The first one wins with an equal score
+var autoDetectCandidates = []model.MetaLog_PatternKey{
+	model.MetaLog_Ecs,
+	model.MetaLog_Json,
+	model.MetaLog_LogFmt,
+	model.MetaLog_Clf,
+	model.MetaLog_Traefik,
+	model.MetaLog_Envoy,
+	model.MetaLog_SpringBoot,
+	model.MetaLog_Hclog,
+	model.MetaLog_TsLevelMsg,
+}
+
+// autoDetectMaxMisses the logs in a row that the cached pattern of a service can't parse until the detection stops
+// The logs of such a service don't share one pattern. Detecting every log again is expensive and flips the cached pattern
+// A miss costs a proto.Clone and an extraction of the log for every candidate. Up to len(autoDetectCandidates)
+const autoDetectMaxMisses = 3
+
+// autoDetectRetryAfter the stopped detection of a service starts again after this duration
+// For example the service logged its start with another pattern
+const autoDetectRetryAfter = 10 * time.Minute
+
+// detectedPattern the pattern detected for a service
+type detectedPattern struct {
+	patternKey model.MetaLog_PatternKey
+	// misses the logs in a row that the pattern can't parse
+	misses int
+	// stopped the time the misses reached autoDetectMaxMisses
+	stopped time.Time
+}
+
+// detect the pattern of a log without pattern key
+// The winner is cached per service name and version like the timestamp layouts of utils.ParseTime
+// A cached pattern that can't parse the log is detected again up to autoDetectMaxMisses times in a row
+// The detection starts again autoDetectRetryAfter later
+// The logs without service name are parsed as is. They don't share a pattern
+func (factory *PatternFactory) detect(log *model.MetaLog) *model.EcsLogEntry {
+	if len(log.EcsLogEntry.GetService().GetName()) == 0 {
+		return ExtractFrom(factory.findPatternFor(log), log)
+	}
+	cacheKey := utils.CacheKeyForLog(log)
+	cached, found := factory.detectedPatternFor(cacheKey)
+	if found && cached.misses >= autoDetectMaxMisses && time.Since(cached.stopped) >= autoDetectRetryAfter {
+		cached = detectedPattern{patternKey: cached.patternKey}
+		factory.cacheDetectedPattern(cacheKey, cached)
+	}
+	if found {
+		if result, ok := factory.tryPattern(log, cached.patternKey); ok {
+			if cached.misses > 0 && cached.misses < autoDetectMaxMisses {
+				factory.cacheDetectedPattern(cacheKey, detectedPattern{patternKey: cached.patternKey})
+			}
+			return factory.accept(log, result)
+		}
+		if cached.misses >= autoDetectMaxMisses {
+			return ExtractFrom(factory.findPatternFor(log), log)
+		}
+		cached.misses++
+		if cached.misses == autoDetectMaxMisses {
+			cached.stopped = time.Now()
+		}
+	}
+	var best *model.MetaLog
+	bestScore := 0
+	for _, candidate := range autoDetectCandidates {
+		if found && candidate == cached.patternKey {
+			continue
+		}
+		result, ok := factory.tryPattern(log, candidate)
+		if !ok {
+			continue
+		}
+		if score := autoDetectScore(result, log); score > bestScore {
+			best, bestScore = result, score
+		}
+	}
+	if best == nil {
+		// Nothing matches. Keep the cached pattern for the next log of the service
+		if found {
+			factory.cacheDetectedPattern(cacheKey, cached)
+		}
+		return ExtractFrom(factory.findPatternFor(log), log)
+	}
+	cached.patternKey = best.PatternKey
+	factory.cacheDetectedPattern(cacheKey, cached)
+	return factory.accept(log, best)
+}
+
+// tryPattern extracts a copy of the log with the pattern. False if the pattern can't parse the log
+func (factory *PatternFactory) tryPattern(log *model.MetaLog, patternKey model.MetaLog_PatternKey) (*model.MetaLog, bool) {
+	result := proto.Clone(log).(*model.MetaLog)
+	result.PatternKey = patternKey
+	ecs := ExtractFrom(factory.findPatternFor(result), result)
+	return result, !ecs.HasProcessError()
+}
+
+// accept the detected pattern for the log
+func (factory *PatternFactory) accept(log *model.MetaLog, result *model.MetaLog) *model.EcsLogEntry {
+	log.PatternKey = result.PatternKey
+	log.EcsLogEntry = result.EcsLogEntry
+	log.EcsLogEntry.SetPattern(result.PatternKey.String())
+	return log.EcsLogEntry
+}
+
+func (factory *PatternFactory) detectedPatternFor(cacheKey string) (detectedPattern, bool) {
+	factory.detectedPatternsMtx.RLock()
+	defer factory.detectedPatternsMtx.RUnlock()
+	detected, found := factory.detectedPatterns[cacheKey]
+	return detected, found
+}
+
+func (factory *PatternFactory) cacheDetectedPattern(cacheKey string, detected detectedPattern) {
+	factory.detectedPatternsMtx.Lock()
+	defer factory.detectedPatternsMtx.Unlock()
+	factory.detectedPatterns[cacheKey] = detected
+}
+
+// autoDetectScore the number of ecs fields the extraction filled. The fields that the ingress provided count for every pattern
+func autoDetectScore(result *model.MetaLog, log *model.MetaLog) int {
+	ecs := result.EcsLogEntry
+	filled := []bool{
+		!proto.Equal(ecs.Timestamp, log.EcsLogEntry.Timestamp),
+		len(ecs.Message) > 0 && ecs.Message != result.RawMessage,
+		ecs.GetLog().GetLevel() != model.LogLevel_unknown && ecs.GetLog().GetLevel() != model.LogLevel_not_set,
+		ecs.IsLoggerSet(),
+		ecs.GetLog().GetOrigin() != nil,
+		ecs.Error != nil,
+		ecs.Trace != nil,
+		ecs.User != nil,
+		ecs.Event != nil,
+		ecs.Http != nil,
+		ecs.Url != nil,
+		ecs.Source != nil,
+		ecs.UserAgent != nil,
+	}
+	score := 0
+	for _, ok := range filled {
+		if ok {
+			score++
+		}
+	}
+	return score
+}
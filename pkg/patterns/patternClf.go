@@ -0,0 +1,155 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// The fields of the common log format grok utils.CustomPatterns
+const (
+	clfClientIp    = "client_ip"
+	clfAuth        = "auth"
+	clfMethod      = "method"
+	clfRequest     = "request"
+	clfHttpVersion = "http_version"
+	clfStatusCode  = "status_code"
+	clfBytes       = "bytes"
+	clfReferrer    = "referrer"
+	clfUserAgent   = "user_agent"
+	// clfEmpty a field without value
+	clfEmpty = "-"
+)
+
+// GrokPatternClf extracts the common (and combined) log format of access logs
+// For example 10.21.0.1 - - [01/Apr/2023:08:33:52 +0000] "GET /v1/acl/token/self HTTP/2.0" 400 44 "-" "curl/8.0.1"
+type GrokPatternClf struct {
+	GrokPatternDefault
+	// Builder fields
+	_extractedFields map[string]string
+}
+
+func (g *GrokPatternClf) from(log *model.MetaLog) GrokPatternExtractor {
+	compilerFor := Instance().CompilerFor(g.GrokPatternDefault.Name)
+	g._this = g
+	g._metaLog = log
+	g._extractedFields = map[string]string{}
+	if compilerFor == nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find a pattern for key %s", g.GrokPatternDefault.Name))
+		return g._this
+	}
+	for k, v := range compilerFor.ParseString(log.RawMessage) {
+		// An absent value is logged as -
+		if len(v) > 0 && v != clfEmpty {
+			g._extractedFields[k] = v
+		}
+	}
+	if len(g._extractedFields) == 0 {
+		g._parseErrors = append(g._parseErrors, "Can't parse the common log format")
+	}
+	return g._this
+}
+
+func (g *GrokPatternClf) timeStamp() GrokPatternExtractor {
+	tsstring, ok := g._extractedFields[string(utils.PatternMatchTimeStamp)]
+	if !ok {
+		return g._this
+	}
+	defer func() {
+		delete(g._extractedFields, string(utils.PatternMatchTimeStamp))
+	}()
+	parsedTs := utils.ParseTime(g._metaLog, tsstring)
+	if parsedTs.IsZero() {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find timestamp for %s", tsstring))
+		return g._this
+	}
+	g._metaLog.EcsLogEntry.Timestamp = timestamppb.New(parsedTs)
+	return g._this
+}
+
+func (g *GrokPatternClf) httpInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	defer func() {
+		for _, key := range []string{clfClientIp, clfAuth, clfMethod, clfRequest, clfHttpVersion, clfStatusCode, clfBytes, clfReferrer, clfUserAgent} {
+			delete(g._extractedFields, key)
+		}
+	}()
+	if client, ok := g._extractedFields[clfClientIp]; ok {
+		ecs.Source = &model.Source{Address: client}
+		if net.ParseIP(client) != nil {
+			ecs.Source.Ip = client
+		} else {
+			ecs.Source.Domain = client
+		}
+	}
+	if user, ok := g._extractedFields[clfAuth]; ok {
+		ecs.User = &model.User{Name: user}
+	}
+	if request, ok := g._extractedFields[clfRequest]; ok {
+		path, query, _ := strings.Cut(request, "?")
+		ecs.Url = &model.Url{Original: request, Path: path, Query: query}
+	}
+	if userAgent, ok := g._extractedFields[clfUserAgent]; ok {
+		ecs.UserAgent = &model.UserAgent{Original: userAgent}
+	}
+	if _, ok := g._extractedFields[clfMethod]; !ok {
+		return g._this
+	}
+	ecs.Http = &model.Http{
+		Request: &model.Http_Request{
+			Method:   g._extractedFields[clfMethod],
+			Referrer: g._extractedFields[clfReferrer],
+		},
+		Response: &model.Http_Response{},
+		Version:  g._extractedFields[clfHttpVersion],
+	}
+	if status, ok := g._extractedFields[clfStatusCode]; ok {
+		code, err := strconv.ParseInt(status, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid status code %s", status))
+		}
+		ecs.Http.Response.StatusCode = code
+	}
+	if bytes, ok := g._extractedFields[clfBytes]; ok {
+		size, err := strconv.ParseInt(bytes, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid response size %s", bytes))
+		}
+		ecs.Http.Response.Bytes = size
+	}
+	return g._this
+}
+
+// logInfo the level of the status code. 5xx error, 4xx warn and info otherwise
+func (g *GrokPatternClf) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	if ecs.Http == nil || ecs.Http.Response == nil || ecs.Http.Response.StatusCode == 0 {
+		return g.GrokPatternDefault.logInfo()
+	}
+	switch status := ecs.Http.Response.StatusCode; {
+	case status >= 500:
+		ecs.SetLogLevel(model.LogLevel_error)
+	case status >= 400:
+		ecs.SetLogLevel(model.LogLevel_warn)
+	default:
+		ecs.SetLogLevel(model.LogLevel_info)
+	}
+	return g._this
+}
+
+func (g *GrokPatternClf) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the registered keys
+	// Add the not standard keys as labels
+	if ecs.Labels == nil && len(g._extractedFields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	for k, v := range g._extractedFields {
+		ecs.Labels["clf_"+k] = v
+	}
+	return ecs
+}
@@ -65,14 +65,21 @@ func (g *GrokPatternDefault) errorInfo() GrokPatternExtractor {
 	return g._this
 }
 
+func (g *GrokPatternDefault) httpInfo() GrokPatternExtractor {
+	// We do not expect an access log in the default log pattern
+	return g._this
+}
+
 func (g *GrokPatternDefault) eventInfo() GrokPatternExtractor {
 	// We do not expect a special error info in the default log pattern
 	return g._this
 }
 
 func (g *GrokPatternDefault) logInfo() GrokPatternExtractor {
-	//We don't know the log level
-	g._metaLog.EcsLogEntry.SetLogLevel(model.LogLevel_unknown)
+	//We don't know the log level. Keep the level if the ingress defines it. For example the syslog severity
+	if !g._metaLog.IngressLevel || !g._metaLog.EcsLogEntry.IsLogLevelSet() {
+		g._metaLog.EcsLogEntry.SetLogLevel(model.LogLevel_unknown)
+	}
 	return g._this
 }
 
@@ -0,0 +1,45 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// GrokPatternEcs extracts a native ecs json log that the ingress did not declare as ecs. Used by the pattern detection
+// The fields of the ecs log override the fields that the ingress has set
+type GrokPatternEcs struct {
+	GrokPatternDefault
+	// level the level of the ecs log
+	level model.LogLevel
+}
+
+func (g *GrokPatternEcs) from(log *model.MetaLog) GrokPatternExtractor {
+	g._this = g
+	g._metaLog = log
+	parsed := &model.EcsLogEntry{}
+	err := parsed.FromJson([]byte(log.RawMessage))
+	if err != nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't parse the ecs log: %s", err.Error()))
+		return g._this
+	}
+	proto.Merge(log.EcsLogEntry, parsed)
+	g.level = parsed.GetLog().GetLevel()
+	return g._this
+}
+
+func (g *GrokPatternEcs) message() GrokPatternExtractor {
+	if len(g._metaLog.EcsLogEntry.Message) == 0 {
+		return g.GrokPatternDefault.message()
+	}
+	return g._this
+}
+
+// logInfo the level of the ecs log. Like GrokPatternDefault otherwise
+func (g *GrokPatternEcs) logInfo() GrokPatternExtractor {
+	if g.level > model.LogLevel_unknown {
+		g._metaLog.EcsLogEntry.SetLogLevel(g.level)
+		return g._this
+	}
+	return g.GrokPatternDefault.logInfo()
+}
@@ -0,0 +1,212 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The fields of the envoy access log. The names of the json access log of the consul connect sidecars
+// https://developer.hashicorp.com/consul/docs/connect/observability/access-logs#default-log-format
+const (
+	envoyAccessStartTime         = "start_time"
+	envoyAccessMethod            = "method"
+	envoyAccessPath              = "path"
+	envoyAccessProtocol          = "protocol"
+	envoyAccessResponseCode      = "response_code"
+	envoyAccessResponseFlags     = "response_flags"
+	envoyAccessBytesReceived     = "bytes_received"
+	envoyAccessBytesSent         = "bytes_sent"
+	envoyAccessDuration          = "duration"
+	envoyAccessUpstreamTime      = "upstream_service_time"
+	envoyAccessForwardedFor      = "x_forwarded_for"
+	envoyAccessUserAgent         = "user_agent"
+	envoyAccessRequestId         = "request_id"
+	envoyAccessAuthority         = "authority"
+	envoyAccessUpstreamHost      = "upstream_host"
+	envoyAccessDownstreamAddress = "downstream_remote_address"
+	// envoyAccessEmpty a field without value
+	envoyAccessEmpty = "-"
+)
+
+// envoyAccessLogFields the fields of the default text format utils.PatternEnvoyAccessLog
+var envoyAccessLogFields = []string{envoyAccessStartTime, envoyAccessMethod, envoyAccessPath, envoyAccessProtocol,
+	envoyAccessResponseCode, envoyAccessResponseFlags, envoyAccessBytesReceived, envoyAccessBytesSent, envoyAccessDuration,
+	envoyAccessUpstreamTime, envoyAccessForwardedFor, envoyAccessUserAgent, envoyAccessRequestId, envoyAccessAuthority,
+	envoyAccessUpstreamHost}
+
+// envoyAccessLogStart the start of the default text format. The application log of envoy starts with [timestamp][thread]
+var envoyAccessLogStart = regexp.MustCompile(`^\s*\[[^\]]+\] "`)
+
+// jsonProfileEnvoyAccess the keys of the envoy access log that are mapped like every json log
+var jsonProfileEnvoyAccess = &JsonProfile{
+	Name:      model.MetaLog_Envoy.String(),
+	Timestamp: []string{envoyAccessStartTime},
+}
+
+// isEnvoyAccessLog true if the log is an access log of envoy in the default text or in the json format
+func isEnvoyAccessLog(log string) bool {
+	return isJsonObject(log) || envoyAccessLogStart.MatchString(log)
+}
+
+// GrokPatternEnvoyAccess extracts the access log of envoy. For example the access log of the consul connect sidecars
+// [2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 204 - 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"
+// The text log is mapped to the keys of the json log. So both are extracted the same way
+// The x-request-id is the transaction id of the trace and the response flags are the reason of the event
+// The keys that are not mapped are added as labels with the prefix envoy_. For example envoy_upstream_cluster
+type GrokPatternEnvoyAccess struct {
+	GrokPatternJson
+}
+
+func (g *GrokPatternEnvoyAccess) from(log *model.MetaLog) GrokPatternExtractor {
+	if isJsonObject(log.RawMessage) {
+		g.GrokPatternJson.from(log)
+		g._this = g
+		if len(g._fields) > 0 {
+			if _, ok := g._fields[envoyAccessResponseCode]; !ok {
+				g._parseErrors = append(g._parseErrors, "Can't find the response of the envoy access log")
+			}
+		}
+		return g._this
+	}
+	g._this = g
+	g._metaLog = log
+	g._fields = map[string]any{}
+	compilerFor := Instance().compilerFor(utils.PatternEnvoyAccessLog)
+	if compilerFor == nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find a pattern for key %s", utils.PatternEnvoyAccessLog))
+		return g._this
+	}
+	parsed := compilerFor.ParseString(log.RawMessage)
+	// Skip the captures of the timestamp
+	for _, key := range envoyAccessLogFields {
+		if value, ok := parsed[key]; ok && len(value) > 0 {
+			g._fields[key] = value
+		}
+	}
+	if len(g._fields) == 0 {
+		g._parseErrors = append(g._parseErrors, "Can't parse the envoy access log")
+	}
+	return g._this
+}
+
+// message the request line of the access log. For example POST /api/v1/locations HTTP/2 204
+// The response flags are appended if present. For example 0 UF for a failed tcp connection
+func (g *GrokPatternEnvoyAccess) message() GrokPatternExtractor {
+	var parts []string
+	for _, key := range []string{envoyAccessMethod, envoyAccessPath, envoyAccessProtocol, envoyAccessResponseCode, envoyAccessResponseFlags} {
+		if value, ok := g.field(key, envoyAccessEmpty); ok {
+			parts = append(parts, value)
+		}
+	}
+	if len(parts) == 0 {
+		return g.GrokPatternDefault.message()
+	}
+	g._metaLog.EcsLogEntry.Message = strings.Join(parts, " ")
+	return g._this
+}
+
+func (g *GrokPatternEnvoyAccess) httpInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	defer func() {
+		for _, key := range []string{envoyAccessMethod, envoyAccessPath, envoyAccessProtocol, envoyAccessResponseCode,
+			envoyAccessResponseFlags, envoyAccessBytesReceived, envoyAccessBytesSent, envoyAccessDuration,
+			envoyAccessForwardedFor, envoyAccessUserAgent, envoyAccessDownstreamAddress} {
+			delete(g._fields, key)
+		}
+	}()
+	// The first address of x-forwarded-for is the client. The downstream address otherwise
+	client, _ := g.field(envoyAccessForwardedFor, envoyAccessEmpty)
+	client = strings.TrimSpace(strings.Split(client, ",")[0])
+	if downstream, ok := g.field(envoyAccessDownstreamAddress, envoyAccessEmpty); ok && len(client) == 0 {
+		client = downstream
+		if host, _, err := net.SplitHostPort(downstream); err == nil {
+			client = host
+		}
+	}
+	if len(client) > 0 {
+		g.setSource(client)
+	}
+	if request, ok := g.field(envoyAccessPath, envoyAccessEmpty); ok {
+		path, query, _ := strings.Cut(request, "?")
+		ecs.Url = &model.Url{Original: request, Path: path, Query: query}
+	}
+	if userAgent, ok := g.field(envoyAccessUserAgent, envoyAccessEmpty); ok {
+		ecs.UserAgent = &model.UserAgent{Original: userAgent}
+	}
+	flags, flagsFound := g.field(envoyAccessResponseFlags, envoyAccessEmpty)
+	if _, ok := g.field(envoyAccessDuration, envoyAccessEmpty); ok || flagsFound {
+		ecs.Event = &model.Event{
+			Duration: g.number(envoyAccessDuration, envoyAccessEmpty) * int64(time.Millisecond),
+			Reason:   flags,
+		}
+	}
+	method, ok := g.field(envoyAccessMethod, envoyAccessEmpty)
+	if !ok {
+		return g._this
+	}
+	protocol, _ := g.field(envoyAccessProtocol, envoyAccessEmpty)
+	ecs.Http = &model.Http{
+		Request: &model.Http_Request{
+			Method: method,
+			Bytes:  g.number(envoyAccessBytesReceived, envoyAccessEmpty),
+		},
+		Response: &model.Http_Response{
+			StatusCode: g.number(envoyAccessResponseCode, envoyAccessEmpty),
+			Bytes:      g.number(envoyAccessBytesSent, envoyAccessEmpty),
+		},
+		Version: strings.TrimPrefix(protocol, "HTTP/"),
+	}
+	return g._this
+}
+
+// logInfo the level of the status code like GrokPatternClf. 5xx error, 4xx warn and info otherwise
+// A request with response flags is a warn. For example an upstream connection failure of a tcp proxy
+func (g *GrokPatternEnvoyAccess) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	switch status := ecs.GetHttp().GetResponse().GetStatusCode(); {
+	case status >= 500:
+		ecs.SetLogLevel(model.LogLevel_error)
+	case status >= 400:
+		ecs.SetLogLevel(model.LogLevel_warn)
+	case len(ecs.GetEvent().GetReason()) > 0:
+		ecs.SetLogLevel(model.LogLevel_warn)
+	default:
+		ecs.SetLogLevel(model.LogLevel_info)
+	}
+	return g._this
+}
+
+// tracingInfo the x-request-id is the transaction id
+func (g *GrokPatternEnvoyAccess) tracingInfo() GrokPatternExtractor {
+	requestId, ok := g.field(envoyAccessRequestId, envoyAccessEmpty)
+	delete(g._fields, envoyAccessRequestId)
+	if !ok {
+		return g._this
+	}
+	if g._metaLog.EcsLogEntry.Trace == nil {
+		g._metaLog.EcsLogEntry.Trace = &model.Tracing{}
+	}
+	g._metaLog.EcsLogEntry.Trace.Transaction = &model.Tracing_Transaction{Id: requestId}
+	return g._this
+}
+
+func (g *GrokPatternEnvoyAccess) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the mapped keys
+	// Add the not mapped keys with value as labels
+	for k := range g._fields {
+		if _, ok := g.field(k, envoyAccessEmpty); !ok {
+			delete(g._fields, k)
+		}
+	}
+	if ecs.Labels == nil && len(g._fields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	flatten("envoy_", g._fields, ecs.Labels)
+	return ecs
+}
@@ -95,6 +95,9 @@ func (g *GrokPatternTsLevelMsg) extract() *model.EcsLogEntry {
 	ecs := g.GrokPatternDefault.extract()
 	// Every step removes the registered keys
 	// Add the not standard keys as labels
+	if ecs.Labels == nil && len(g._extractedFields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
 	for k, v := range g._extractedFields {
 		if utils.IsRegisteredKey(k) {
 			ecs.Labels["pattern_"+k] = v
@@ -0,0 +1,82 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"strings"
+)
+
+// jsonProfileHclog the keys of the json format of hclog
+// https://github.com/hashicorp/go-hclog/blob/main/intlogger.go
+var jsonProfileHclog = &JsonProfile{
+	Name:      model.MetaLog_Hclog.String(),
+	Timestamp: []string{"@timestamp"},
+	Message:   []string{"@message"},
+	Level:     []string{"@level"},
+	Logger:    []string{"@module"},
+	Caller:    []string{"@caller"},
+	Error:     []string{"error", "err"},
+	TraceId:   []string{"trace_id", "traceId", "traceID"},
+	SpanId:    []string{"span_id", "spanId", "spanID"},
+}
+
+// GrokPatternHclog extracts the text and the json logs of hclog. For example the agent logs of Nomad, Consul and Vault
+// 2024-01-01T10:00:00.123+0100 [INFO]  agent: detected plugin: name=java type=driver
+// {"@level":"info","@message":"detected plugin","@module":"agent","@timestamp":"2024-01-01T10:00:00.123+01:00","name":"java"}
+// The text log is mapped to the keys of the json log. So both are extracted with the json profile of hclog
+// The key value pairs that are not mapped by the profile are added as labels with the prefix hclog_
+type GrokPatternHclog struct {
+	GrokPatternJson
+}
+
+func (g *GrokPatternHclog) from(log *model.MetaLog) GrokPatternExtractor {
+	if isJsonObject(log.RawMessage) {
+		g.GrokPatternJson.from(log)
+		g._this = g
+		return g._this
+	}
+	g._this = g
+	g._metaLog = log
+	g._fields = map[string]any{}
+	compilerFor := Instance().compilerFor(g.GrokPatternDefault.Name.String())
+	if compilerFor == nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find a pattern for key %s", g.GrokPatternDefault.Name.String()))
+		return g._this
+	}
+	parsed, _ := utils.ParseAndGetRegisteredKey(compilerFor, log.RawMessage)
+	if len(parsed) == 0 {
+		g._parseErrors = append(g._parseErrors, "Can't parse the hclog log")
+		return g._this
+	}
+	// hclog writes the args after the message separated with a colon
+	message, args := utils.SplitTrailingKeyValues(parsed[utils.PatternMatchKeyMessage])
+	if len(args) > 0 {
+		message = strings.TrimSuffix(message, ":")
+	}
+	for k, v := range args {
+		g._fields[k] = v
+	}
+	g._fields["@message"] = message
+	for key, jsonKey := range map[utils.PatterMatch]string{
+		utils.PatternMatchTimeStamp: "@timestamp",
+		utils.PatternMatchKeyLevel:  "@level",
+		utils.PatternMatchKeyLogger: "@module",
+	} {
+		if value, ok := parsed[key]; ok && len(value) > 0 {
+			g._fields[jsonKey] = value
+		}
+	}
+	return g._this
+}
+
+func (g *GrokPatternHclog) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the mapped keys
+	// Add the args as labels
+	if ecs.Labels == nil && len(g._fields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	flatten("hclog_", g._fields, ecs.Labels)
+	return ecs
+}
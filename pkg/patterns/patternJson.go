@@ -0,0 +1,458 @@
+package patterns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JsonProfile maps the keys of a structured json log to ecs
+// Every field lists the keys that are looked up in order. The first present key is taken
+type JsonProfile struct {
+	Name       string   `yaml:"name"`
+	Timestamp  []string `yaml:"timestamp"`
+	Message    []string `yaml:"message"`
+	Level      []string `yaml:"level"`
+	Logger     []string `yaml:"logger"`
+	Caller     []string `yaml:"caller"`
+	Error      []string `yaml:"error"`
+	StackTrace []string `yaml:"stackTrace"`
+	TraceId    []string `yaml:"traceId"`
+	SpanId     []string `yaml:"spanId"`
+	Host       []string `yaml:"host"`
+	// Levels maps the lower case level values to a log level name. For example the numeric levels of pino
+	Levels map[string]string `yaml:"levels"`
+	// DefaultLevel the level of a log without level key. Serilog omits the level information for example
+	DefaultLevel string `yaml:"defaultLevel"`
+}
+
+// The numeric levels of pino and bunyan
+var numericLevels = map[string]string{
+	"10": "trace",
+	"20": "debug",
+	"30": "info",
+	"40": "warn",
+	"50": "error",
+	"60": "fatal",
+}
+
+// The levels of Serilog that are not known by model.StringToLogLevel
+var serilogLevels = map[string]string{
+	"verbose":     "trace",
+	"information": "info",
+}
+
+// JsonProfileGeneric the profile of the pattern key json. Knows the keys of all built-in profiles
+var JsonProfileGeneric = &JsonProfile{
+	Name:       model.MetaLog_Json.String(),
+	Timestamp:  []string{"@timestamp", "timestamp", "time", "ts", "@t"},
+	Message:    []string{"message", "msg", "@m", "@mt"},
+	Level:      []string{"level", "lvl", "severity", "@l"},
+	Logger:     []string{"logger", "logger_name", "SourceContext"},
+	Caller:     []string{"caller", "source", "src"},
+	Error:      []string{"error", "err", "@x"},
+	StackTrace: []string{"stacktrace", "stack_trace"},
+	TraceId:    []string{"trace_id", "traceId", "traceID", "trace.id", "@tr"},
+	SpanId:     []string{"span_id", "spanId", "spanID", "span.id", "@sp"},
+	Host:       []string{"hostname"},
+	Levels:     joinLevels(numericLevels, serilogLevels),
+}
+
+// builtInJsonProfiles the profiles of the supported logging libraries. Selected by name like a pattern key
+var builtInJsonProfiles = []*JsonProfile{
+	{
+		// https://pkg.go.dev/go.uber.org/zap#NewProductionEncoderConfig
+		Name:       "zap",
+		Timestamp:  []string{"ts"},
+		Message:    []string{"msg"},
+		Level:      []string{"level"},
+		Logger:     []string{"logger"},
+		Caller:     []string{"caller"},
+		Error:      []string{"error"},
+		StackTrace: []string{"stacktrace"},
+		TraceId:    []string{"trace_id", "traceId"},
+		SpanId:     []string{"span_id", "spanId"},
+	},
+	{
+		// https://pkg.go.dev/github.com/sirupsen/logrus#JSONFormatter
+		Name:      "logrus",
+		Timestamp: []string{"time"},
+		Message:   []string{"msg"},
+		Level:     []string{"level"},
+		Caller:    []string{"file"},
+		Error:     []string{"error"},
+		TraceId:   []string{"trace_id", "traceId"},
+		SpanId:    []string{"span_id", "spanId"},
+	},
+	{
+		// https://getpino.io/#/docs/api?id=logger
+		Name:      "pino",
+		Timestamp: []string{"time"},
+		Message:   []string{"msg"},
+		Level:     []string{"level"},
+		Logger:    []string{"name"},
+		Error:     []string{"err"},
+		TraceId:   []string{"trace_id", "traceId"},
+		SpanId:    []string{"span_id", "spanId"},
+		Host:      []string{"hostname"},
+		Levels:    numericLevels,
+	},
+	{
+		// https://github.com/trentm/node-bunyan#core-fields
+		Name:      "bunyan",
+		Timestamp: []string{"time"},
+		Message:   []string{"msg"},
+		Level:     []string{"level"},
+		Logger:    []string{"name"},
+		Caller:    []string{"src"},
+		Error:     []string{"err"},
+		TraceId:   []string{"trace_id", "traceId"},
+		SpanId:    []string{"span_id", "spanId"},
+		Host:      []string{"hostname"},
+		Levels:    numericLevels,
+	},
+	{
+		// https://github.com/serilog/serilog-formatting-compact#format-details
+		Name:         "serilog",
+		Timestamp:    []string{"@t"},
+		Message:      []string{"@m", "@mt"},
+		Level:        []string{"@l"},
+		Logger:       []string{"SourceContext"},
+		Error:        []string{"@x"},
+		TraceId:      []string{"@tr"},
+		SpanId:       []string{"@sp"},
+		Levels:       serilogLevels,
+		DefaultLevel: "info",
+	},
+	{
+		// https://pkg.go.dev/log/slog#JSONHandler
+		Name:      "slog",
+		Timestamp: []string{"time"},
+		Message:   []string{"msg"},
+		Level:     []string{"level"},
+		Caller:    []string{"source"},
+		Error:     []string{"error", "err"},
+		TraceId:   []string{"trace_id", "traceId", "traceID"},
+		SpanId:    []string{"span_id", "spanId", "spanID"},
+	},
+}
+
+func joinLevels(levels ...map[string]string) map[string]string {
+	result := make(map[string]string)
+	for _, l := range levels {
+		for k, v := range l {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// isBuiltInJsonProfile true if a built-in profile is named by name
+func isBuiltInJsonProfile(name string) bool {
+	for _, profile := range builtInJsonProfiles {
+		if strings.EqualFold(profile.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// addJsonProfiles registers the names of the profiles
+func (factory *PatternFactory) addJsonProfiles(profiles []*JsonProfile) {
+	for _, profile := range profiles {
+		name := strings.ToLower(profile.Name)
+		factory.jsonProfiles[name] = profile
+		model.RegisterUserPatternKey(name)
+	}
+}
+
+// GrokPatternJson extracts a structured json log with a JsonProfile
+// The keys that are not mapped by the profile are added as labels with the prefix json_
+type GrokPatternJson struct {
+	GrokPatternDefault
+	profile *JsonProfile
+	// Builder fields
+	_fields map[string]any
+}
+
+func (g *GrokPatternJson) from(log *model.MetaLog) GrokPatternExtractor {
+	g._this = g
+	g._metaLog = log
+	g._fields = map[string]any{}
+	decoder := json.NewDecoder(strings.NewReader(log.RawMessage))
+	// Keep the precision of the numbers. For example the epoch nanos of a timestamp
+	decoder.UseNumber()
+	err := decoder.Decode(&g._fields)
+	if err != nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't parse the json log: %s", err.Error()))
+	}
+	return g._this
+}
+
+// isJsonObject true if the log is a json object. Used by the extractors of libraries that log text or json
+func isJsonObject(log string) bool {
+	return strings.HasPrefix(strings.TrimSpace(log), "{")
+}
+
+// field the value of key as string. A value that is empty or the placeholder of an absent value is not present
+func (g *GrokPatternJson) field(key string, placeholder string) (string, bool) {
+	value, ok := g._fields[key]
+	if !ok {
+		return "", false
+	}
+	text := jsonString(value)
+	if len(text) == 0 || text == placeholder {
+		return "", false
+	}
+	return text, true
+}
+
+// number the numeric value of key. 0 if the key is absent
+func (g *GrokPatternJson) number(key string, placeholder string) int64 {
+	value, ok := g.field(key, placeholder)
+	if !ok {
+		return 0
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid number %s for %s", value, key))
+	}
+	return number
+}
+
+// setSource the client of an access log. An ip or a domain
+func (g *GrokPatternJson) setSource(client string) {
+	ecs := g._metaLog.EcsLogEntry
+	ecs.Source = &model.Source{Address: client}
+	if net.ParseIP(client) != nil {
+		ecs.Source.Ip = client
+	} else {
+		ecs.Source.Domain = client
+	}
+}
+
+// take the value of the first present key and remove it
+func (g *GrokPatternJson) take(keys []string) (any, bool) {
+	for _, key := range keys {
+		if value, ok := g._fields[key]; ok {
+			delete(g._fields, key)
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// takeString the value of the first present key as string
+func (g *GrokPatternJson) takeString(keys []string) (string, bool) {
+	value, ok := g.take(keys)
+	if !ok {
+		return "", false
+	}
+	return jsonString(value), true
+}
+
+func (g *GrokPatternJson) timeStamp() GrokPatternExtractor {
+	value, ok := g.take(g.profile.Timestamp)
+	if !ok {
+		return g._this
+	}
+	var parsedTs time.Time
+	if number, isNumber := value.(json.Number); isNumber {
+		parsedTs = epoch(number)
+	} else {
+		parsedTs = utils.ParseTime(g._metaLog, jsonString(value))
+	}
+	if parsedTs.IsZero() {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find timestamp for %v", value))
+		return g._this
+	}
+	g._metaLog.EcsLogEntry.Timestamp = timestamppb.New(parsedTs)
+	return g._this
+}
+
+func (g *GrokPatternJson) message() GrokPatternExtractor {
+	message, ok := g.takeString(g.profile.Message)
+	if !ok {
+		return g.GrokPatternDefault.message()
+	}
+	g._metaLog.EcsLogEntry.Message = message
+	return g._this
+}
+
+func (g *GrokPatternJson) hostInfo() GrokPatternExtractor {
+	host, ok := g.takeString(g.profile.Host)
+	if ok && !g._metaLog.EcsLogEntry.IsHostNameSet() {
+		g._metaLog.EcsLogEntry.SetHostName(host)
+	}
+	return g._this
+}
+
+// errorInfo the error is a string or an object with message, type (or name) and stack. A multiline string is an exception with stack trace
+func (g *GrokPatternJson) errorInfo() GrokPatternExtractor {
+	value, errorFound := g.take(g.profile.Error)
+	stackTrace, stackTraceFound := g.takeString(g.profile.StackTrace)
+	if !errorFound && !stackTraceFound {
+		return g._this
+	}
+	ecsError := &model.Error{StackTrace: stackTrace}
+	switch value := value.(type) {
+	case nil:
+	case map[string]any:
+		ecsError.Message = jsonString(value["message"])
+		ecsError.Type = jsonString(first(value, "type", "name"))
+		if stack := jsonString(value["stack"]); len(stack) > 0 {
+			ecsError.StackTrace = stack
+		}
+	default:
+		message := jsonString(value)
+		if firstLine, _, multiline := strings.Cut(message, "\n"); multiline {
+			ecsError.StackTrace = message
+			message = strings.TrimSpace(firstLine)
+		}
+		ecsError.Message = message
+	}
+	g._metaLog.EcsLogEntry.Error = ecsError
+	return g._this
+}
+
+// logInfo the caller is a string file:line or an object with file and line
+func (g *GrokPatternJson) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	if logger, ok := g.takeString(g.profile.Logger); ok {
+		ecs.SetLogger(logger)
+	}
+	if caller, ok := g.take(g.profile.Caller); ok {
+		switch caller := caller.(type) {
+		case map[string]any:
+			line := jsonString(caller["line"])
+			if len(line) == 0 {
+				line = "-1"
+			}
+			ecs.SetOriginFile(jsonString(caller["file"]), line)
+			ecs.Log.Origin.Function = jsonString(first(caller, "function", "func"))
+		default:
+			file, line := splitCaller(jsonString(caller))
+			ecs.SetOriginFile(file, line)
+		}
+	}
+	level, levelFound := g.takeString(g.profile.Level)
+	if !levelFound {
+		level = g.profile.DefaultLevel
+	}
+	if len(level) == 0 {
+		return g.GrokPatternDefault.logInfo()
+	}
+	if mapped, ok := g.profile.Levels[strings.ToLower(level)]; ok {
+		level = mapped
+	}
+	ecs.SetLogLevel(model.StringToLogLevel(level))
+	return g._this
+}
+
+func (g *GrokPatternJson) tracingInfo() GrokPatternExtractor {
+	traceId, traceFound := g.takeString(g.profile.TraceId)
+	spanId, _ := g.takeString(g.profile.SpanId)
+	if traceFound {
+		g._metaLog.EcsLogEntry.Trace = &model.Tracing{
+			Span:  &model.Tracing_Span{Id: spanId},
+			Trace: &model.Tracing_Trace{Id: traceId},
+		}
+	}
+	return g._this
+}
+
+func (g *GrokPatternJson) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the mapped keys
+	// Add the not mapped keys as labels. Nested objects are flattened with dots
+	if ecs.Labels == nil && len(g._fields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	flatten("json_", g._fields, ecs.Labels)
+	return ecs
+}
+
+func flatten(prefix string, fields map[string]any, labels map[string]string) {
+	for k, v := range fields {
+		if nested, ok := v.(map[string]any); ok {
+			flatten(prefix+k+".", nested, labels)
+			continue
+		}
+		labels[prefix+k] = jsonString(v)
+	}
+}
+
+// jsonString the string value or the json of the value
+func jsonString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return strings.TrimSuffix(buffer.String(), "\n")
+	}
+}
+
+// first the value of the first present key
+func first(fields map[string]any, keys ...string) any {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+// splitCaller a caller in the form file:line. The line is -1 if the caller has no line
+func splitCaller(caller string) (string, string) {
+	index := strings.LastIndex(caller, ":")
+	if index < 0 {
+		return caller, "-1"
+	}
+	if _, err := strconv.Atoi(caller[index+1:]); err != nil {
+		return caller, "-1"
+	}
+	return caller[:index], caller[index+1:]
+}
+
+// epoch a unix timestamp in seconds, millis, micros or nanos. The unit is guessed by the magnitude
+func epoch(number json.Number) time.Time {
+	value, err := number.Float64()
+	if err != nil || value <= 0 {
+		return time.Time{}
+	}
+	switch {
+	case value >= 1e17:
+		nanos, err := number.Int64()
+		if err != nil {
+			nanos = int64(value)
+		}
+		return time.Unix(0, nanos).UTC()
+	case value >= 1e14:
+		return time.UnixMicro(int64(value)).UTC()
+	case value >= 1e11:
+		return time.UnixMilli(int64(value)).UTC()
+	default:
+		seconds, fraction := math.Modf(value)
+		return time.Unix(int64(seconds), int64(math.Round(fraction*1e9))).UTC()
+	}
+}
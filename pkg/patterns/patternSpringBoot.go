@@ -0,0 +1,164 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The fields of the Spring Boot and Logback layouts that are not a utils.PatterMatch
+const (
+	springBootApplication = "application"
+	// springBootTime the time of a Logback timestamp without date
+	springBootTime = "time"
+)
+
+// GrokPatternSpringBoot extracts the default console layout of Spring Boot
+// For example 2023-10-06 00:17:09.669  INFO 12345 --- [           main] o.s.b.w.embedded.tomcat.TomcatWebServer  : Tomcat started
+// The default layout of Logback and Log4j2 is parsed, too. For example 00:17:09.669 [main] INFO  com.acme.App - Started
+type GrokPatternSpringBoot struct {
+	GrokPatternDefault
+	// Builder fields
+	_extractedFields map[string]string
+}
+
+func (g *GrokPatternSpringBoot) from(log *model.MetaLog) GrokPatternExtractor {
+	g._this = g
+	g._metaLog = log
+	g._extractedFields = map[string]string{}
+	for _, key := range []string{g.GrokPatternDefault.Name.String(), utils.PatternLogbackLayout} {
+		compilerFor := Instance().compilerFor(key)
+		if compilerFor == nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find a pattern for key %s", key))
+			return g._this
+		}
+		for k, v := range compilerFor.ParseString(log.RawMessage) {
+			// Skip the captures of the timestamp formats
+			if len(v) > 0 && (utils.IsRegisteredKey(k) || k == springBootApplication || k == springBootTime) {
+				g._extractedFields[k] = v
+			}
+		}
+		if len(g._extractedFields) > 0 {
+			return g._this
+		}
+	}
+	g._parseErrors = append(g._parseErrors, "Can't parse the Spring Boot layout")
+	return g._this
+}
+
+func (g *GrokPatternSpringBoot) timeStamp() GrokPatternExtractor {
+	defer func() {
+		delete(g._extractedFields, string(utils.PatternMatchTimeStamp))
+		delete(g._extractedFields, springBootTime)
+	}()
+	if tsstring, ok := g._extractedFields[string(utils.PatternMatchTimeStamp)]; ok {
+		parsedTs := utils.ParseTime(g._metaLog, tsstring)
+		if parsedTs.IsZero() {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find timestamp for %s", tsstring))
+			return g._this
+		}
+		g._metaLog.EcsLogEntry.Timestamp = timestamppb.New(parsedTs)
+		return g._this
+	}
+	// A time without date is a time of the day of the ingress timestamp
+	// Both are in UTC like the timestamps without zone of utils.ParseTime
+	if timestring, ok := g._extractedFields[springBootTime]; ok && g._metaLog.EcsLogEntry.Timestamp != nil {
+		// The fraction of the seconds is optional
+		parsedTime, err := time.ParseInLocation("15:04:05", strings.Replace(timestring, ",", ".", 1), time.UTC)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find timestamp for %s", timestring))
+			return g._this
+		}
+		ingress := g._metaLog.EcsLogEntry.Timestamp.AsTime().UTC()
+		ts := time.Date(ingress.Year(), ingress.Month(), ingress.Day(), parsedTime.Hour(), parsedTime.Minute(), parsedTime.Second(), parsedTime.Nanosecond(), time.UTC)
+		// The log is written before the ingress. A time far after the ingress is of the day before and vice versa
+		// For example 23:59:59 ingested at 00:00:01
+		switch diff := ts.Sub(ingress); {
+		case diff > 12*time.Hour:
+			ts = ts.AddDate(0, 0, -1)
+		case diff < -12*time.Hour:
+			ts = ts.AddDate(0, 0, 1)
+		}
+		g._metaLog.EcsLogEntry.Timestamp = timestamppb.New(ts)
+	}
+	return g._this
+}
+
+func (g *GrokPatternSpringBoot) message() GrokPatternExtractor {
+	message, ok := g._extractedFields[string(utils.PatternMatchKeyMessage)]
+	if !ok {
+		return g.GrokPatternDefault.message()
+	}
+	defer func() {
+		delete(g._extractedFields, string(utils.PatternMatchKeyMessage))
+	}()
+	g._metaLog.EcsLogEntry.Message = message
+	return g._this
+}
+
+// serviceInfo the application name of Spring Boot 3.4 if the ingress does not know the service
+func (g *GrokPatternSpringBoot) serviceInfo() GrokPatternExtractor {
+	application, ok := g._extractedFields[springBootApplication]
+	if !ok {
+		return g._this
+	}
+	defer func() {
+		delete(g._extractedFields, springBootApplication)
+	}()
+	if !g._metaLog.EcsLogEntry.IsServiceNameSet() {
+		g._metaLog.EcsLogEntry.SetSetServiceName(application)
+	}
+	return g._this
+}
+
+func (g *GrokPatternSpringBoot) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	defer func() {
+		for _, key := range []utils.PatterMatch{utils.PatternMatchKeyLevel, utils.PatternMatchKeyLogger, utils.PatternMatchKeyThread, utils.PatternMatchKeyPid} {
+			delete(g._extractedFields, string(key))
+		}
+	}()
+	if logger, ok := g._extractedFields[string(utils.PatternMatchKeyLogger)]; ok {
+		ecs.SetLogger(logger)
+	}
+	if thread, ok := g._extractedFields[string(utils.PatternMatchKeyThread)]; ok {
+		if ecs.Log == nil {
+			ecs.Log = &model.Log{}
+		}
+		ecs.Log.ThreadName = strings.TrimSpace(thread)
+	}
+	if pid, ok := g._extractedFields[string(utils.PatternMatchKeyPid)]; ok {
+		parsedPid, err := strconv.ParseInt(pid, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid pid %s", pid))
+		} else {
+			if ecs.Process == nil {
+				ecs.Process = &model.Process{}
+			}
+			ecs.Process.Pid = parsedPid
+		}
+	}
+	level, ok := g._extractedFields[string(utils.PatternMatchKeyLevel)]
+	if !ok {
+		return g.GrokPatternDefault.logInfo()
+	}
+	ecs.SetLogLevel(model.StringToLogLevel(level))
+	return g._this
+}
+
+func (g *GrokPatternSpringBoot) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the registered keys
+	// Add the not standard keys as labels
+	if ecs.Labels == nil && len(g._extractedFields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	for k, v := range g._extractedFields {
+		ecs.Labels["springboot_"+k] = v
+	}
+	return ecs
+}
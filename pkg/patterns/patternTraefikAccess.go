@@ -0,0 +1,170 @@
+package patterns
+
+import (
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"strconv"
+	"strings"
+)
+
+// The fields of the traefik json access log
+// https://doc.traefik.io/traefik/observability/access-logs/#list-of-all-available-fields
+const (
+	traefikAccessMethod           = "RequestMethod"
+	traefikAccessPath             = "RequestPath"
+	traefikAccessProtocol         = "RequestProtocol"
+	traefikAccessRequestBytes     = "RequestContentSize"
+	traefikAccessStatus           = "DownstreamStatus"
+	traefikAccessResponseBytes    = "DownstreamContentSize"
+	traefikAccessDuration         = "Duration"
+	traefikAccessClientHost       = "ClientHost"
+	traefikAccessClientUsername   = "ClientUsername"
+	traefikAccessRouterName       = "RouterName"
+	traefikAccessServiceName      = "ServiceName"
+	traefikAccessRequestUserAgent = "request_User-Agent"
+	traefikAccessRequestReferer   = "request_Referer"
+	// traefikAccessEmpty a field without value
+	traefikAccessEmpty = "-"
+)
+
+// jsonProfileTraefikAccess the keys of the traefik json access log that are mapped like every json log
+var jsonProfileTraefikAccess = &JsonProfile{
+	Name:      model.MetaLog_Traefik.String(),
+	Timestamp: []string{"time", "StartUTC"},
+	Message:   []string{"msg"},
+	Level:     []string{"level"},
+	TraceId:   []string{"TraceId"},
+	SpanId:    []string{"SpanId"},
+}
+
+// GrokPatternTraefikAccess extracts the json access log of traefik
+// For example {"ClientHost":"10.21.0.1","DownstreamStatus":404,"Duration":1234567,"RequestMethod":"GET","RequestPath":"/v1/jobs","RouterName":"api@docker"}
+// The router and the service of traefik are added as labels traefik_router and traefik_service
+// The keys that are not mapped are added as labels with the prefix traefik_
+type GrokPatternTraefikAccess struct {
+	GrokPatternJson
+}
+
+func (g *GrokPatternTraefikAccess) from(log *model.MetaLog) GrokPatternExtractor {
+	g.GrokPatternJson.from(log)
+	g._this = g
+	if len(g._fields) > 0 {
+		if _, ok := g._fields[traefikAccessMethod]; !ok {
+			g._parseErrors = append(g._parseErrors, "Can't find the request of the traefik access log")
+		}
+	}
+	return g._this
+}
+
+// message the request line of the access log. For example GET /v1/jobs HTTP/1.1 404
+func (g *GrokPatternTraefikAccess) message() GrokPatternExtractor {
+	message, _ := g.takeString(g.profile.Message)
+	if len(message) > 0 {
+		g._metaLog.EcsLogEntry.Message = message
+		return g._this
+	}
+	var parts []string
+	for _, key := range []string{traefikAccessMethod, traefikAccessPath, traefikAccessProtocol, traefikAccessStatus} {
+		if value, ok := g.field(key, traefikAccessEmpty); ok {
+			parts = append(parts, value)
+		}
+	}
+	if len(parts) == 0 {
+		return g.GrokPatternDefault.message()
+	}
+	g._metaLog.EcsLogEntry.Message = strings.Join(parts, " ")
+	return g._this
+}
+
+func (g *GrokPatternTraefikAccess) httpInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	defer func() {
+		for _, key := range []string{traefikAccessMethod, traefikAccessPath, traefikAccessProtocol, traefikAccessRequestBytes,
+			traefikAccessStatus, traefikAccessResponseBytes, traefikAccessDuration, traefikAccessClientHost,
+			traefikAccessClientUsername, traefikAccessRouterName, traefikAccessServiceName, traefikAccessRequestUserAgent,
+			traefikAccessRequestReferer} {
+			delete(g._fields, key)
+		}
+	}()
+	if client, ok := g.field(traefikAccessClientHost, traefikAccessEmpty); ok {
+		g.setSource(client)
+	}
+	if user, ok := g.field(traefikAccessClientUsername, traefikAccessEmpty); ok {
+		ecs.User = &model.User{Name: user}
+	}
+	if request, ok := g.field(traefikAccessPath, traefikAccessEmpty); ok {
+		path, query, _ := strings.Cut(request, "?")
+		ecs.Url = &model.Url{Original: request, Path: path, Query: query}
+	}
+	if userAgent, ok := g.field(traefikAccessRequestUserAgent, traefikAccessEmpty); ok {
+		ecs.UserAgent = &model.UserAgent{Original: userAgent}
+	}
+	if duration, ok := g.field(traefikAccessDuration, traefikAccessEmpty); ok {
+		nanos, err := strconv.ParseInt(duration, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid duration %s", duration))
+		} else {
+			if ecs.Event == nil {
+				ecs.Event = &model.Event{}
+			}
+			ecs.Event.Duration = nanos
+		}
+	}
+	for key, label := range map[string]string{traefikAccessRouterName: "traefik_router", traefikAccessServiceName: "traefik_service"} {
+		if value, ok := g.field(key, traefikAccessEmpty); ok {
+			if ecs.Labels == nil {
+				ecs.Labels = make(map[string]string)
+			}
+			ecs.Labels[label] = value
+		}
+	}
+	method, ok := g.field(traefikAccessMethod, traefikAccessEmpty)
+	if !ok {
+		return g._this
+	}
+	referrer, _ := g.field(traefikAccessRequestReferer, traefikAccessEmpty)
+	protocol, _ := g.field(traefikAccessProtocol, traefikAccessEmpty)
+	ecs.Http = &model.Http{
+		Request: &model.Http_Request{
+			Method:   method,
+			Referrer: referrer,
+			Bytes:    g.number(traefikAccessRequestBytes, traefikAccessEmpty),
+		},
+		Response: &model.Http_Response{
+			StatusCode: g.number(traefikAccessStatus, traefikAccessEmpty),
+			Bytes:      g.number(traefikAccessResponseBytes, traefikAccessEmpty),
+		},
+		Version: strings.TrimPrefix(protocol, "HTTP/"),
+	}
+	return g._this
+}
+
+// logInfo the level of the status code like GrokPatternClf. 5xx error, 4xx warn and info otherwise
+func (g *GrokPatternTraefikAccess) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	if ecs.Http == nil || ecs.Http.Response == nil || ecs.Http.Response.StatusCode == 0 {
+		return g.GrokPatternJson.logInfo()
+	}
+	// The level of traefik is always info
+	g.take(g.profile.Level)
+	switch status := ecs.Http.Response.StatusCode; {
+	case status >= 500:
+		ecs.SetLogLevel(model.LogLevel_error)
+	case status >= 400:
+		ecs.SetLogLevel(model.LogLevel_warn)
+	default:
+		ecs.SetLogLevel(model.LogLevel_info)
+	}
+	return g._this
+}
+
+func (g *GrokPatternTraefikAccess) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the mapped keys
+	// Add the not mapped keys as labels
+	if ecs.Labels == nil && len(g._fields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	flatten("traefik_", g._fields, ecs.Labels)
+	return ecs
+}
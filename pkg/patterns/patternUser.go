@@ -0,0 +1,323 @@
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"github.com/suikast42/logunifier/pkg/model"
+	"github.com/suikast42/logunifier/pkg/utils"
+	"github.com/trivago/grok"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The ecs fields that a capture of a user defined pattern can be mapped to
+// A capture without mapping is mapped to the field of its name if that is one of these. To the label pattern_<capture> otherwise
+const (
+	UserFieldTimestamp     = "@timestamp"
+	UserFieldMessage       = "message"
+	UserFieldLevel         = "level"
+	UserFieldLogger        = "log.logger"
+	UserFieldOriginFile    = "log.origin.file.name"
+	UserFieldOriginLine    = "log.origin.file.line"
+	UserFieldErrorMessage  = "error.message"
+	UserFieldErrorType     = "error.type"
+	UserFieldStackTrace    = "error.stack_trace"
+	UserFieldTraceId       = "trace.id"
+	UserFieldSpanId        = "span.id"
+	UserFieldUser          = "user.name"
+	UserFieldHttpMethod    = "http.request.method"
+	UserFieldHttpStatus    = "http.response.status_code"
+	UserFieldHttpBytes     = "http.response.bytes"
+	UserFieldUrl           = "url.original"
+	UserFieldSource        = "source.address"
+	UserFieldUserAgent     = "user_agent.original"
+	UserFieldLabelPrefix   = "labels."
+	userFieldTimestampName = "timestamp"
+)
+
+var userFields = map[string]bool{
+	UserFieldTimestamp:    true,
+	UserFieldMessage:      true,
+	UserFieldLevel:        true,
+	UserFieldLogger:       true,
+	UserFieldOriginFile:   true,
+	UserFieldOriginLine:   true,
+	UserFieldErrorMessage: true,
+	UserFieldErrorType:    true,
+	UserFieldStackTrace:   true,
+	UserFieldTraceId:      true,
+	UserFieldSpanId:       true,
+	UserFieldUser:         true,
+	UserFieldHttpMethod:   true,
+	UserFieldHttpStatus:   true,
+	UserFieldHttpBytes:    true,
+	UserFieldUrl:          true,
+	UserFieldSource:       true,
+	UserFieldUserAgent:    true,
+}
+
+// UserPatternDefinition the patterns and json profiles declared by configuration
+type UserPatternDefinition struct {
+	Patterns     []UserPattern  `yaml:"patterns"`
+	JsonProfiles []*JsonProfile `yaml:"jsonProfiles"`
+}
+
+// UserPattern a named grok pattern. The name is referenced like a built-in pattern key
+// For example by the label com.github.logunifier.application.pattern.key
+type UserPattern struct {
+	Name string `yaml:"name"`
+	// Grok the expression. The built-in and the custom patterns of utils.CustomPatterns can be used
+	Grok string `yaml:"grok"`
+	// Fields maps a capture to an ecs field. See UserFieldTimestamp and the other fields
+	Fields map[string]string `yaml:"fields"`
+	// TimestampLayout the go time layout of the timestamp. The standard formats of utils.StandardTimeFormats are tried if empty
+	TimestampLayout string `yaml:"timestampLayout"`
+}
+
+type compiledUserPattern struct {
+	UserPattern
+	compiled *grok.CompiledGrok
+}
+
+// ParseUserPatterns a yaml pattern definition and validate it
+func ParseUserPatterns(content []byte) (*UserPatternDefinition, error) {
+	definition := &UserPatternDefinition{}
+	err := yaml.Unmarshal(content, definition)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	var errs []error
+	validateName := func(i int, name string) {
+		switch {
+		case len(name) == 0:
+			errs = append(errs, fmt.Errorf("pattern %d has no name", i))
+		case model.IsBuiltInPatternKey(name) || isBuiltInJsonProfile(name):
+			errs = append(errs, fmt.Errorf("pattern %s overrides a built-in pattern key", name))
+		case names[name]:
+			errs = append(errs, fmt.Errorf("pattern %s is declared twice", name))
+		}
+		names[name] = true
+	}
+	for i, pattern := range definition.Patterns {
+		name := strings.ToLower(pattern.Name)
+		definition.Patterns[i].Name = name
+		validateName(i, name)
+		if len(pattern.Grok) == 0 {
+			errs = append(errs, fmt.Errorf("pattern %s has no grok expression", name))
+		}
+		for capture, field := range pattern.Fields {
+			if !isUserField(field) {
+				errs = append(errs, fmt.Errorf("pattern %s maps %s to the unknown field %s", name, capture, field))
+			}
+		}
+	}
+	for i, profile := range definition.JsonProfiles {
+		profile.Name = strings.ToLower(profile.Name)
+		validateName(len(definition.Patterns)+i, profile.Name)
+	}
+	return definition, errors.Join(errs...)
+}
+
+func isUserField(field string) bool {
+	if label, found := strings.CutPrefix(field, UserFieldLabelPrefix); found {
+		return len(label) > 0
+	}
+	return userFields[field]
+}
+
+// addUserPatterns compiles the patterns with the patterns of the factory and registers their names and the names of the json profiles
+func (factory *PatternFactory) addUserPatterns(definition *UserPatternDefinition) error {
+	g, err := grok.New(grok.Config{
+		Patterns:            factory.patterns,
+		SkipDefaultPatterns: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, pattern := range definition.Patterns {
+		compiled, err := g.Compile(pattern.Grok)
+		if err != nil {
+			return fmt.Errorf("cannot compile pattern %s with value %s: %w", pattern.Name, pattern.Grok, err)
+		}
+		factory.userPatterns[pattern.Name] = &compiledUserPattern{UserPattern: pattern, compiled: compiled}
+		model.RegisterUserPatternKey(pattern.Name)
+	}
+	factory.addJsonProfiles(definition.JsonProfiles)
+	return nil
+}
+
+// GrokPatternUser extracts a log with a pattern declared by configuration
+type GrokPatternUser struct {
+	GrokPatternDefault
+	pattern *compiledUserPattern
+	// Builder fields
+	_fields map[string]string
+}
+
+func (g *GrokPatternUser) from(log *model.MetaLog) GrokPatternExtractor {
+	g._this = g
+	g._metaLog = log
+	g._fields = map[string]string{}
+	for capture, value := range g.pattern.compiled.ParseString(log.RawMessage) {
+		if len(value) == 0 {
+			continue
+		}
+		field, ok := g.pattern.Fields[capture]
+		switch {
+		case ok:
+		case capture == userFieldTimestampName:
+			field = UserFieldTimestamp
+		case userFields[capture]:
+			field = capture
+		default:
+			field = UserFieldLabelPrefix + "pattern_" + capture
+		}
+		g._fields[field] = value
+	}
+	if len(g._fields) == 0 {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't parse with the pattern %s", g.pattern.Name))
+	}
+	return g._this
+}
+
+// take the value of the field and remove it
+func (g *GrokPatternUser) take(field string) (string, bool) {
+	value, ok := g._fields[field]
+	delete(g._fields, field)
+	return value, ok
+}
+
+func (g *GrokPatternUser) timeStamp() GrokPatternExtractor {
+	tsstring, ok := g.take(UserFieldTimestamp)
+	if !ok {
+		return g._this
+	}
+	var parsedTs time.Time
+	if len(g.pattern.TimestampLayout) > 0 {
+		parsedTs, _ = time.ParseInLocation(g.pattern.TimestampLayout, tsstring, time.UTC)
+	} else {
+		parsedTs = utils.ParseTime(g._metaLog, tsstring)
+	}
+	if parsedTs.IsZero() {
+		g._parseErrors = append(g._parseErrors, fmt.Sprintf("Can't find timestamp for %s", tsstring))
+		return g._this
+	}
+	g._metaLog.EcsLogEntry.Timestamp = timestamppb.New(parsedTs.UTC())
+	return g._this
+}
+
+func (g *GrokPatternUser) message() GrokPatternExtractor {
+	message, ok := g.take(UserFieldMessage)
+	if !ok {
+		g._metaLog.EcsLogEntry.Message = g._metaLog.RawMessage
+		return g._this
+	}
+	g._metaLog.EcsLogEntry.Message = message
+	return g._this
+}
+
+func (g *GrokPatternUser) errorInfo() GrokPatternExtractor {
+	message, messageFound := g.take(UserFieldErrorMessage)
+	errorType, typeFound := g.take(UserFieldErrorType)
+	stackTrace, stackTraceFound := g.take(UserFieldStackTrace)
+	if messageFound || typeFound || stackTraceFound {
+		g._metaLog.EcsLogEntry.Error = &model.Error{
+			Message:    message,
+			Type:       errorType,
+			StackTrace: stackTrace,
+		}
+	}
+	return g._this
+}
+
+func (g *GrokPatternUser) httpInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	if user, ok := g.take(UserFieldUser); ok {
+		ecs.User = &model.User{Name: user}
+	}
+	if url, ok := g.take(UserFieldUrl); ok {
+		path, query, _ := strings.Cut(url, "?")
+		ecs.Url = &model.Url{Original: url, Path: path, Query: query}
+	}
+	if source, ok := g.take(UserFieldSource); ok {
+		ecs.Source = &model.Source{Address: source}
+	}
+	if userAgent, ok := g.take(UserFieldUserAgent); ok {
+		ecs.UserAgent = &model.UserAgent{Original: userAgent}
+	}
+	method, methodFound := g.take(UserFieldHttpMethod)
+	status, statusFound := g.take(UserFieldHttpStatus)
+	bytes, bytesFound := g.take(UserFieldHttpBytes)
+	if !methodFound && !statusFound && !bytesFound {
+		return g._this
+	}
+	ecs.Http = &model.Http{
+		Request:  &model.Http_Request{Method: method},
+		Response: &model.Http_Response{},
+	}
+	if statusFound {
+		code, err := strconv.ParseInt(status, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid status code %s", status))
+		}
+		ecs.Http.Response.StatusCode = code
+	}
+	if bytesFound {
+		size, err := strconv.ParseInt(bytes, 10, 64)
+		if err != nil {
+			g._parseErrors = append(g._parseErrors, fmt.Sprintf("Invalid response size %s", bytes))
+		}
+		ecs.Http.Response.Bytes = size
+	}
+	return g._this
+}
+
+func (g *GrokPatternUser) logInfo() GrokPatternExtractor {
+	ecs := g._metaLog.EcsLogEntry
+	if logger, ok := g.take(UserFieldLogger); ok {
+		ecs.SetLogger(logger)
+	}
+	origin, originFound := g.take(UserFieldOriginFile)
+	line, lineFound := g.take(UserFieldOriginLine)
+	if originFound {
+		if !lineFound {
+			line = "-1"
+		}
+		ecs.SetOriginFile(origin, line)
+	}
+	level, levelFound := g.take(UserFieldLevel)
+	if !levelFound {
+		return g.GrokPatternDefault.logInfo()
+	}
+	ecs.SetLogLevel(model.StringToLogLevel(level))
+	return g._this
+}
+
+func (g *GrokPatternUser) tracingInfo() GrokPatternExtractor {
+	traceId, traceFound := g.take(UserFieldTraceId)
+	spanId, _ := g.take(UserFieldSpanId)
+	if traceFound {
+		g._metaLog.EcsLogEntry.Trace = &model.Tracing{
+			Span:  &model.Tracing_Span{Id: spanId},
+			Trace: &model.Tracing_Trace{Id: traceId},
+		}
+	}
+	return g._this
+}
+
+func (g *GrokPatternUser) extract() *model.EcsLogEntry {
+	ecs := g.GrokPatternDefault.extract()
+	// Every step removes the mapped fields
+	// The labels are left
+	if ecs.Labels == nil && len(g._fields) > 0 {
+		ecs.Labels = make(map[string]string)
+	}
+	for field, value := range g._fields {
+		ecs.Labels[strings.TrimPrefix(field, UserFieldLabelPrefix)] = value
+	}
+	return ecs
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/suikast42/logunifier/pkg/utils"
 	"github.com/trivago/grok"
 	additionalPatterns "github.com/trivago/grok/patterns"
+	"os"
 	"strings"
 	"sync"
 )
@@ -17,6 +18,15 @@ type PatternFactory struct {
 	logger    *zerolog.Logger
 	patterns  map[string]string
 	compilers map[string]*grok.CompiledGrok
+	// userPatterns the patterns declared by configuration. Keyed by the lower case name
+	userPatterns map[string]*compiledUserPattern
+	// jsonProfiles the built-in and the user defined json profiles. Keyed by the lower case name
+	jsonProfiles map[string]*JsonProfile
+	// autoDetect detect the pattern of the logs without pattern key
+	autoDetect          bool
+	detectedPatternsMtx sync.RWMutex
+	// detectedPatterns the detected patterns per service name and version
+	detectedPatterns map[string]detectedPattern
 }
 
 func (factory *PatternFactory) CompilerFor(key model.MetaLog_PatternKey) *grok.CompiledGrok {
@@ -87,15 +97,46 @@ func Initialize() (*PatternFactory, error) {
 		compiledPatterns[k] = compiled
 	}
 	logger := config.Logger()
-	instance = &PatternFactory{
-		patterns:  addPatterns,
-		compilers: compiledPatterns,
-		logger:    &logger,
+	factory := &PatternFactory{
+		patterns:         addPatterns,
+		compilers:        compiledPatterns,
+		userPatterns:     make(map[string]*compiledUserPattern),
+		jsonProfiles:     make(map[string]*JsonProfile),
+		detectedPatterns: make(map[string]detectedPattern),
+		logger:           &logger,
 	}
+	factory.addJsonProfiles(builtInJsonProfiles)
+	if cfg, err := config.Instance(); err == nil {
+		factory.autoDetect = cfg.PatternAutoDetect()
+		if len(cfg.PatternConfig()) > 0 {
+			err := factory.loadUserPatterns(cfg.PatternConfig())
+			if err != nil {
+				return nil, fmt.Errorf("can't load the user defined patterns %s: %w", cfg.PatternConfig(), err)
+			}
+		}
+	}
+	instance = factory
 
 	return instance, nil
 }
 
+func (factory *PatternFactory) loadUserPatterns(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	definition, err := ParseUserPatterns(content)
+	if err != nil {
+		return err
+	}
+	err = factory.addUserPatterns(definition)
+	if err != nil {
+		return err
+	}
+	factory.logger.Info().Msgf("Loaded %d user defined patterns and %d json profiles from %s", len(definition.Patterns), len(definition.JsonProfiles), file)
+	return nil
+}
+
 func add(source map[string]string, new map[string]string) error {
 
 	for k, v := range new {
@@ -116,14 +157,27 @@ func (factory *PatternFactory) Parse(log *model.MetaLog) *model.EcsLogEntry {
 		log.EcsLogEntry.SetLogLevel(model.LogLevel_fatal)
 		return log.EcsLogEntry
 	}
-	// Native Ecs.
-	if log.PatternKey == model.MetaLog_Ecs {
+	// Native Ecs or already structured by the ingress
+	if log.PatternKey == model.MetaLog_Ecs || log.PatternKey == model.MetaLog_Otlp {
 		return log.EcsLogEntry
 	}
+	if factory.autoDetect && log.PatternKey == model.MetaLog_Nop && !factory.isUserPattern(log) {
+		return factory.detect(log)
+	}
 	extractor := factory.findPatternFor(log)
 	return ExtractFrom(extractor, log)
 }
 
+// isUserPattern true if the ingress requested a user defined pattern or a json profile
+func (factory *PatternFactory) isUserPattern(log *model.MetaLog) bool {
+	if log.EcsLogEntry.Log == nil {
+		return false
+	}
+	_, pattern := factory.userPatterns[log.EcsLogEntry.Log.PatternKey]
+	_, profile := factory.jsonProfiles[log.EcsLogEntry.Log.PatternKey]
+	return pattern || profile
+}
+
 func (factory *PatternFactory) findPatternFor(log *model.MetaLog) GrokPatternExtractor {
 
 	switch log.PatternKey {
@@ -135,9 +189,48 @@ func (factory *PatternFactory) findPatternFor(log *model.MetaLog) GrokPatternExt
 				},
 			},
 		}
-	case model.MetaLog_TsLevelMsg,
-		model.MetaLog_Traefik,
-		model.MetaLog_Envoy:
+	case model.MetaLog_Traefik:
+		// Traefik logs the access log and its application log to the same output
+		if isJsonObject(log.RawMessage) {
+			return &GrokPatternTraefikAccess{
+				GrokPatternJson: GrokPatternJson{
+					GrokPatternDefault: GrokPatternDefault{
+						GrokPattern: GrokPattern{
+							Name: log.PatternKey,
+						},
+					},
+					profile: jsonProfileTraefikAccess,
+				},
+			}
+		}
+		return &GrokPatternTsLevelMsg{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+		}
+	case model.MetaLog_Envoy:
+		if isEnvoyAccessLog(log.RawMessage) {
+			return &GrokPatternEnvoyAccess{
+				GrokPatternJson: GrokPatternJson{
+					GrokPatternDefault: GrokPatternDefault{
+						GrokPattern: GrokPattern{
+							Name: log.PatternKey,
+						},
+					},
+					profile: jsonProfileEnvoyAccess,
+				},
+			}
+		}
+		return &GrokPatternTsLevelMsg{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+		}
+	case model.MetaLog_TsLevelMsg:
 		return &GrokPatternTsLevelMsg{
 			GrokPatternDefault: GrokPatternDefault{
 				GrokPattern: GrokPattern{
@@ -146,8 +239,80 @@ func (factory *PatternFactory) findPatternFor(log *model.MetaLog) GrokPatternExt
 			},
 		}
 
-		//case model.MetaLog_Ecs:
+	case model.MetaLog_Clf:
+		return &GrokPatternClf{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+		}
+
+	case model.MetaLog_SpringBoot:
+		return &GrokPatternSpringBoot{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+		}
+
+	case model.MetaLog_Hclog:
+		return &GrokPatternHclog{
+			GrokPatternJson: GrokPatternJson{
+				GrokPatternDefault: GrokPatternDefault{
+					GrokPattern: GrokPattern{
+						Name: log.PatternKey,
+					},
+				},
+				profile: jsonProfileHclog,
+			},
+		}
+
+	case model.MetaLog_Json:
+		return &GrokPatternJson{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+			profile: JsonProfileGeneric,
+		}
+
+	case model.MetaLog_Ecs:
+		// Only the pattern detection extracts ecs. The ecs logs of the ingress are not parsed
+		return &GrokPatternEcs{
+			GrokPatternDefault: GrokPatternDefault{
+				GrokPattern: GrokPattern{
+					Name: log.PatternKey,
+				},
+			},
+		}
+
 	case model.MetaLog_Nop:
+		// The ingress keeps the name of a user defined pattern or a json profile in the log
+		if log.EcsLogEntry.Log != nil {
+			if pattern, ok := factory.userPatterns[log.EcsLogEntry.Log.PatternKey]; ok {
+				return &GrokPatternUser{
+					GrokPatternDefault: GrokPatternDefault{
+						GrokPattern: GrokPattern{
+							Name: model.MetaLog_Nop,
+						},
+					},
+					pattern: pattern,
+				}
+			}
+			if profile, ok := factory.jsonProfiles[log.EcsLogEntry.Log.PatternKey]; ok {
+				return &GrokPatternJson{
+					GrokPatternDefault: GrokPatternDefault{
+						GrokPattern: GrokPattern{
+							Name: model.MetaLog_Nop,
+						},
+					},
+					profile: profile,
+				}
+			}
+		}
 		return &GrokPatternDefault{
 			GrokPattern: GrokPattern{
 				Name: model.MetaLog_Nop,
@@ -1,6 +1,9 @@
 package patterns
 
 import (
+	"github.com/suikast42/logunifier/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"os"
 	"testing"
 	"time"
 )
@@ -28,3 +31,825 @@ func TestTsLevelMsgPattern(t *testing.T) {
 		t.Error("Expected no zero but got zero")
 	}
 }
+
+// The labels of a log entry are nil if the ingress has none. The leftover fields are labels then
+func TestTsLevelMsgLeftoverFields(t *testing.T) {
+	ecs := patternfactory.Parse(&model.MetaLog{
+		PatternKey:  model.MetaLog_Envoy,
+		RawMessage:  `[2023-10-06 00:17:09.669][12][info][main] [source/server/server.cc:404] initializing epoch 0`,
+		EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+	})
+	if ecs.HasProcessError() {
+		t.Errorf("Expected no parse error but got %s", ecs.ProcessError.Reason)
+	}
+	if ecs.Labels["pattern_thread"] != "12" {
+		t.Errorf("Expected label pattern_thread=12 but got %v", ecs.Labels)
+	}
+}
+
+func TestClfPattern(t *testing.T) {
+	tests := []struct {
+		pos        int
+		data       string
+		level      model.LogLevel
+		status     int64
+		bytes      int64
+		path       string
+		query      string
+		user       string
+		userAgent  string
+		parseError bool
+	}{
+		{
+			pos:    1,
+			data:   `10.21.0.1 - - [01/Apr/2023:08:33:52 +0000] "GET /v1/acl/token/self HTTP/2.0" 400 44 "-" "-" 79 "nomad@file" "https://10.21.21.41:4646" 7ms`,
+			level:  model.LogLevel_warn,
+			status: 400,
+			bytes:  44,
+			path:   "/v1/acl/token/self",
+		},
+		{
+			pos:       2,
+			data:      `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "POST /apache_pb.gif?id=1 HTTP/1.0" 503 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			level:     model.LogLevel_error,
+			status:    503,
+			bytes:     2326,
+			path:      "/apache_pb.gif",
+			query:     "id=1",
+			user:      "frank",
+			userAgent: "Mozilla/4.08",
+		},
+		{
+			pos:    3,
+			data:   `localhost - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 304 - "-" "-"`,
+			level:  model.LogLevel_info,
+			status: 304,
+			path:   "/",
+		},
+		{
+			pos:        4,
+			data:       `not an access log`,
+			level:      model.LogLevel_unknown,
+			parseError: true,
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.StringToLogPatternKey("clf"),
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+		})
+		if ecs.Log.Level != test.level {
+			t.Errorf("Pos %d: expected level %s but got %s", test.pos, test.level, ecs.Log.Level)
+		}
+		if ecs.HasProcessError() != test.parseError {
+			t.Errorf("Pos %d: expected parse error %t but got %v", test.pos, test.parseError, ecs.ProcessError)
+		}
+		if test.parseError {
+			continue
+		}
+		if ecs.Http.Request.Method == "" || ecs.Http.Response.StatusCode != test.status || ecs.Http.Response.Bytes != test.bytes {
+			t.Errorf("Pos %d: expected status %d with %d bytes but got %v", test.pos, test.status, test.bytes, ecs.Http)
+		}
+		if ecs.Url.Path != test.path || ecs.Url.Query != test.query {
+			t.Errorf("Pos %d: expected path %s and query %s but got %v", test.pos, test.path, test.query, ecs.Url)
+		}
+		if ecs.Source == nil || len(ecs.Source.Address) == 0 {
+			t.Errorf("Pos %d: expected the client as source but got %v", test.pos, ecs.Source)
+		}
+		if (ecs.User != nil && ecs.User.Name != test.user) || (ecs.User == nil && test.user != "") {
+			t.Errorf("Pos %d: expected user %s but got %v", test.pos, test.user, ecs.User)
+		}
+		if (ecs.UserAgent != nil && ecs.UserAgent.Original != test.userAgent) || (ecs.UserAgent == nil && test.userAgent != "") {
+			t.Errorf("Pos %d: expected user agent %s but got %v", test.pos, test.userAgent, ecs.UserAgent)
+		}
+		if ecs.Timestamp == nil || ecs.Timestamp.AsTime().Year() < 2000 {
+			t.Errorf("Pos %d: expected the access time but got %v", test.pos, ecs.Timestamp)
+		}
+	}
+}
+
+func TestUserPattern(t *testing.T) {
+	content, err := os.ReadFile("../../internal/config/patterns.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	definition, err := ParseUserPatterns(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = patternfactory.addUserPatterns(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pos        int
+		data       string
+		level      model.LogLevel
+		message    string
+		ts         string
+		labels     map[string]string
+		parseError bool
+	}{
+		{
+			pos:     1,
+			data:    `2023/10/06 00:17:09 [error] 31#31: *1 open() "/usr/share/nginx/html/favicon.ico" failed`,
+			level:   model.LogLevel_error,
+			message: `*1 open() "/usr/share/nginx/html/favicon.ico" failed`,
+			ts:      "2023-10-06T00:17:09Z",
+			labels:  map[string]string{"pid": "31", "pattern_tid": "31"},
+		},
+		{
+			pos:        2,
+			data:       `not an nginx error log`,
+			level:      model.LogLevel_unknown,
+			message:    `not an nginx error log`,
+			parseError: true,
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.StringToLogPatternKey("NGINX_ERROR"),
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{PatternKey: model.PatternKeyName("NGINX_ERROR")}},
+		})
+		if ecs.Log.Level != test.level {
+			t.Errorf("Pos %d: expected level %s but got %s", test.pos, test.level, ecs.Log.Level)
+		}
+		if ecs.Message != test.message {
+			t.Errorf("Pos %d: expected message %s but got %s", test.pos, test.message, ecs.Message)
+		}
+		if ecs.HasProcessError() != test.parseError {
+			t.Errorf("Pos %d: expected parse error %t but got %v", test.pos, test.parseError, ecs.ProcessError)
+		}
+		if test.parseError {
+			continue
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		for name, value := range test.labels {
+			if ecs.Labels[name] != value {
+				t.Errorf("Pos %d: expected label %s=%s but got %v", test.pos, name, value, ecs.Labels)
+			}
+		}
+	}
+}
+
+func TestParseUserPatterns(t *testing.T) {
+	tests := []struct {
+		pos     int
+		content string
+		valid   bool
+	}{
+		{pos: 1, content: "patterns:\n  - name: app\n    grok: '%{GREEDYDATA:message}'\n    fields:\n      message: labels.text", valid: true},
+		{pos: 2, content: "patterns:\n  - name: logfmt\n    grok: '%{GREEDYDATA:message}'"},
+		{pos: 3, content: "patterns:\n  - name: app\n    grok: '%{GREEDYDATA:message}'\n  - name: APP\n    grok: '%{GREEDYDATA:message}'"},
+		{pos: 4, content: "patterns:\n  - name: app"},
+		{pos: 5, content: "patterns:\n  - grok: '%{GREEDYDATA:message}'"},
+		{pos: 6, content: "patterns:\n  - name: app\n    grok: '%{GREEDYDATA:message}'\n    fields:\n      message: unknown"},
+		{pos: 7, content: "patterns:\n  - name: app\n    grok: '%{GREEDYDATA:message}'\n    fields:\n      message: 'labels.'"},
+		{pos: 8, content: "jsonProfiles:\n  - name: app\n    message: [ text ]", valid: true},
+		{pos: 9, content: "jsonProfiles:\n  - name: zap\n    message: [ text ]"},
+		{pos: 10, content: "patterns:\n  - name: app\n    grok: '%{GREEDYDATA:message}'\njsonProfiles:\n  - name: app"},
+	}
+	for _, test := range tests {
+		_, err := ParseUserPatterns([]byte(test.content))
+		if (err == nil) != test.valid {
+			t.Errorf("Pos %d: expected valid %t but got %v", test.pos, test.valid, err)
+		}
+	}
+}
+
+func TestJsonPattern(t *testing.T) {
+	content, err := os.ReadFile("../../internal/config/patterns.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	definition, err := ParseUserPatterns(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = patternfactory.addUserPatterns(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pos        int
+		key        string
+		data       string
+		level      model.LogLevel
+		message    string
+		ts         string
+		logger     string
+		origin     string
+		errMessage string
+		traceId    string
+		labels     map[string]string
+		parseError bool
+	}{
+		{
+			pos:     1,
+			key:     "json",
+			data:    `{"time":"2023-10-06T00:17:09Z","level":"warn","msg":"disk almost full","free":{"bytes":1024},"trace_id":"4bf92f3577b34da6"}`,
+			level:   model.LogLevel_warn,
+			message: "disk almost full",
+			ts:      "2023-10-06T00:17:09Z",
+			traceId: "4bf92f3577b34da6",
+			labels:  map[string]string{"json_free.bytes": "1024"},
+		},
+		{
+			pos:        2,
+			key:        "zap",
+			data:       `{"level":"error","ts":1696551429.5,"logger":"billing","caller":"invoice/service.go:42","msg":"invoice failed","error":"connection refused","stacktrace":"main.run\n\tmain.go:12"}`,
+			level:      model.LogLevel_error,
+			message:    "invoice failed",
+			ts:         "2023-10-06T00:17:09Z",
+			logger:     "billing",
+			origin:     "invoice/service.go:42",
+			errMessage: "connection refused",
+		},
+		{
+			pos:        3,
+			key:        "pino",
+			data:       `{"level":50,"time":1696551429669,"pid":7,"hostname":"worker-01","name":"api","msg":"request failed","err":{"type":"Error","message":"boom","stack":"Error: boom\n    at main.js:1"}}`,
+			level:      model.LogLevel_error,
+			message:    "request failed",
+			ts:         "2023-10-06T00:17:09Z",
+			logger:     "api",
+			errMessage: "boom",
+			labels:     map[string]string{"json_pid": "7"},
+		},
+		{
+			pos:     4,
+			key:     "bunyan",
+			data:    `{"name":"api","hostname":"worker-01","pid":7,"level":30,"msg":"started","time":"2023-10-06T00:17:09.000Z","src":{"file":"/app/index.js","line":12,"func":"start"},"v":0}`,
+			level:   model.LogLevel_info,
+			message: "started",
+			ts:      "2023-10-06T00:17:09Z",
+			logger:  "api",
+			origin:  "/app/index.js:12",
+			labels:  map[string]string{"json_v": "0"},
+		},
+		{
+			pos:        5,
+			key:        "serilog",
+			data:       `{"@t":"2023-10-06T00:17:09.0000000Z","@mt":"Processed {Count} items","Count":5,"SourceContext":"Billing.Worker","@x":"System.Exception: boom\n   at Billing.Worker.Run()"}`,
+			level:      model.LogLevel_info,
+			message:    "Processed {Count} items",
+			ts:         "2023-10-06T00:17:09Z",
+			logger:     "Billing.Worker",
+			errMessage: "System.Exception: boom",
+			labels:     map[string]string{"json_Count": "5"},
+		},
+		{
+			pos:     6,
+			key:     "slog",
+			data:    `{"time":"2023-10-06T00:17:09Z","level":"DEBUG","source":{"function":"main.main","file":"/app/main.go","line":21},"msg":"hello","user":"frank"}`,
+			level:   model.LogLevel_debug,
+			message: "hello",
+			ts:      "2023-10-06T00:17:09Z",
+			origin:  "/app/main.go:21",
+			labels:  map[string]string{"json_user": "frank"},
+		},
+		{
+			pos:     7,
+			key:     "logrus",
+			data:    `{"file":"/app/main.go:30","func":"main.main","level":"info","msg":"A walrus appears","size":10,"time":"2023-10-06T00:17:09Z"}`,
+			level:   model.LogLevel_info,
+			message: "A walrus appears",
+			ts:      "2023-10-06T00:17:09Z",
+			origin:  "/app/main.go:30",
+			labels:  map[string]string{"json_size": "10", "json_func": "main.main"},
+		},
+		{
+			pos:     8,
+			key:     "billing_json",
+			data:    `{"eventTime":"2023-10-06T00:17:09Z","prio":2,"text":"retry","component":"payments","correlation":"abc"}`,
+			level:   model.LogLevel_warn,
+			message: "retry",
+			ts:      "2023-10-06T00:17:09Z",
+			logger:  "payments",
+			traceId: "abc",
+		},
+		{
+			pos:        9,
+			key:        "json",
+			data:       `not a json log`,
+			level:      model.LogLevel_unknown,
+			message:    "not a json log",
+			parseError: true,
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.StringToLogPatternKey(test.key),
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{PatternKey: model.PatternKeyName(test.key)}},
+		})
+		if ecs.Log.Level != test.level {
+			t.Errorf("Pos %d: expected level %s but got %s", test.pos, test.level, ecs.Log.Level)
+		}
+		if ecs.Message != test.message {
+			t.Errorf("Pos %d: expected message %s but got %s", test.pos, test.message, ecs.Message)
+		}
+		if ecs.HasProcessError() != test.parseError {
+			t.Errorf("Pos %d: expected parse error %t but got %v", test.pos, test.parseError, ecs.ProcessError)
+		}
+		if test.parseError {
+			continue
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		if ecs.Log.Logger != test.logger {
+			t.Errorf("Pos %d: expected logger %s but got %s", test.pos, test.logger, ecs.Log.Logger)
+		}
+		if len(test.origin) > 0 && (ecs.Log.Origin == nil || ecs.Log.Origin.File.Name+":"+ecs.Log.Origin.File.Line != test.origin) {
+			t.Errorf("Pos %d: expected origin %s but got %v", test.pos, test.origin, ecs.Log.Origin)
+		}
+		if len(test.errMessage) > 0 && (ecs.Error == nil || ecs.Error.Message != test.errMessage || len(ecs.Error.StackTrace) == 0) {
+			t.Errorf("Pos %d: expected error %s with stack trace but got %v", test.pos, test.errMessage, ecs.Error)
+		}
+		if len(test.traceId) > 0 && (ecs.Trace == nil || ecs.Trace.Trace.Id != test.traceId) {
+			t.Errorf("Pos %d: expected trace id %s but got %v", test.pos, test.traceId, ecs.Trace)
+		}
+		for name, value := range test.labels {
+			if ecs.Labels[name] != value {
+				t.Errorf("Pos %d: expected label %s=%s but got %v", test.pos, name, value, ecs.Labels)
+			}
+		}
+	}
+}
+
+func TestAutoDetectPattern(t *testing.T) {
+	patternfactory.autoDetect = true
+	defer func() {
+		patternfactory.autoDetect = false
+	}()
+	tests := []struct {
+		pos     int
+		service string
+		data    string
+		key     model.MetaLog_PatternKey
+		level   model.LogLevel
+		message string
+	}{
+		{pos: 1, service: "ecs", data: `{"@timestamp":"2023-10-06T00:17:09Z","log":{"level":"warn"},"message":"disk almost full"}`, key: model.MetaLog_Ecs, level: model.LogLevel_warn, message: "disk almost full"},
+		{pos: 2, service: "json", data: `{"time":"2023-10-06T00:17:09Z","level":"warn","msg":"disk almost full"}`, key: model.MetaLog_Json, level: model.LogLevel_warn, message: "disk almost full"},
+		{pos: 3, service: "logfmt", data: `ts=2023-10-06T00:17:09Z level=warn msg="disk almost full"`, key: model.MetaLog_LogFmt, level: model.LogLevel_warn, message: "disk almost full"},
+		{pos: 4, service: "clf", data: `10.21.0.1 - - [01/Apr/2023:08:33:52 +0000] "GET /v1/acl/token/self HTTP/2.0" 400 44 "-" "curl/8.0.1"`, key: model.MetaLog_Clf, level: model.LogLevel_warn, message: `10.21.0.1 - - [01/Apr/2023:08:33:52 +0000] "GET /v1/acl/token/self HTTP/2.0" 400 44 "-" "curl/8.0.1"`},
+		{pos: 5, service: "traefik", data: `2023-10-06T00:17:09Z WRN github.com/traefik/traefik/v3/pkg/provider/docker/pdocker.go:123 > Provider error`, key: model.MetaLog_Traefik, level: model.LogLevel_warn, message: "Provider error"},
+		{pos: 6, service: "hclog", data: `2023-10-06T00:17:09.669Z [WARN] agent: disk almost full`, key: model.MetaLog_Hclog, level: model.LogLevel_warn, message: "disk almost full"},
+		{pos: 7, service: "plain", data: `disk almost full`, key: model.MetaLog_Nop, level: model.LogLevel_unknown, message: "disk almost full"},
+		{pos: 8, service: "tslevelmsg", data: `2023-10-06T00:17:09.669Z WARN agent: disk almost full`, key: model.MetaLog_TsLevelMsg, level: model.LogLevel_warn, message: "agent: disk almost full"},
+		{pos: 9, service: "traefikaccess", data: `{"ClientHost":"10.21.0.1","DownstreamStatus":404,"Duration":1234567,"RequestMethod":"GET","RequestPath":"/v1/jobs","RequestProtocol":"HTTP/2.0","level":"info","msg":"","time":"2023-10-06T00:17:09Z"}`, key: model.MetaLog_Traefik, level: model.LogLevel_warn, message: "GET /v1/jobs HTTP/2.0 404"},
+		{pos: 10, service: "envoyaccess", data: `[2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 503 UF 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"`, key: model.MetaLog_Envoy, level: model.LogLevel_error, message: "POST /api/v1/locations HTTP/2 503 UF"},
+	}
+	for _, test := range tests {
+		log := &model.MetaLog{
+			PatternKey: model.MetaLog_Nop,
+			RawMessage: test.data,
+			EcsLogEntry: &model.EcsLogEntry{
+				Log:     &model.Log{Level: model.LogLevel_not_set, PatternKey: model.MetaLog_Nop.String()},
+				Service: &model.Service{Name: test.service},
+			},
+		}
+		ecs := patternfactory.Parse(log)
+		if log.PatternKey != test.key || ecs.Log.PatternKey != test.key.String() {
+			t.Errorf("Pos %d: expected pattern %s but got %s %s", test.pos, test.key, log.PatternKey, ecs.Log.PatternKey)
+		}
+		if ecs.Log.Level != test.level || ecs.Message != test.message {
+			t.Errorf("Pos %d: expected %s %s but got %s %s", test.pos, test.level, test.message, ecs.Log.Level, ecs.Message)
+		}
+		if ecs.Service.Name != test.service {
+			t.Errorf("Pos %d: expected the service of the ingress but got %s", test.pos, ecs.Service.Name)
+		}
+	}
+	// The winner is cached per service
+	if cached, ok := patternfactory.detectedPatternFor("logfmt@"); !ok || cached.patternKey != model.MetaLog_LogFmt {
+		t.Errorf("Expected the cached logfmt pattern but got %s", cached.patternKey)
+	}
+	if _, ok := patternfactory.detectedPatternFor("plain@"); ok {
+		t.Error("Expected no cached pattern for a log that nothing matches")
+	}
+	// A cached pattern that can't parse the log is detected again
+	log := &model.MetaLog{
+		PatternKey: model.MetaLog_Nop,
+		RawMessage: tests[3].data,
+		EcsLogEntry: &model.EcsLogEntry{
+			Log:     &model.Log{Level: model.LogLevel_not_set},
+			Service: &model.Service{Name: "logfmt"},
+		},
+	}
+	patternfactory.Parse(log)
+	if cached, _ := patternfactory.detectedPatternFor("logfmt@"); log.PatternKey != model.MetaLog_Clf || cached.patternKey != model.MetaLog_Clf {
+		t.Errorf("Expected the detected clf pattern but got %s cached %s", log.PatternKey, cached.patternKey)
+	}
+	// The logs without service are parsed as is
+	log = &model.MetaLog{
+		PatternKey: model.MetaLog_Nop,
+		RawMessage: tests[2].data,
+		EcsLogEntry: &model.EcsLogEntry{
+			Log:     &model.Log{Level: model.LogLevel_not_set},
+			Service: &model.Service{},
+		},
+	}
+	patternfactory.Parse(log)
+	if _, ok := patternfactory.detectedPatternFor("@"); ok || log.PatternKey != model.MetaLog_Nop {
+		t.Errorf("Expected no detection without service but got %s", log.PatternKey)
+	}
+	// The detection stops if the logs of a service don't share a pattern
+	for i := 0; i <= autoDetectMaxMisses+1; i++ {
+		log = &model.MetaLog{
+			PatternKey: model.MetaLog_Nop,
+			RawMessage: tests[2+i%2].data,
+			EcsLogEntry: &model.EcsLogEntry{
+				Log:     &model.Log{Level: model.LogLevel_not_set},
+				Service: &model.Service{Name: "mixed"},
+			},
+		}
+		patternfactory.Parse(log)
+	}
+	if cached, _ := patternfactory.detectedPatternFor("mixed@"); cached.misses != autoDetectMaxMisses || log.PatternKey != model.MetaLog_Nop {
+		t.Errorf("Expected the detection stopped but got %s with %d misses", log.PatternKey, cached.misses)
+	}
+	// The detection starts again after autoDetectRetryAfter
+	cached, _ := patternfactory.detectedPatternFor("mixed@")
+	cached.stopped = cached.stopped.Add(-autoDetectRetryAfter)
+	patternfactory.cacheDetectedPattern("mixed@", cached)
+	log = &model.MetaLog{
+		PatternKey: model.MetaLog_Nop,
+		RawMessage: log.RawMessage,
+		EcsLogEntry: &model.EcsLogEntry{
+			Log:     &model.Log{Level: model.LogLevel_not_set},
+			Service: &model.Service{Name: "mixed"},
+		},
+	}
+	patternfactory.Parse(log)
+	if cached, _ := patternfactory.detectedPatternFor("mixed@"); cached.misses >= autoDetectMaxMisses || log.PatternKey == model.MetaLog_Nop {
+		t.Errorf("Expected the detection started again but got %s with %d misses", log.PatternKey, cached.misses)
+	}
+}
+
+func TestSpringBootPattern(t *testing.T) {
+	ingressTs := time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		pos     int
+		data    string
+		level   model.LogLevel
+		ts      string
+		pid     int64
+		thread  string
+		logger  string
+		message string
+		service string
+		ingress time.Time
+	}{
+		{
+			pos:     1,
+			data:    `2023-10-06 00:17:09.669  INFO 12345 --- [           main] o.s.b.w.embedded.tomcat.TomcatWebServer  : Tomcat started on port(s): 8080 (http)`,
+			level:   model.LogLevel_info,
+			ts:      "2023-10-06T00:17:09.669Z",
+			pid:     12345,
+			thread:  "main",
+			logger:  "o.s.b.w.embedded.tomcat.TomcatWebServer",
+			message: "Tomcat started on port(s): 8080 (http)",
+		},
+		{
+			pos:     2,
+			data:    "2023-10-06T00:17:09.669+02:00 ERROR 7 --- [billing] [nio-8080-exec-1] o.a.c.c.C.[.[.[/].[dispatcherServlet]    : Servlet.service() failed\njava.lang.IllegalStateException: boom\n\tat com.acme.Invoice.create(Invoice.java:42)",
+			level:   model.LogLevel_error,
+			ts:      "2023-10-05T22:17:09.669Z",
+			pid:     7,
+			thread:  "nio-8080-exec-1",
+			logger:  "o.a.c.c.C.[.[.[/].[dispatcherServlet]",
+			message: "Servlet.service() failed\njava.lang.IllegalStateException: boom\n\tat com.acme.Invoice.create(Invoice.java:42)",
+			service: "billing",
+		},
+		{
+			pos:     3,
+			data:    `00:17:09.669 [main] WARN  com.acme.App - Started`,
+			level:   model.LogLevel_warn,
+			ts:      "2023-10-06T00:17:09.669Z",
+			thread:  "main",
+			logger:  "com.acme.App",
+			message: "Started",
+		},
+		{
+			pos:     4,
+			data:    `23:59:59 [main] WARN  com.acme.App - Stopped`,
+			level:   model.LogLevel_warn,
+			ts:      "2023-10-05T23:59:59Z",
+			thread:  "main",
+			logger:  "com.acme.App",
+			message: "Stopped",
+			ingress: time.Date(2023, 10, 6, 0, 0, 1, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		ingress := ingressTs
+		if !test.ingress.IsZero() {
+			ingress = test.ingress
+		}
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.StringToLogPatternKey("springboot"),
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Timestamp: timestamppb.New(ingress), Log: &model.Log{}},
+		})
+		if ecs.HasProcessError() {
+			t.Errorf("Pos %d: expected no parse error but got %s", test.pos, ecs.ProcessError.Reason)
+			continue
+		}
+		if ecs.Log.Level != test.level || ecs.Message != test.message {
+			t.Errorf("Pos %d: expected %s %s but got %s %s", test.pos, test.level, test.message, ecs.Log.Level, ecs.Message)
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339Nano); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		if ecs.Log.ThreadName != test.thread || ecs.Log.Logger != test.logger {
+			t.Errorf("Pos %d: expected thread %s and logger %s but got %s %s", test.pos, test.thread, test.logger, ecs.Log.ThreadName, ecs.Log.Logger)
+		}
+		if ecs.Process.GetPid() != test.pid {
+			t.Errorf("Pos %d: expected pid %d but got %d", test.pos, test.pid, ecs.Process.GetPid())
+		}
+		if ecs.Service.GetName() != test.service {
+			t.Errorf("Pos %d: expected service %s but got %s", test.pos, test.service, ecs.Service.GetName())
+		}
+		if len(ecs.Labels) > 0 {
+			t.Errorf("Pos %d: expected no labels but got %v", test.pos, ecs.Labels)
+		}
+	}
+}
+
+func TestHclogPattern(t *testing.T) {
+	tests := []struct {
+		pos        int
+		data       string
+		level      model.LogLevel
+		ts         string
+		logger     string
+		message    string
+		labels     map[string]string
+		parseError bool
+	}{
+		{
+			pos:     1,
+			data:    `2024-01-01T10:00:00.123+0100 [INFO]  agent: detected plugin: name=java type=driver plugin_version=0.1.0`,
+			level:   model.LogLevel_info,
+			ts:      "2024-01-01T09:00:00Z",
+			logger:  "agent",
+			message: "detected plugin",
+			labels:  map[string]string{"hclog_name": "java", "hclog_type": "driver", "hclog_plugin_version": "0.1.0"},
+		},
+		{
+			pos:     2,
+			data:    `2024-01-01T10:00:00.123Z [WARN]  agent.server.raft: heartbeat timeout reached, starting election: last-leader-addr= last-leader-id="node 1"`,
+			level:   model.LogLevel_warn,
+			ts:      "2024-01-01T10:00:00Z",
+			logger:  "agent.server.raft",
+			message: "heartbeat timeout reached, starting election",
+			labels:  map[string]string{"hclog_last-leader-addr": "", "hclog_last-leader-id": "node 1"},
+		},
+		{
+			pos:     3,
+			data:    `2024-01-01T10:00:00.123Z [ERROR] client.alloc_runner.task_runner: running driver failed: error="failed to pull image" alloc_id=1234`,
+			level:   model.LogLevel_error,
+			ts:      "2024-01-01T10:00:00Z",
+			logger:  "client.alloc_runner.task_runner",
+			message: "running driver failed",
+			labels:  map[string]string{"hclog_alloc_id": "1234"},
+		},
+		{
+			pos:     4,
+			data:    `{"@level":"debug","@message":"detected plugin","@module":"agent","@timestamp":"2024-01-01T10:00:00.123456+01:00","name":"java","health":{"healthy":true}}`,
+			level:   model.LogLevel_debug,
+			ts:      "2024-01-01T09:00:00Z",
+			logger:  "agent",
+			message: "detected plugin",
+			labels:  map[string]string{"hclog_name": "java", "hclog_health.healthy": "true"},
+		},
+		{
+			pos:        5,
+			data:       `level=info msg="no hclog"`,
+			parseError: true,
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.StringToLogPatternKey("hclog"),
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+		})
+		if ecs.HasProcessError() != test.parseError {
+			t.Errorf("Pos %d: expected parse error %t but got %v", test.pos, test.parseError, ecs.ProcessError)
+		}
+		if test.parseError {
+			continue
+		}
+		if ecs.Log.Level != test.level || ecs.Message != test.message {
+			t.Errorf("Pos %d: expected %s %s but got %s %s", test.pos, test.level, test.message, ecs.Log.Level, ecs.Message)
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		if ecs.Log.Logger != test.logger {
+			t.Errorf("Pos %d: expected logger %s but got %s", test.pos, test.logger, ecs.Log.Logger)
+		}
+		for k, v := range test.labels {
+			if label, ok := ecs.Labels[k]; !ok || label != v {
+				t.Errorf("Pos %d: expected label %s=%s but got %v", test.pos, k, v, ecs.Labels)
+			}
+		}
+	}
+}
+
+func TestTraefikAccessPattern(t *testing.T) {
+	tests := []struct {
+		pos      int
+		data     string
+		level    model.LogLevel
+		ts       string
+		message  string
+		method   string
+		status   int64
+		path     string
+		client   string
+		duration int64
+		labels   map[string]string
+	}{
+		{
+			pos:      1,
+			data:     `{"ClientAddr":"10.21.0.1:53422","ClientHost":"10.21.0.1","ClientUsername":"-","DownstreamContentSize":44,"DownstreamStatus":404,"Duration":1234567,"RequestHost":"nomad.cloud.private","RequestMethod":"GET","RequestPath":"/v1/jobs?namespace=default","RequestProtocol":"HTTP/2.0","RouterName":"nomad@consulcatalog","ServiceName":"nomad@consulcatalog","level":"info","msg":"","time":"2023-10-06T00:17:09Z"}`,
+			level:    model.LogLevel_warn,
+			ts:       "2023-10-06T00:17:09Z",
+			message:  "GET /v1/jobs?namespace=default HTTP/2.0 404",
+			method:   "GET",
+			status:   404,
+			path:     "/v1/jobs",
+			client:   "10.21.0.1",
+			duration: 1234567,
+			labels:   map[string]string{"traefik_router": "nomad@consulcatalog", "traefik_service": "nomad@consulcatalog", "traefik_RequestHost": "nomad.cloud.private", "traefik_ClientAddr": "10.21.0.1:53422"},
+		},
+		{
+			pos:      2,
+			data:     `{"ClientHost":"10.21.0.2","DownstreamStatus":502,"Duration":30000000000,"RequestMethod":"POST","RequestPath":"/api","RequestProtocol":"HTTP/1.1","RouterName":"api@docker","level":"info","msg":"","time":"2023-10-06T00:17:10Z"}`,
+			level:    model.LogLevel_error,
+			ts:       "2023-10-06T00:17:10Z",
+			message:  "POST /api HTTP/1.1 502",
+			method:   "POST",
+			status:   502,
+			path:     "/api",
+			client:   "10.21.0.2",
+			duration: 30000000000,
+			labels:   map[string]string{"traefik_router": "api@docker"},
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.MetaLog_Traefik,
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+		})
+		if ecs.HasProcessError() {
+			t.Errorf("Pos %d: expected no parse error but got %s", test.pos, ecs.ProcessError.Reason)
+			continue
+		}
+		if ecs.Log.Level != test.level || ecs.Message != test.message {
+			t.Errorf("Pos %d: expected %s %s but got %s %s", test.pos, test.level, test.message, ecs.Log.Level, ecs.Message)
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		if ecs.Http.GetRequest().GetMethod() != test.method || ecs.Http.GetResponse().GetStatusCode() != test.status {
+			t.Errorf("Pos %d: expected %s %d but got %v", test.pos, test.method, test.status, ecs.Http)
+		}
+		if ecs.Url.GetPath() != test.path || ecs.Source.GetIp() != test.client || ecs.User != nil {
+			t.Errorf("Pos %d: expected path %s from %s but got %v %v %v", test.pos, test.path, test.client, ecs.Url, ecs.Source, ecs.User)
+		}
+		if ecs.Event.GetDuration() != test.duration {
+			t.Errorf("Pos %d: expected duration %d but got %d", test.pos, test.duration, ecs.Event.GetDuration())
+		}
+		for k, v := range test.labels {
+			if label, ok := ecs.Labels[k]; !ok || label != v {
+				t.Errorf("Pos %d: expected label %s=%s but got %v", test.pos, k, v, ecs.Labels)
+			}
+		}
+		if _, ok := ecs.Labels["traefik_level"]; ok {
+			t.Errorf("Pos %d: expected the level of traefik is removed but got %v", test.pos, ecs.Labels)
+		}
+	}
+	// The application log of traefik is not an access log
+	ecs := patternfactory.Parse(&model.MetaLog{
+		PatternKey:  model.MetaLog_Traefik,
+		RawMessage:  `2023-10-06T00:17:09Z WRN github.com/traefik/traefik/v3/pkg/provider/docker/pdocker.go:123 > Provider error`,
+		EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+	})
+	if ecs.HasProcessError() || ecs.Http != nil || ecs.Message != "Provider error" {
+		t.Errorf("Expected the application log of traefik but got %v", ecs)
+	}
+}
+
+func TestEnvoyAccessPattern(t *testing.T) {
+	tests := []struct {
+		pos       int
+		data      string
+		level     model.LogLevel
+		ts        string
+		message   string
+		method    string
+		status    int64
+		client    string
+		duration  int64
+		reason    string
+		requestId string
+		labels    map[string]string
+	}{
+		{
+			pos:       1,
+			data:      `[2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 204 - 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"`,
+			level:     model.LogLevel_info,
+			ts:        "2016-04-15T20:17:00Z",
+			message:   "POST /api/v1/locations HTTP/2 204",
+			method:    "POST",
+			status:    204,
+			client:    "10.0.35.28",
+			duration:  226000000,
+			requestId: "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2",
+			labels:    map[string]string{"envoy_upstream_service_time": "100", "envoy_authority": "locations", "envoy_upstream_host": "tcp://10.0.2.1:80"},
+		},
+		{
+			pos:       2,
+			data:      `{"start_time":"2023-10-06T00:17:09.669Z","route_name":"","method":"GET","path":"/v1/jobs","protocol":"HTTP/1.1","response_code":503,"response_flags":"UF,URX","bytes_received":0,"bytes_sent":91,"duration":12,"upstream_service_time":null,"x_forwarded_for":null,"user_agent":"curl/8.0.1","request_id":"4f1b3c8e","authority":"billing","upstream_host":"10.21.0.7:21000","upstream_cluster":"billing.default.dc1.internal","downstream_remote_address":"10.21.0.1:53422"}`,
+			level:     model.LogLevel_error,
+			ts:        "2023-10-06T00:17:09Z",
+			message:   "GET /v1/jobs HTTP/1.1 503 UF,URX",
+			method:    "GET",
+			status:    503,
+			client:    "10.21.0.1",
+			duration:  12000000,
+			reason:    "UF,URX",
+			requestId: "4f1b3c8e",
+			labels:    map[string]string{"envoy_upstream_cluster": "billing.default.dc1.internal", "envoy_upstream_host": "10.21.0.7:21000"},
+		},
+		{
+			pos:      3,
+			data:     `{"start_time":"2023-10-06T00:17:09.669Z","method":"-","path":"-","protocol":"-","response_code":0,"response_flags":"UF","bytes_received":0,"bytes_sent":0,"duration":3,"upstream_cluster":"db.default.dc1.internal","downstream_remote_address":"10.21.0.1:53422"}`,
+			level:    model.LogLevel_warn,
+			ts:       "2023-10-06T00:17:09Z",
+			message:  "0 UF",
+			client:   "10.21.0.1",
+			duration: 3000000,
+			reason:   "UF",
+			labels:   map[string]string{"envoy_upstream_cluster": "db.default.dc1.internal"},
+		},
+	}
+	for _, test := range tests {
+		ecs := patternfactory.Parse(&model.MetaLog{
+			PatternKey:  model.MetaLog_Envoy,
+			RawMessage:  test.data,
+			EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+		})
+		if ecs.HasProcessError() {
+			t.Errorf("Pos %d: expected no parse error but got %s", test.pos, ecs.ProcessError.Reason)
+			continue
+		}
+		if ecs.Log.Level != test.level || ecs.Message != test.message {
+			t.Errorf("Pos %d: expected %s %s but got %s %s", test.pos, test.level, test.message, ecs.Log.Level, ecs.Message)
+		}
+		if ts := ecs.Timestamp.AsTime().Format(time.RFC3339); ts != test.ts {
+			t.Errorf("Pos %d: expected timestamp %s but got %s", test.pos, test.ts, ts)
+		}
+		if ecs.Http.GetRequest().GetMethod() != test.method || ecs.Http.GetResponse().GetStatusCode() != test.status {
+			t.Errorf("Pos %d: expected %s %d but got %v", test.pos, test.method, test.status, ecs.Http)
+		}
+		if ecs.Source.GetIp() != test.client {
+			t.Errorf("Pos %d: expected client %s but got %v", test.pos, test.client, ecs.Source)
+		}
+		if ecs.Event.GetDuration() != test.duration || ecs.Event.GetReason() != test.reason {
+			t.Errorf("Pos %d: expected duration %d and reason %s but got %v", test.pos, test.duration, test.reason, ecs.Event)
+		}
+		if ecs.Trace.GetTransaction().GetId() != test.requestId {
+			t.Errorf("Pos %d: expected transaction %s but got %v", test.pos, test.requestId, ecs.Trace)
+		}
+		for k, v := range test.labels {
+			if label, ok := ecs.Labels[k]; !ok || label != v {
+				t.Errorf("Pos %d: expected label %s=%s but got %v", test.pos, k, v, ecs.Labels)
+			}
+		}
+		for k, v := range ecs.Labels {
+			if v == "-" || len(v) == 0 {
+				t.Errorf("Pos %d: expected no label without value but got %s", test.pos, k)
+			}
+		}
+	}
+	// The application log of envoy is not an access log
+	ecs := patternfactory.Parse(&model.MetaLog{
+		PatternKey:  model.MetaLog_Envoy,
+		RawMessage:  `[2023-10-06 00:17:09.669][12][info][main] [source/server/server.cc:404] initializing epoch 0`,
+		EcsLogEntry: &model.EcsLogEntry{Log: &model.Log{}},
+	})
+	if ecs.HasProcessError() || ecs.Http != nil || ecs.Log.Level != model.LogLevel_info {
+		t.Errorf("Expected the application log of envoy but got %v", ecs)
+	}
+}
@@ -48,6 +48,9 @@ type GrokPatternExtractor interface {
 	// ErrorInfo Log error information
 	errorInfo() GrokPatternExtractor
 
+	// HttpInfo The request, url, client and user agent of an access log
+	httpInfo() GrokPatternExtractor
+
 	// LogInfo Meta information about the log
 	logInfo() GrokPatternExtractor
 
@@ -78,6 +81,7 @@ func ExtractFrom(extractor GrokPatternExtractor, from *model.MetaLog) *model.Ecs
 		organisationInfo().
 		serviceInfo().
 		errorInfo().
+		httpInfo().
 		logInfo().
 		tracingInfo().
 		extract()
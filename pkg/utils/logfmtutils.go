@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"github.com/grafana/loki/v3/pkg/logql/log/logfmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -133,6 +135,27 @@ func isKey(word string, wholeWord string) (string, bool) {
 //	return result, parseError
 //}
 
+var trailingKeyValues = regexp.MustCompile(`(?s)^(.*?)((?:\s+[\w.\-/]+=(?:"(?:[^"\\]|\\.)*"|[^\s"]*))+)\s*$`)
+var keyValue = regexp.MustCompile(`([\w.\-/]+)=("(?:[^"\\]|\\.)*"|[^\s"]*)`)
+
+// SplitTrailingKeyValues splits the key value pairs at the end of a text message. For example the args of hclog
+// The message is returned unchanged if it does not end with key value pairs. Quoted values are unquoted
+func SplitTrailingKeyValues(message string) (string, map[string]string) {
+	result := make(map[string]string)
+	match := trailingKeyValues.FindStringSubmatch(message)
+	if match == nil {
+		return message, result
+	}
+	for _, kv := range keyValue.FindAllStringSubmatch(match[2], -1) {
+		value := kv[2]
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		result[kv[1]] = value
+	}
+	return match[1], result
+}
+
 func normalizeKeys(key []byte) string {
 
 	lowerKey := strings.ToLower(string(key))
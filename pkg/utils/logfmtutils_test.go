@@ -212,3 +212,23 @@ func TestInValidKvs(t *testing.T) {
 
 	}
 }
+
+func TestSplitTrailingKeyValues(t *testing.T) {
+	tests := []struct {
+		pos     int
+		text    string
+		message string
+		kv      map[string]string
+	}{
+		{pos: 1, text: `detected plugin: name=java type=driver`, message: "detected plugin:", kv: map[string]string{"name": "java", "type": "driver"}},
+		{pos: 2, text: `election: leader-addr= leader-id="node 1"`, message: "election:", kv: map[string]string{"leader-addr": "", "leader-id": "node 1"}},
+		{pos: 3, text: `a=b is not at the end`, message: "a=b is not at the end", kv: map[string]string{}},
+		{pos: 4, text: `request url=http://x?a=b`, message: "request", kv: map[string]string{"url": "http://x?a=b"}},
+	}
+	for _, test := range tests {
+		message, kv := SplitTrailingKeyValues(test.text)
+		if message != test.message || !reflect.DeepEqual(kv, test.kv) {
+			t.Errorf("Pos %d: expected %s %v but got %s %v", test.pos, test.message, test.kv, message, kv)
+		}
+	}
+}
@@ -18,6 +18,8 @@ const (
 	PatternMatchKeyThread     PatterMatch = "thread"
 	PatternMatchKeyOrigin     PatterMatch = "origin"
 	PatternMatchKeyOriginLine PatterMatch = "originline"
+	PatternMatchKeyLogger     PatterMatch = "logger"
+	PatternMatchKeyPid        PatterMatch = "pid"
 )
 
 var patternMatchKeys = map[string]PatterMatch{
@@ -27,6 +29,8 @@ var patternMatchKeys = map[string]PatterMatch{
 	"thread":     PatternMatchKeyThread,
 	"origin":     PatternMatchKeyOrigin,
 	"originline": PatternMatchKeyOriginLine,
+	"logger":     PatternMatchKeyLogger,
+	"pid":        PatternMatchKeyPid,
 }
 
 const (
@@ -35,6 +39,14 @@ const (
 	timeFormatApacheLog  = "timeFormatApacheLog"
 )
 
+// PatternEnvoyAccessLog the default access log format of envoy and the consul connect sidecars. The alternative of the envoy application log
+// For example [2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 204 - 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"
+const PatternEnvoyAccessLog = "ENVOY_ACCESS_LOG"
+
+// PatternLogbackLayout the default layout of Logback and Log4j2. The alternative of the Spring Boot layout
+// For example 2023-10-06 00:17:09.669 [main] INFO  com.acme.App - Started
+const PatternLogbackLayout = "LOGBACK_LAYOUT"
+
 var CustomPatterns = map[string]string{
 	"MULTILINE":        `((\s)*(.*))*`,
 	"LOGLEVEL_KEYWORD": `((?i)trace|(?i)trc|(?i)debug|(?i)dbg|(?i)dbug|(?i)info|(?i)inf|(?i)notice|(?i)wrn|(?i)warn|(?i)warning|(?i)error|(?i)err|(?i)alert|(?i)fatal|(?i)ftl|(?i)emerg|(?i)emergency|(?i)crit|(?i)critical)`,
@@ -52,8 +64,16 @@ var CustomPatterns = map[string]string{
 	"GENERIC_TS":                      "%{TS:timestamp}",
 	model.MetaLog_Envoy.String():      `[",',\[]?%{GENERIC_TS}[",',\]]?[",',\[]?%{NUMBER:thread}[",',\]]?[",',\[]?%{LOGLEVEL_KEYWORD:level}[",',\]]?%{MULTILINE:message}`,
 	model.MetaLog_TsLevelMsg.String(): `[",',\[]?%{GENERIC_TS}[",',\]]? [",',\[]?%{LOGLEVEL_KEYWORD:level}[",',\]]? %{MULTILINE:message}`,
-	model.MetaLog_Clf.String():        `%{IPORHOST:client_ip} %{USER:ident} %{USER:auth} \[%{HTTPDATE:timestamp}\] \"%{WORD:method} %{URIPATHPARAM:request} HTTP/%{NUMBER:http_version}\" %{NUMBER:status_code} %{NUMBER:bytes} \"%{DATA:referrer}\" \"%{DATA:user_agent}\"`,
+	model.MetaLog_Clf.String():        `%{IPORHOST:client_ip} %{USER:ident} %{USER:auth} \[%{HTTPDATE:timestamp}\] \"%{WORD:method} %{URIPATHPARAM:request} HTTP/%{NUMBER:http_version}\" %{NUMBER:status_code} (?:%{NUMBER:bytes}|-) \"%{DATA:referrer}\" \"%{DATA:user_agent}\"`,
 	model.MetaLog_Traefik.String():    `%{TIMESTAMP_ISO8601:timestamp} %{LOGLEVEL_KEYWORD:level} %{DATA:origin}:%{NUMBER:originline} > %{GREEDYDATA:message}`,
+	// 2023-10-06T00:17:09.669+02:00  INFO 12345 --- [billing] [           main] o.s.b.w.embedded.tomcat.TomcatWebServer  : Tomcat started
+	model.MetaLog_SpringBoot.String(): `%{GENERIC_TS}\s+%{LOGLEVEL_KEYWORD:level}\s+%{NUMBER:pid} --- (?:\[%{DATA:application}\] )?\[\s*%{DATA:thread}\] %{NOTSPACE:logger}\s+: %{MULTILINE:message}`,
+	// 2024-01-01T10:00:00.123+0100 [INFO]  agent.server.raft: entering follower state: leader-address= leader-id=
+	model.MetaLog_Hclog.String(): `%{TIMESTAMP_ISO8601:timestamp}\s+\[%{LOGLEVEL_KEYWORD:level}\]\s+(?:%{HCLOG_MODULE:logger}: )?%{MULTILINE:message}`,
+	"HCLOG_MODULE":               `[\w\-]+(?:\.[\w\-]+)*`,
+	PatternEnvoyAccessLog:        `\[%{TIMESTAMP_ISO8601:start_time}\] "%{NOTSPACE:method} %{NOTSPACE:path} %{NOTSPACE:protocol}" %{NUMBER:response_code} %{NOTSPACE:response_flags} %{NUMBER:bytes_received} %{NUMBER:bytes_sent} %{NUMBER:duration} "?%{ENVOY_VALUE:upstream_service_time}"? "%{DATA:x_forwarded_for}" "%{DATA:user_agent}" "%{DATA:request_id}" "%{DATA:authority}" "%{DATA:upstream_host}"`,
+	"ENVOY_VALUE":                `[^\s"]+`,
+	PatternLogbackLayout:         `(?:%{GENERIC_TS}|%{TIME:time}) \[%{DATA:thread}\] %{LOGLEVEL_KEYWORD:level}\s+%{NOTSPACE:logger} - %{MULTILINE:message}`,
 }
 
 func ParseAndGetRegisteredKey(compiler *grok.CompiledGrok, log string) (map[PatterMatch]string, error) {
@@ -105,19 +125,20 @@ var StandardTimeFormats = []string{
 var tsFormatCahce = make(map[string]string)
 
 func cachedLayoutForLog(log *model.MetaLog) (string, bool) {
-	ts, found := tsFormatCahce[cacheKeyForLog(log)]
+	ts, found := tsFormatCahce[CacheKeyForLog(log)]
 	return ts, found
 }
 
 func cacheLayoutForLog(log *model.MetaLog, ts string) {
-	tsFormatCahce[cacheKeyForLog(log)] = ts
+	tsFormatCahce[CacheKeyForLog(log)] = ts
 }
 
 func deleteCachedLayoutForLog(log *model.MetaLog) {
-	delete(tsFormatCahce, cacheKeyForLog(log))
+	delete(tsFormatCahce, CacheKeyForLog(log))
 }
 
-func cacheKeyForLog(log *model.MetaLog) string {
+// CacheKeyForLog the service name and version of the log
+func CacheKeyForLog(log *model.MetaLog) string {
 	if log.EcsLogEntry != nil && log.EcsLogEntry.Service != nil {
 		return log.EcsLogEntry.Service.Name + "@" + log.EcsLogEntry.Service.Version
 	}
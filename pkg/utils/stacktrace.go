@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"github.com/suikast42/logunifier/pkg/model"
+	"regexp"
+	"strings"
+)
+
+var (
+	// at com.acme.Invoice.create(Invoice.java:42) ~[app.jar:?]
+	javaFrame = regexp.MustCompile(`^\s*at\s+([\w$.<>/-]+)\(([\w$. -]*?)(?::(\d+))?\)`)
+	// at Acme.Billing.Invoice.Create(String id) in C:\src\Invoice.cs:line 42
+	dotNetFrame = regexp.MustCompile(`^\s*at\s+(.+?)(?:\s+in\s+(.+):line\s+(\d+))?\s*$`)
+	// File "/app/main.py", line 10, in main
+	pythonFrame = regexp.MustCompile(`^\s+File "(.+)", line (\d+)(?:, in (.+))?$`)
+	// /app/main.go:12 +0x1d
+	goFrameFile = regexp.MustCompile(`^\s+(.+):(\d+)(?:\s+\+0x[0-9a-f]+)?$`)
+	// main.(*Server).run(0xc000010000)
+	goFrameFunction = regexp.MustCompile(`^[\w.*/()\[\]{}-]+\(.*\)$`)
+	// java.lang.IllegalStateException: boom or Caused by: java.io.IOException
+	exceptionHeader = regexp.MustCompile(`^(?:Exception in thread "[^"]*"\s+|Caused by:\s+|Unhandled exception\.\s+)?([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)(?::\s*(.*))?$`)
+	// The python exception at the end of the trace. For example ValueError: invalid literal
+	pythonException = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::\s*(.*))?$`)
+)
+
+const (
+	pythonTraceback = "Traceback (most recent call last):"
+	goPanic         = "panic: "
+	goFatalError    = "fatal error: "
+	goGoroutine     = "goroutine "
+)
+
+// StackTrace a stack trace found in a log message
+type StackTrace struct {
+	// Type the type of the exception. panic for a go panic
+	Type    string
+	Message string
+	// Trace the text of the stack trace from the exception header on
+	Trace  string
+	Frames []*model.Error_Frame
+}
+
+// ParseStackTrace detects a java, .NET, python or go panic stack trace in a multiline text
+func ParseStackTrace(text string) (*StackTrace, bool) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) < 2 {
+		return nil, false
+	}
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, pythonTraceback):
+			return parsePython(lines[i:])
+		case strings.HasPrefix(line, goPanic) || strings.HasPrefix(line, goFatalError):
+			return parseGo(lines[i:])
+		case isAtFrame(line):
+			// The header of the exception is the line before the first frame
+			header := i
+			if i > 0 && len(strings.TrimSpace(lines[i-1])) > 0 {
+				header = i - 1
+			}
+			return parseAtFrames(lines[header:])
+		}
+	}
+	return nil, false
+}
+
+// ParseExceptionHeader the type and the message of a line that starts a stack trace
+func ParseExceptionHeader(line string) (string, string, bool) {
+	switch {
+	case strings.HasPrefix(line, pythonTraceback):
+		return "", "", true
+	case strings.HasPrefix(line, goPanic):
+		return "panic", strings.TrimPrefix(line, goPanic), true
+	case strings.HasPrefix(line, goFatalError):
+		return "fatal error", strings.TrimPrefix(line, goFatalError), true
+	}
+	match := exceptionHeader.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil || !isExceptionType(match[1]) {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// IsStackTraceContinuation true if the line continues a stack trace that is logged line by line
+// A frame, an indented line, a cause or the goroutine of a go panic
+func IsStackTraceContinuation(line string) bool {
+	if len(strings.TrimSpace(line)) == 0 {
+		return false
+	}
+	return line[0] == ' ' || line[0] == '\t' ||
+		strings.HasPrefix(line, "Caused by:") ||
+		strings.HasPrefix(line, goGoroutine) ||
+		goFrameFunction.MatchString(line)
+}
+
+// ParseStackFrame a single frame line of a java, .NET or python stack trace
+func ParseStackFrame(line string) (*model.Error_Frame, bool) {
+	if match := pythonFrame.FindStringSubmatch(line); match != nil {
+		return &model.Error_Frame{Function: match[3], File: match[1], Line: match[2]}, true
+	}
+	if !isAtFrame(line) {
+		return nil, false
+	}
+	return parseAtFrame(line), true
+}
+
+func isAtFrame(line string) bool {
+	trimmed := strings.TrimLeft(line, " \t")
+	return len(trimmed) < len(line) && strings.HasPrefix(trimmed, "at ")
+}
+
+// isExceptionType a type name with a known exception suffix. For example java.lang.IllegalStateException or ValueError
+func isExceptionType(name string) bool {
+	return strings.HasSuffix(name, "Exception") ||
+		strings.HasSuffix(name, "Error") ||
+		strings.HasSuffix(name, "Throwable")
+}
+
+// parseAtFrame a java or .NET frame
+// The parameters of a .NET method contain spaces. A java frame contains the file or (Native Method) and (Unknown Source)
+func parseAtFrame(line string) *model.Error_Frame {
+	if match := javaFrame.FindStringSubmatch(line); match != nil && !strings.Contains(line, ":line ") {
+		location := match[2]
+		if !strings.Contains(location, " ") || location == "Native Method" || location == "Unknown Source" {
+			return &model.Error_Frame{Function: match[1], File: location, Line: match[3]}
+		}
+	}
+	match := dotNetFrame.FindStringSubmatch(line)
+	return &model.Error_Frame{Function: match[1], File: match[2], Line: match[3]}
+}
+
+// parseAtFrames the java or .NET stack trace. The first line is the exception header
+func parseAtFrames(lines []string) (*StackTrace, bool) {
+	result := &StackTrace{}
+	if errorType, message, ok := ParseExceptionHeader(lines[0]); ok {
+		result.Type, result.Message = errorType, message
+	}
+	var trace []string
+	for _, line := range lines {
+		if isAtFrame(line) {
+			result.Frames = append(result.Frames, parseAtFrame(line))
+		} else if len(trace) > 0 && !IsStackTraceContinuation(line) {
+			// The trace ends with the first line that does not belong to it
+			break
+		}
+		trace = append(trace, line)
+	}
+	result.Trace = strings.Join(trace, "\n")
+	return result, len(result.Frames) > 0
+}
+
+// parsePython the lines from the traceback header on. The exception follows the frames
+func parsePython(lines []string) (*StackTrace, bool) {
+	result := &StackTrace{}
+	end := len(lines)
+	for i, line := range lines[1:] {
+		if match := pythonFrame.FindStringSubmatch(line); match != nil {
+			result.Frames = append(result.Frames, &model.Error_Frame{Function: match[3], File: match[1], Line: match[2]})
+			continue
+		}
+		if IsStackTraceContinuation(line) || strings.HasPrefix(line, pythonTraceback) || len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		// The exception of the trace. A chained exception is followed by further tracebacks
+		if match := pythonException.FindStringSubmatch(line); match != nil {
+			result.Type, result.Message = match[1], match[2]
+			end = i + 2
+			continue
+		}
+		// A message between chained tracebacks. For example During handling of the above exception
+		if !strings.HasSuffix(line, ":") {
+			if end == len(lines) {
+				end = i + 1
+			}
+			break
+		}
+	}
+	result.Trace = strings.TrimRight(strings.Join(lines[:end], "\n"), "\n")
+	return result, len(result.Frames) > 0
+}
+
+// parseGo the lines from the panic on. Every frame is a function line followed by the indented file line
+func parseGo(lines []string) (*StackTrace, bool) {
+	result := &StackTrace{}
+	result.Type, result.Message, _ = ParseExceptionHeader(lines[0])
+	end := len(lines)
+	function := ""
+	for i, line := range lines[1:] {
+		if match := goFrameFile.FindStringSubmatch(line); match != nil && len(function) > 0 {
+			result.Frames = append(result.Frames, &model.Error_Frame{Function: function, File: match[1], Line: match[2]})
+			function = ""
+			continue
+		}
+		if goFrameFunction.MatchString(line) {
+			function = line
+			continue
+		}
+		// The function that started the goroutine
+		if created, found := strings.CutPrefix(line, "created by "); found {
+			function, _, _ = strings.Cut(created, " in goroutine")
+			continue
+		}
+		if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(line, goGoroutine) || strings.HasPrefix(line, "[") {
+			continue
+		}
+		if len(result.Frames) > 0 && !IsStackTraceContinuation(line) {
+			end = i + 1
+			break
+		}
+	}
+	result.Trace = strings.TrimRight(strings.Join(lines[:end], "\n"), "\n")
+	return result, len(result.Frames) > 0
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStackTrace(t *testing.T) {
+	tests := []struct {
+		pos        int
+		text       string
+		ok         bool
+		errorType  string
+		message    string
+		frames     int
+		function   string
+		file       string
+		line       string
+		traceStart string
+		traceEnd   string
+	}{
+		{
+			pos: 1,
+			text: "Failed to create invoice\n" +
+				"java.lang.IllegalStateException: invoice closed\n" +
+				"\tat com.acme.Invoice.create(Invoice.java:42) ~[app.jar:?]\n" +
+				"\tat java.base/jdk.internal.reflect.NativeMethodAccessorImpl.invoke0(Native Method)\n" +
+				"Caused by: java.io.IOException: disk full\n" +
+				"\tat com.acme.Store.write(Store.java:7)\n" +
+				"\t... 5 more",
+			ok:         true,
+			errorType:  "java.lang.IllegalStateException",
+			message:    "invoice closed",
+			frames:     3,
+			function:   "com.acme.Invoice.create",
+			file:       "Invoice.java",
+			line:       "42",
+			traceStart: "java.lang.IllegalStateException",
+			traceEnd:   "... 5 more",
+		},
+		{
+			pos: 2,
+			text: "System.InvalidOperationException: Sequence contains no elements\n" +
+				"   at System.Linq.ThrowHelper.ThrowNoElementsException()\n" +
+				"   at Acme.Billing.Invoice.Create(String id) in C:\\src\\Invoice.cs:line 42",
+			ok:         true,
+			errorType:  "System.InvalidOperationException",
+			message:    "Sequence contains no elements",
+			frames:     2,
+			function:   "System.Linq.ThrowHelper.ThrowNoElementsException",
+			traceStart: "System.InvalidOperationException",
+			traceEnd:   "line 42",
+		},
+		{
+			pos: 3,
+			text: "Traceback (most recent call last):\n" +
+				"  File \"/app/main.py\", line 10, in <module>\n" +
+				"    main()\n" +
+				"  File \"/app/main.py\", line 6, in main\n" +
+				"    int(\"a\")\n" +
+				"ValueError: invalid literal for int() with base 10: 'a'",
+			ok:         true,
+			errorType:  "ValueError",
+			message:    "invalid literal for int() with base 10: 'a'",
+			frames:     2,
+			function:   "<module>",
+			file:       "/app/main.py",
+			line:       "10",
+			traceStart: "Traceback",
+			traceEnd:   "'a'",
+		},
+		{
+			pos: 4,
+			text: "panic: runtime error: index out of range [3] with length 3\n" +
+				"\n" +
+				"goroutine 1 [running]:\n" +
+				"main.lookup(...)\n" +
+				"\t/app/main.go:12\n" +
+				"main.main()\n" +
+				"\t/app/main.go:8 +0x1d\n" +
+				"exit status 2",
+			ok:         true,
+			errorType:  "panic",
+			message:    "runtime error: index out of range [3] with length 3",
+			frames:     2,
+			function:   "main.lookup(...)",
+			file:       "/app/main.go",
+			line:       "12",
+			traceStart: "panic",
+			traceEnd:   "+0x1d",
+		},
+		{
+			pos:  5,
+			text: "level=info msg=started\nlevel=info msg=stopped",
+		},
+		{
+			pos:  6,
+			text: "java.lang.IllegalStateException: without frames",
+		},
+	}
+	for _, test := range tests {
+		trace, ok := ParseStackTrace(test.text)
+		if ok != test.ok {
+			t.Errorf("Pos %d: expected %t but got %t", test.pos, test.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if trace.Type != test.errorType || trace.Message != test.message {
+			t.Errorf("Pos %d: expected %s: %s but got %s: %s", test.pos, test.errorType, test.message, trace.Type, trace.Message)
+		}
+		if len(trace.Frames) != test.frames {
+			t.Errorf("Pos %d: expected %d frames but got %d %v", test.pos, test.frames, len(trace.Frames), trace.Frames)
+			continue
+		}
+		frame := trace.Frames[0]
+		if frame.Function != test.function || frame.File != test.file || frame.Line != test.line {
+			t.Errorf("Pos %d: expected frame %s %s:%s but got %v", test.pos, test.function, test.file, test.line, frame)
+		}
+		if !strings.HasPrefix(trace.Trace, test.traceStart) || !strings.HasSuffix(trace.Trace, test.traceEnd) {
+			t.Errorf("Pos %d: expected the trace from %s to %s but got %s", test.pos, test.traceStart, test.traceEnd, trace.Trace)
+		}
+	}
+}